@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/addresslookuptableprog"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func (c *Client) GetAddressLookupTable(ctx context.Context, base58Addr string) (addresslookuptableprog.AddressLookupTable, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, base58Addr)
+	if err != nil {
+		return addresslookuptableprog.AddressLookupTable{}, err
+	}
+	if accountInfo.Owner != common.AddressLookupTableProgramID {
+		return addresslookuptableprog.AddressLookupTable{}, errors.New("owner mismatch")
+	}
+	return addresslookuptableprog.DeserializeLookupTable(accountInfo.Data)
+}
+
+// GetAddressLookupTableAccount fetch a lookup table which can be used in types.NewMessageParam directly
+func (c *Client) GetAddressLookupTableAccount(ctx context.Context, base58Addr string) (types.AddressLookupTableAccount, error) {
+	addressLookupTable, err := c.GetAddressLookupTable(ctx, base58Addr)
+	if err != nil {
+		return types.AddressLookupTableAccount{}, err
+	}
+	return types.AddressLookupTableAccount{
+		Key:       common.PublicKeyFromString(base58Addr),
+		Addresses: addressLookupTable.Addresses,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// BatchGetTransactionResult is the result of a single transaction in a batch, Transaction is nil if it is not found
+type BatchGetTransactionResult struct {
+	Transaction *GetTransactionResponse
+	Err         error
+}
+
+// BatchGetTransaction fetches transactions in a single batch request, results are in the order of txhashes
+func (c *Client) BatchGetTransaction(ctx context.Context, txhashes []string) ([]BatchGetTransactionResult, error) {
+	return c.BatchGetTransactionWithConfig(ctx, txhashes, rpc.GetTransactionConfig{})
+}
+
+// BatchGetTransactionWithConfig fetches transactions in a single batch request, results are in the order of txhashes
+// will ignore encoding, MaxSupportedTransactionVersion defaults to 0
+func (c *Client) BatchGetTransactionWithConfig(ctx context.Context, txhashes []string, cfg rpc.GetTransactionConfig) ([]BatchGetTransactionResult, error) {
+	maxSupportedTransactionVersion := cfg.MaxSupportedTransactionVersion
+	if maxSupportedTransactionVersion == nil {
+		maxSupportedTransactionVersion = pointer.Uint8(0)
+	}
+	rpcCfg := rpc.GetTransactionConfig{
+		Encoding:                       rpc.GetTransactionConfigEncodingBase64,
+		Commitment:                     cfg.Commitment,
+		MaxSupportedTransactionVersion: maxSupportedTransactionVersion,
+	}
+
+	requests := make([]rpc.BatchRequest, 0, len(txhashes))
+	for _, txhash := range txhashes {
+		requests = append(requests, rpc.NewBatchRequest("getTransaction", txhash, rpcCfg))
+	}
+	bodies, err := c.RpcClient.BatchCall(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]BatchGetTransactionResult, 0, len(bodies))
+	for _, body := range bodies {
+		var res rpc.GetTransactionResponse
+		err := json.Unmarshal(body, &res)
+		if err != nil {
+			err = fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+		}
+		tx, err := processGetTransaction(res, err)
+		output = append(output, BatchGetTransactionResult{
+			Transaction: tx,
+			Err:         err,
+		})
+	}
+	return output, nil
+}
+
+// BatchGetAccountInfoResult is the result of a single account in a batch
+type BatchGetAccountInfoResult struct {
+	AccountInfo AccountInfo
+	Err         error
+}
+
+// BatchGetAccountInfo fetches accounts in a single batch request, results are in the order of base58Addrs
+func (c *Client) BatchGetAccountInfo(ctx context.Context, base58Addrs []string) ([]BatchGetAccountInfoResult, error) {
+	return c.BatchGetAccountInfoWithConfig(ctx, base58Addrs, GetAccountInfoConfig{})
+}
+
+// BatchGetAccountInfoWithConfig fetches accounts in a single batch request, results are in the order of base58Addrs
+func (c *Client) BatchGetAccountInfoWithConfig(ctx context.Context, base58Addrs []string, cfg GetAccountInfoConfig) ([]BatchGetAccountInfoResult, error) {
+	rpcCfg := rpc.GetAccountInfoConfig{
+		Encoding:   rpc.GetAccountInfoConfigEncodingBase64,
+		Commitment: cfg.Commitment,
+		DataSlice:  cfg.DataSlice,
+	}
+
+	requests := make([]rpc.BatchRequest, 0, len(base58Addrs))
+	for _, base58Addr := range base58Addrs {
+		requests = append(requests, rpc.NewBatchRequest("getAccountInfo", base58Addr, rpcCfg))
+	}
+	bodies, err := c.RpcClient.BatchCall(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]BatchGetAccountInfoResult, 0, len(bodies))
+	for _, body := range bodies {
+		var res rpc.GetAccountInfoResponse
+		err := json.Unmarshal(body, &res)
+		if err != nil {
+			err = fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+		}
+		accountInfo, err := c.processGetAccountInfo(res, err)
+		output = append(output, BatchGetAccountInfoResult{
+			AccountInfo: accountInfo,
+			Err:         err,
+		})
+	}
+	return output, nil
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_BatchGetAccountInfo(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		base58Addrs []string
+		cfg         GetAccountInfoConfig
+	}
+	tests := []struct {
+		name         string
+		requestBody  string
+		responseBody string
+		args         args
+		want         []BatchGetAccountInfoResult
+		err          error
+	}{
+		{
+			requestBody:  `[{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["F5RYi7FMPefkc7okJNh21Hcsch7RUaLVr8Rzc8SQqxUb", {"encoding":"base64","commitment":"confirmed"}]},{"jsonrpc":"2.0", "id":2, "method":"getAccountInfo", "params":["7AgNbHc1HNgjpfhAHNSpeeKcqsZB1Mx6TqDhqDR5UsfE", {"encoding":"base64","commitment":"confirmed"}]},{"jsonrpc":"2.0", "id":3, "method":"getAccountInfo", "params":["invalid", {"encoding":"base64","commitment":"confirmed"}]}]`,
+			responseBody: `[{"jsonrpc":"2.0","result":{"context":{"slot":77317716},"value":{"data":["AQID","base64"],"executable":false,"lamports":21474700400,"owner":"11111111111111111111111111111111","rentEpoch":178}},"id":1},{"jsonrpc":"2.0","result":{"context":{"slot":77317716},"value":null},"id":2},{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":3}]`,
+			args: args{
+				ctx:         context.Background(),
+				base58Addrs: []string{"F5RYi7FMPefkc7okJNh21Hcsch7RUaLVr8Rzc8SQqxUb", "7AgNbHc1HNgjpfhAHNSpeeKcqsZB1Mx6TqDhqDR5UsfE", "invalid"},
+				cfg: GetAccountInfoConfig{
+					Commitment: rpc.CommitmentConfirmed,
+				},
+			},
+			want: []BatchGetAccountInfoResult{
+				{
+					AccountInfo: AccountInfo{
+						Lamports:  21474700400,
+						Owner:     common.SystemProgramID,
+						RentEpoch: 178,
+						Data:      []byte{1, 2, 3},
+					},
+				},
+				{
+					AccountInfo: AccountInfo{},
+				},
+				{
+					Err: &rpc.JSONRPCError{Code: -32602, Message: "Invalid param: Invalid"},
+				},
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := ioutil.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, tt.requestBody, string(body))
+				n, err := rw.Write([]byte(tt.responseBody))
+				assert.Nil(t, err)
+				assert.Equal(t, len([]byte(tt.responseBody)), n)
+			}))
+			c := NewClient(server.URL)
+			got, err := c.BatchGetAccountInfoWithConfig(tt.args.ctx, tt.args.base58Addrs, tt.args.cfg)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+			server.Close()
+		})
+	}
+}
+
+func TestClient_BatchGetTransaction(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		txhashes []string
+	}
+	tests := []struct {
+		name         string
+		requestBody  string
+		responseBody string
+		args         args
+		want         []BatchGetTransactionResult
+		err          error
+	}{
+		{
+			requestBody:  `[{"jsonrpc":"2.0", "id":1, "method":"getTransaction", "params":["4Dj8Xbs7L6z7pbNp5eGZXLmYZLwePPRVTfunjx2EWDc4nwtVYRq4YqduiFKXR23cGqmbF6LHoubGnKa7gCozstGF", {"encoding":"base64","maxSupportedTransactionVersion":0}]},{"jsonrpc":"2.0", "id":2, "method":"getTransaction", "params":["invalid", {"encoding":"base64","maxSupportedTransactionVersion":0}]}]`,
+			responseBody: `[{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":2},{"jsonrpc":"2.0","result":null,"id":1}]`,
+			args: args{
+				ctx:      context.Background(),
+				txhashes: []string{"4Dj8Xbs7L6z7pbNp5eGZXLmYZLwePPRVTfunjx2EWDc4nwtVYRq4YqduiFKXR23cGqmbF6LHoubGnKa7gCozstGF", "invalid"},
+			},
+			want: []BatchGetTransactionResult{
+				{
+					Transaction: nil,
+				},
+				{
+					Err: &rpc.JSONRPCError{Code: -32602, Message: "Invalid param: Invalid"},
+				},
+			},
+			err: nil,
+		},
+		{
+			requestBody:  `[{"jsonrpc":"2.0", "id":1, "method":"getTransaction", "params":["4Dj8Xbs7L6z7pbNp5eGZXLmYZLwePPRVTfunjx2EWDc4nwtVYRq4YqduiFKXR23cGqmbF6LHoubGnKa7gCozstGF", {"encoding":"base64","maxSupportedTransactionVersion":0}]}]`,
+			responseBody: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`,
+			args: args{
+				ctx:      context.Background(),
+				txhashes: []string{"4Dj8Xbs7L6z7pbNp5eGZXLmYZLwePPRVTfunjx2EWDc4nwtVYRq4YqduiFKXR23cGqmbF6LHoubGnKa7gCozstGF"},
+			},
+			want: nil,
+			err:  errors.New("rpc: batch request failed, code: -32600, message: Invalid request"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := ioutil.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, tt.requestBody, string(body))
+				n, err := rw.Write([]byte(tt.responseBody))
+				assert.Nil(t, err)
+				assert.Equal(t, len([]byte(tt.responseBody)), n)
+			}))
+			c := NewClient(server.URL)
+			got, err := c.BatchGetTransaction(tt.args.ctx, tt.args.txhashes)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+			server.Close()
+		})
+	}
+}
@@ -3,12 +3,13 @@ package client
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/mr-tron/base58"
 	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/pkg/programlog"
 	"github.com/portto/solana-go-sdk/program/tokenprog"
 	"github.com/portto/solana-go-sdk/rpc"
 	"github.com/portto/solana-go-sdk/types"
@@ -16,6 +17,8 @@ import (
 
 type Client struct {
 	RpcClient rpc.RpcClient
+
+	priorityFee *PriorityFeeConfig
 }
 
 func New(opts ...rpc.Option) *Client {
@@ -25,7 +28,7 @@ func New(opts ...rpc.Option) *Client {
 }
 
 func NewClient(endpoint string) *Client {
-	return &Client{rpc.New(rpc.WithEndpoint(endpoint))}
+	return &Client{RpcClient: rpc.New(rpc.WithEndpoint(endpoint))}
 }
 
 // GetBalance fetch users lamports(SOL) balance
@@ -347,9 +350,13 @@ func (c *Client) QuickSendTransaction(ctx context.Context, param QuickSendTransa
 	if err != nil {
 		return "", fmt.Errorf("failed to get recent blockhash, err: %v", err)
 	}
+	instructions, err := c.attachPriorityFee(ctx, param.FeePayer, param.Instructions)
+	if err != nil {
+		return "", err
+	}
 	tx, err := types.NewTransaction(types.NewTransactionParam{
 		Message: types.NewMessage(types.NewMessageParam{
-			Instructions:    param.Instructions,
+			Instructions:    instructions,
 			FeePayer:        param.FeePayer,
 			RecentBlockhash: recentBlockhashRes.Blockhash,
 		}),
@@ -443,6 +450,26 @@ func (c *Client) GetSlotWithConfig(ctx context.Context, cfg rpc.GetSlotConfig) (
 	return res.Result, nil
 }
 
+// GetBlockHeight returns the current block height of the node
+func (c *Client) GetBlockHeight(ctx context.Context) (uint64, error) {
+	res, err := c.RpcClient.GetBlockHeight(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return 0, err
+	}
+	return res.Result, nil
+}
+
+// GetBlockHeightWithConfig returns the current block height of the node by commitment
+func (c *Client) GetBlockHeightWithConfig(ctx context.Context, cfg rpc.GetBlockHeightConfig) (uint64, error) {
+	res, err := c.RpcClient.GetBlockHeightWithConfig(ctx, cfg)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return 0, err
+	}
+	return res.Result, nil
+}
+
 type GetTransactionResponse struct {
 	Slot        uint64
 	Meta        *TransactionMeta
@@ -451,7 +478,7 @@ type GetTransactionResponse struct {
 }
 
 type TransactionMeta struct {
-	Err               interface{}
+	Err               *types.TransactionError
 	Fee               uint64
 	PreBalances       []int64
 	PostBalances      []int64
@@ -459,6 +486,12 @@ type TransactionMeta struct {
 	PostTokenBalances []rpc.TransactionMetaTokenBalance
 	LogMessages       []string
 	InnerInstructions []TransactionMetaInnerInstruction
+	LoadedAddresses   rpc.TransactionLoadedAddresses
+}
+
+// ParseLogs parses LogMessages into the invocation tree of programs
+func (m TransactionMeta) ParseLogs() programlog.Result {
+	return programlog.Parse(m.LogMessages)
 }
 
 type TransactionMetaInnerInstruction struct {
@@ -468,38 +501,39 @@ type TransactionMetaInnerInstruction struct {
 
 // GetTransaction returns transaction details for a confirmed transaction
 func (c *Client) GetTransaction(ctx context.Context, txhash string) (*GetTransactionResponse, error) {
-	res, err := c.RpcClient.GetTransactionWithConfig(
-		ctx,
-		txhash,
-		rpc.GetTransactionConfig{
-			Encoding: rpc.GetTransactionConfigEncodingBase64,
-		},
+	return processGetTransaction(
+		c.RpcClient.GetTransactionWithConfig(
+			ctx,
+			txhash,
+			rpc.GetTransactionConfig{
+				Encoding:                       rpc.GetTransactionConfigEncodingBase64,
+				MaxSupportedTransactionVersion: pointer.Uint8(0),
+			},
+		),
 	)
-	err = checkRpcResult(res.GeneralResponse, err)
-	if err != nil {
-		return nil, err
-	}
-	if res.Result == nil {
-		return nil, nil
-	}
-	tx, err := getTransaction(res)
-	if err != nil {
-		return nil, err
-	}
-	return &tx, nil
 }
 
 // GetTransactionWithConfig returns transaction details for a confirmed transaction
-// will ignore encoding
+// will ignore encoding, MaxSupportedTransactionVersion defaults to 0
 func (c *Client) GetTransactionWithConfig(ctx context.Context, txhash string, cfg rpc.GetTransactionConfig) (*GetTransactionResponse, error) {
-	res, err := c.RpcClient.GetTransactionWithConfig(
-		ctx,
-		txhash,
-		rpc.GetTransactionConfig{
-			Encoding:   rpc.GetTransactionConfigEncodingBase64,
-			Commitment: cfg.Commitment,
-		},
+	maxSupportedTransactionVersion := cfg.MaxSupportedTransactionVersion
+	if maxSupportedTransactionVersion == nil {
+		maxSupportedTransactionVersion = pointer.Uint8(0)
+	}
+	return processGetTransaction(
+		c.RpcClient.GetTransactionWithConfig(
+			ctx,
+			txhash,
+			rpc.GetTransactionConfig{
+				Encoding:                       rpc.GetTransactionConfigEncodingBase64,
+				Commitment:                     cfg.Commitment,
+				MaxSupportedTransactionVersion: maxSupportedTransactionVersion,
+			},
+		),
 	)
+}
+
+func processGetTransaction(res rpc.GetTransactionResponse, err error) (*GetTransactionResponse, error) {
 	err = checkRpcResult(res.GeneralResponse, err)
 	if err != nil {
 		return nil, err
@@ -556,7 +590,7 @@ func getTransaction(res rpc.GetTransactionResponse) (GetTransactionResponse, err
 			})
 		}
 		transactionMeta = &TransactionMeta{
-			Err:               res.Result.Meta.Err,
+			Err:               types.ParseTransactionError(res.Result.Meta.Err),
 			Fee:               res.Result.Meta.Fee,
 			PreBalances:       res.Result.Meta.PreBalances,
 			PostBalances:      res.Result.Meta.PostBalances,
@@ -564,6 +598,7 @@ func getTransaction(res rpc.GetTransactionResponse) (GetTransactionResponse, err
 			PostTokenBalances: res.Result.Meta.PostTokenBalances,
 			LogMessages:       res.Result.Meta.LogMessages,
 			InnerInstructions: innerInstructions,
+			LoadedAddresses:   res.Result.Meta.LoadedAddresses,
 		}
 	}
 
@@ -596,7 +631,8 @@ func (c *Client) GetBlock(ctx context.Context, slot uint64) (GetBlockResponse, e
 		ctx,
 		slot,
 		rpc.GetBlockConfig{
-			Encoding: rpc.GetBlockConfigEncodingBase64,
+			Encoding:                       rpc.GetBlockConfigEncodingBase64,
+			MaxSupportedTransactionVersion: pointer.Uint8(0),
 		},
 	)
 	err = checkRpcResult(res.GeneralResponse, err)
@@ -651,7 +687,7 @@ func getBlock(res rpc.GetBlockResponse) (GetBlockResponse, error) {
 				})
 			}
 			transactionMeta = &TransactionMeta{
-				Err:               rTx.Meta.Err,
+				Err:               types.ParseTransactionError(rTx.Meta.Err),
 				Fee:               rTx.Meta.Fee,
 				PreBalances:       rTx.Meta.PreBalances,
 				PostBalances:      rTx.Meta.PostBalances,
@@ -659,6 +695,7 @@ func getBlock(res rpc.GetBlockResponse) (GetBlockResponse, error) {
 				PostTokenBalances: rTx.Meta.PostTokenBalances,
 				LogMessages:       rTx.Meta.LogMessages,
 				InnerInstructions: innerInstructions,
+				LoadedAddresses:   rTx.Meta.LoadedAddresses,
 			}
 		}
 
@@ -850,9 +887,15 @@ func (c *Client) GetSignatureStatusesWithConfig(ctx context.Context, signatures
 }
 
 type SimulateTransaction struct {
-	Err      interface{}
-	Logs     []string
-	Accounts []*AccountInfo
+	Err           *types.TransactionError
+	Logs          []string
+	Accounts      []*AccountInfo
+	UnitsConsumed *uint64
+}
+
+// ParseLogs parses Logs into the invocation tree of programs
+func (s SimulateTransaction) ParseLogs() programlog.Result {
+	return programlog.Parse(s.Logs)
 }
 
 type SimulateTransactionConfig struct {
@@ -930,9 +973,10 @@ func (c *Client) processSimulateTransaction(res rpc.SimulateTransactionResponse,
 	}
 
 	return SimulateTransaction{
-		Err:      res.Result.Value.Err,
-		Logs:     res.Result.Value.Logs,
-		Accounts: accountInfos,
+		Err:           types.ParseTransactionError(res.Result.Value.Err),
+		Logs:          res.Result.Value.Logs,
+		Accounts:      accountInfos,
+		UnitsConsumed: res.Result.Value.UnitsConsumed,
 	}, nil
 }
 
@@ -957,11 +1001,7 @@ func checkRpcResult(res rpc.GeneralResponse, err error) error {
 		return err
 	}
 	if res.Error != nil {
-		errRes, err := json.Marshal(res.Error)
-		if err != nil {
-			return fmt.Errorf("rpc response error: %v", res.Error)
-		}
-		return fmt.Errorf("rpc response error: %v", string(errRes))
+		return newRpcResponseError(*res.Error)
 	}
 	return nil
 }
@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// GetHealth returns true if the node is healthy, an unhealthy node returns false without an error
+func (c *Client) GetHealth(ctx context.Context) (bool, error) {
+	res, err := c.RpcClient.GetHealth(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		var jsonRPCErr *rpc.JSONRPCError
+		if errors.As(err, &jsonRPCErr) && jsonRPCErr.Code == rpc.ErrorCodeNodeUnhealthy {
+			return false, nil
+		}
+		return false, err
+	}
+	return res.Result == "ok", nil
+}
+
+// GetEpochInfo returns information about the current epoch
+func (c *Client) GetEpochInfo(ctx context.Context) (rpc.GetEpochInfoResponseResult, error) {
+	res, err := c.RpcClient.GetEpochInfo(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return rpc.GetEpochInfoResponseResult{}, err
+	}
+	return res.Result, nil
+}
+
+// GetEpochInfoWithConfig returns information about the current epoch
+func (c *Client) GetEpochInfoWithConfig(ctx context.Context, cfg rpc.GetEpochInfoConfig) (rpc.GetEpochInfoResponseResult, error) {
+	res, err := c.RpcClient.GetEpochInfoWithConfig(ctx, cfg)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return rpc.GetEpochInfoResponseResult{}, err
+	}
+	return res.Result, nil
+}
+
+// LeaderSchedule maps a validator identity to its leader slot indices relative to the first slot of the epoch
+type LeaderSchedule map[common.PublicKey][]uint64
+
+// GetLeaderSchedule returns the leader schedule of the current epoch
+func (c *Client) GetLeaderSchedule(ctx context.Context) (LeaderSchedule, error) {
+	return processGetLeaderSchedule(c.RpcClient.GetLeaderSchedule(ctx))
+}
+
+// GetLeaderScheduleWithConfig returns the leader schedule of the current epoch
+func (c *Client) GetLeaderScheduleWithConfig(ctx context.Context, cfg rpc.GetLeaderScheduleConfig) (LeaderSchedule, error) {
+	return processGetLeaderSchedule(c.RpcClient.GetLeaderScheduleWithConfig(ctx, cfg))
+}
+
+// GetLeaderScheduleBySlot returns the leader schedule of the epoch which the slot is in, it is nil if the epoch is not found
+func (c *Client) GetLeaderScheduleBySlot(ctx context.Context, slot uint64) (LeaderSchedule, error) {
+	return processGetLeaderSchedule(c.RpcClient.GetLeaderScheduleBySlot(ctx, slot))
+}
+
+func processGetLeaderSchedule(res rpc.GetLeaderScheduleResponse, err error) (LeaderSchedule, error) {
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+	if res.Result == nil {
+		return nil, nil
+	}
+	schedule := make(LeaderSchedule, len(res.Result))
+	for identity, slots := range res.Result {
+		schedule[common.PublicKeyFromString(identity)] = slots
+	}
+	return schedule, nil
+}
+
+// GetSlotLeader returns the current slot leader
+func (c *Client) GetSlotLeader(ctx context.Context) (common.PublicKey, error) {
+	res, err := c.RpcClient.GetSlotLeader(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return common.PublicKeyFromString(res.Result), nil
+}
+
+// GetSlotLeaderWithConfig returns the current slot leader
+func (c *Client) GetSlotLeaderWithConfig(ctx context.Context, cfg rpc.GetSlotLeaderConfig) (common.PublicKey, error) {
+	res, err := c.RpcClient.GetSlotLeaderWithConfig(ctx, cfg)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return common.PublicKeyFromString(res.Result), nil
+}
+
+// GetSlotLeaders returns the slot leaders from the start slot, the limit is between 1 and 5,000
+func (c *Client) GetSlotLeaders(ctx context.Context, startSlot uint64, limit uint64) ([]common.PublicKey, error) {
+	res, err := c.RpcClient.GetSlotLeaders(ctx, startSlot, limit)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+	return toPublicKeys(res.Result), nil
+}
+
+type Supply struct {
+	Total                  uint64
+	Circulating            uint64
+	NonCirculating         uint64
+	NonCirculatingAccounts []common.PublicKey
+}
+
+// GetSupply returns information about the current supply
+func (c *Client) GetSupply(ctx context.Context) (Supply, error) {
+	return processGetSupply(c.RpcClient.GetSupply(ctx))
+}
+
+// GetSupplyWithConfig returns information about the current supply
+func (c *Client) GetSupplyWithConfig(ctx context.Context, cfg rpc.GetSupplyConfig) (Supply, error) {
+	return processGetSupply(c.RpcClient.GetSupplyWithConfig(ctx, cfg))
+}
+
+func processGetSupply(res rpc.GetSupplyResponse, err error) (Supply, error) {
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return Supply{}, err
+	}
+	return Supply{
+		Total:                  res.Result.Value.Total,
+		Circulating:            res.Result.Value.Circulating,
+		NonCirculating:         res.Result.Value.NonCirculating,
+		NonCirculatingAccounts: toPublicKeys(res.Result.Value.NonCirculatingAccounts),
+	}, nil
+}
+
+type LargestAccount struct {
+	Address  common.PublicKey
+	Lamports uint64
+}
+
+// GetLargestAccounts returns the 20 largest accounts by lamport balance
+func (c *Client) GetLargestAccounts(ctx context.Context) ([]LargestAccount, error) {
+	return processGetLargestAccounts(c.RpcClient.GetLargestAccounts(ctx))
+}
+
+// GetLargestAccountsWithConfig returns the 20 largest accounts by lamport balance
+func (c *Client) GetLargestAccountsWithConfig(ctx context.Context, cfg rpc.GetLargestAccountsConfig) ([]LargestAccount, error) {
+	return processGetLargestAccounts(c.RpcClient.GetLargestAccountsWithConfig(ctx, cfg))
+}
+
+func processGetLargestAccounts(res rpc.GetLargestAccountsResponse, err error) ([]LargestAccount, error) {
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]LargestAccount, 0, len(res.Result.Value))
+	for _, v := range res.Result.Value {
+		accounts = append(accounts, LargestAccount{
+			Address:  common.PublicKeyFromString(v.Address),
+			Lamports: v.Lamports,
+		})
+	}
+	return accounts, nil
+}
+
+// GetRecentPerformanceSamples returns recent performance samples in reverse slot order
+func (c *Client) GetRecentPerformanceSamples(ctx context.Context) ([]rpc.GetRecentPerformanceSamplesResult, error) {
+	res, err := c.RpcClient.GetRecentPerformanceSamples(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+// GetRecentPerformanceSamplesWithLimit returns at most limit recent performance samples in reverse slot order
+func (c *Client) GetRecentPerformanceSamplesWithLimit(ctx context.Context, limit uint64) ([]rpc.GetRecentPerformanceSamplesResult, error) {
+	res, err := c.RpcClient.GetRecentPerformanceSamplesWithLimit(ctx, limit)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+// GetRecentPrioritizationFees returns prioritization fees of recent blocks for transactions which lock all the
+// accounts as writable. it is the fees of the whole blocks if no account is passed.
+func (c *Client) GetRecentPrioritizationFees(ctx context.Context, accounts []common.PublicKey) ([]rpc.GetRecentPrioritizationFeesResult, error) {
+	addrs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		addrs = append(addrs, account.ToBase58())
+	}
+	res, err := c.RpcClient.GetRecentPrioritizationFees(ctx, addrs)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+// GetHighestSnapshotSlot returns the highest full and incremental snapshot slots of the node
+func (c *Client) GetHighestSnapshotSlot(ctx context.Context) (rpc.GetHighestSnapshotSlotResult, error) {
+	res, err := c.RpcClient.GetHighestSnapshotSlot(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return rpc.GetHighestSnapshotSlotResult{}, err
+	}
+	return res.Result, nil
+}
+
+// GetMaxRetransmitSlot returns the max slot seen from retransmit stage
+func (c *Client) GetMaxRetransmitSlot(ctx context.Context) (uint64, error) {
+	res, err := c.RpcClient.GetMaxRetransmitSlot(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return 0, err
+	}
+	return res.Result, nil
+}
+
+// GetMaxShredInsertSlot returns the max slot seen from after shred insert
+func (c *Client) GetMaxShredInsertSlot(ctx context.Context) (uint64, error) {
+	res, err := c.RpcClient.GetMaxShredInsertSlot(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return 0, err
+	}
+	return res.Result, nil
+}
+
+func toPublicKeys(addrs []string) []common.PublicKey {
+	pubkeys := make([]common.PublicKey, 0, len(addrs))
+	for _, addr := range addrs {
+		pubkeys = append(pubkeys, common.PublicKeyFromString(addr))
+	}
+	return pubkeys
+}
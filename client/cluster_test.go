@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/program/voteprog"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ClusterMethods(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		responseBody string
+		call         func(c *Client) (interface{}, error)
+		want         interface{}
+		err          error
+	}{
+		{
+			name:         "healthy",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getHealth"}`,
+			responseBody: `{"jsonrpc":"2.0","result":"ok","id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetHealth(context.Background())
+			},
+			want: true,
+		},
+		{
+			name:         "unhealthy",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getHealth"}`,
+			responseBody: `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is unhealthy","data":{}},"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetHealth(context.Background())
+			},
+			want: false,
+		},
+		{
+			name:         "get epoch info",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getEpochInfo", "params":[{"minContextSlot":86715000}]}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"absoluteSlot":86715160,"blockHeight":84901536,"epoch":200,"slotIndex":315160,"slotsInEpoch":432000},"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetEpochInfoWithConfig(context.Background(), rpc.GetEpochInfoConfig{MinContextSlot: pointer.Uint64(86715000)})
+			},
+			want: rpc.GetEpochInfoResponseResult{
+				AbsoluteSlot: 86715160,
+				BlockHeight:  84901536,
+				Epoch:        200,
+				SlotIndex:    315160,
+				SlotsInEpoch: 432000,
+			},
+		},
+		{
+			name:         "get leader schedule",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLeaderSchedule"}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F":[0,1,2,3]},"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetLeaderSchedule(context.Background())
+			},
+			want: LeaderSchedule{
+				common.PublicKeyFromString("4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F"): {0, 1, 2, 3},
+			},
+		},
+		{
+			name:         "get leader schedule of an unknown epoch",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLeaderSchedule", "params":[100000000]}`,
+			responseBody: `{"jsonrpc":"2.0","result":null,"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetLeaderScheduleBySlot(context.Background(), 100000000)
+			},
+			want: LeaderSchedule(nil),
+		},
+		{
+			name:         "get slot leaders",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getSlotLeaders", "params":[100, 2]}`,
+			responseBody: `{"jsonrpc":"2.0","result":["ChorusmmK7i1AxXeiTtQgQZhQNiXYU84ULeaYF1EH15n","Awes4Tr6TX8JDzEhCZY2QVNimT6iD1zWHzf1vNyGvpLM"],"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetSlotLeaders(context.Background(), 100, 2)
+			},
+			want: []common.PublicKey{
+				common.PublicKeyFromString("ChorusmmK7i1AxXeiTtQgQZhQNiXYU84ULeaYF1EH15n"),
+				common.PublicKeyFromString("Awes4Tr6TX8JDzEhCZY2QVNimT6iD1zWHzf1vNyGvpLM"),
+			},
+		},
+		{
+			name:         "get supply",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getSupply"}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1114},"value":{"circulating":16000,"nonCirculating":1000000,"nonCirculatingAccounts":["FEy8pTbP5fEoqMV1GdTz83byuJDr2Yx6m5tfjDrRCY1M"],"total":1016000}},"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetSupply(context.Background())
+			},
+			want: Supply{
+				Total:                  1016000,
+				Circulating:            16000,
+				NonCirculating:         1000000,
+				NonCirculatingAccounts: []common.PublicKey{common.PublicKeyFromString("FEy8pTbP5fEoqMV1GdTz83byuJDr2Yx6m5tfjDrRCY1M")},
+			},
+		},
+		{
+			name:         "get largest accounts",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLargestAccounts", "params":[{"filter":"nonCirculating"}]}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":54},"value":[{"lamports":999974,"address":"99P8ZgtJYe1buSK8JXkvpLh8xPsCFuLYhz9hQFNw93WJ"}]},"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetLargestAccountsWithConfig(context.Background(), rpc.GetLargestAccountsConfig{Filter: rpc.GetLargestAccountsConfigFilterNonCirculating})
+			},
+			want: []LargestAccount{
+				{
+					Address:  common.PublicKeyFromString("99P8ZgtJYe1buSK8JXkvpLh8xPsCFuLYhz9hQFNw93WJ"),
+					Lamports: 999974,
+				},
+			},
+		},
+		{
+			name:         "get recent prioritization fees",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getRecentPrioritizationFees", "params":[["CxELquR1gPP8wHe33gZ4QxqGB3sZ9RSwsJ2KshVewkFY"]]}`,
+			responseBody: `{"jsonrpc":"2.0","result":[{"slot":348125,"prioritizationFee":500}],"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetRecentPrioritizationFees(context.Background(), []common.PublicKey{common.PublicKeyFromString("CxELquR1gPP8wHe33gZ4QxqGB3sZ9RSwsJ2KshVewkFY")})
+			},
+			want: []rpc.GetRecentPrioritizationFeesResult{
+				{
+					Slot:              348125,
+					PrioritizationFee: 500,
+				},
+			},
+		},
+		{
+			name:         "get vote accounts",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getVoteAccounts"}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"current":[{"activatedStake":500029968930560,"commission":100,"epochCredits":[[1,64,0],[2,192,64]],"epochVoteAccount":true,"lastVote":147,"nodePubkey":"Cizx4TTBo1gV5Ep1BXPs3ZjJBjBtFnZ8PSDGvpA1yK7F","rootSlot":42,"votePubkey":"3ZT31jkAGhUaw8jsy4bTknwBMP8i4Eueh52By4zXcsVw"}],"delinquent":[]},"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetVoteAccounts(context.Background())
+			},
+			want: VoteAccounts{
+				Current: []VoteAccountInfo{
+					{
+						VotePubkey:       common.PublicKeyFromString("3ZT31jkAGhUaw8jsy4bTknwBMP8i4Eueh52By4zXcsVw"),
+						NodePubkey:       common.PublicKeyFromString("Cizx4TTBo1gV5Ep1BXPs3ZjJBjBtFnZ8PSDGvpA1yK7F"),
+						ActivatedStake:   500029968930560,
+						EpochVoteAccount: true,
+						Commission:       100,
+						LastVote:         147,
+						EpochCredits: []voteprog.EpochCredits{
+							{Epoch: 1, Credits: 64, PrevCredits: 0},
+							{Epoch: 2, Credits: 192, PrevCredits: 64},
+						},
+						RootSlot: 42,
+					},
+				},
+				Delinquent: []VoteAccountInfo{},
+			},
+		},
+		{
+			name:         "get token largest accounts",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenLargestAccounts", "params":["3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E"]}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1114},"value":[{"address":"FYjHNoFtSQ5uijKrZFyYAxvEr87hsKXkXcxkcmkBAf4r","amount":"771","decimals":2,"uiAmount":7.71,"uiAmountString":"7.71"}]},"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetTokenLargestAccounts(context.Background(), "3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E")
+			},
+			want: []TokenLargestAccount{
+				{
+					Address:  common.PublicKeyFromString("FYjHNoFtSQ5uijKrZFyYAxvEr87hsKXkXcxkcmkBAf4r"),
+					Amount:   771,
+					Decimals: 2,
+				},
+			},
+		},
+		{
+			name:         "get stake minimum delegation",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getStakeMinimumDelegation"}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":501},"value":1000000000},"id":1}`,
+			call: func(c *Client) (interface{}, error) {
+				return c.GetStakeMinimumDelegation(context.Background())
+			},
+			want: uint64(1000000000),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := ioutil.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, tt.requestBody, string(body))
+				n, err := rw.Write([]byte(tt.responseBody))
+				assert.Nil(t, err)
+				assert.Equal(t, len([]byte(tt.responseBody)), n)
+			}))
+			defer server.Close()
+			c := NewClient(server.URL)
+			got, err := tt.call(c)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/cmptbdgprog"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DefaultComputeUnitMarginPercent is used if ComputeUnitConfig.MarginPercent is zero
+const DefaultComputeUnitMarginPercent = 10
+
+// simulationBlockhash is used if the message has no recent blockhash, the node replaces it when simulating
+const simulationBlockhash = "11111111111111111111111111111111"
+
+type ComputeUnitConfig struct {
+	// MarginPercent is added to the consumed units, e.g. 10 adds 10%. the default is DefaultComputeUnitMarginPercent
+	MarginPercent uint64
+	// MinUnits is the lowest compute unit limit
+	MinUnits   uint32
+	Commitment rpc.Commitment
+}
+
+type ComputeUnitEstimate struct {
+	// UnitsConsumed is the compute units consumed in the simulation
+	UnitsConsumed uint64
+	// ComputeUnitLimit is UnitsConsumed plus the margin
+	ComputeUnitLimit uint32
+	// Message has a SetComputeUnitLimit instruction with ComputeUnitLimit, sign it instead of the original one
+	Message types.Message
+	// Fee is the total fee of Message in lamports. it is nil if the message has no recent blockhash or the blockhash is not found
+	Fee *uint64
+	// Simulation is the simulation result, it is also returned if the simulation fails
+	Simulation SimulateTransaction
+}
+
+// EstimateComputeUnits simulates the message without verifying signatures and sets its compute unit limit to
+// the consumed units plus a margin. an existing SetComputeUnitLimit instruction is rewritten, otherwise one is
+// prepended. the message is simulated with the max limit so an existing lower limit doesn't fail the simulation.
+func (c *Client) EstimateComputeUnits(ctx context.Context, message types.Message, cfg ComputeUnitConfig) (ComputeUnitEstimate, error) {
+	marginPercent := cfg.MarginPercent
+	if marginPercent == 0 {
+		marginPercent = DefaultComputeUnitMarginPercent
+	}
+
+	simulated := setComputeUnitLimit(message, cmptbdgprog.MaxComputeUnitLimit)
+	if simulated.RecentBlockHash == "" {
+		simulated.RecentBlockHash = simulationBlockhash
+	}
+	signatures := make([]types.Signature, 0, simulated.Header.NumRequireSignatures)
+	for i := uint8(0); i < simulated.Header.NumRequireSignatures; i++ {
+		signatures = append(signatures, make([]byte, 64))
+	}
+	simulation, err := c.SimulateTransactionWithConfig(
+		ctx,
+		types.Transaction{
+			Signatures: signatures,
+			Message:    simulated,
+		},
+		SimulateTransactionConfig{
+			SigVerify:              false,
+			Commitment:             cfg.Commitment,
+			ReplaceRecentBlockhash: true,
+		},
+	)
+	if err != nil {
+		return ComputeUnitEstimate{}, fmt.Errorf("failed to simulate tx, err: %v", err)
+	}
+	if simulation.Err != nil {
+		return ComputeUnitEstimate{Simulation: simulation}, fmt.Errorf("simulation failed, err: %w", simulation.Err)
+	}
+	if simulation.UnitsConsumed == nil {
+		return ComputeUnitEstimate{Simulation: simulation}, fmt.Errorf("simulation result has no consumed units")
+	}
+
+	limit := computeUnitLimit(*simulation.UnitsConsumed, marginPercent, cfg.MinUnits)
+	estimate := ComputeUnitEstimate{
+		UnitsConsumed:    *simulation.UnitsConsumed,
+		ComputeUnitLimit: limit,
+		Message:          setComputeUnitLimit(message, limit),
+		Simulation:       simulation,
+	}
+	if message.RecentBlockHash != "" {
+		estimate.Fee, err = c.GetFeeForMessageWithConfig(ctx, estimate.Message, GetFeeForMessageConfig{
+			Commitment: cfg.Commitment,
+		})
+		if err != nil {
+			return ComputeUnitEstimate{}, fmt.Errorf("failed to get fee for message, err: %v", err)
+		}
+	}
+	return estimate, nil
+}
+
+func computeUnitLimit(unitsConsumed uint64, marginPercent uint64, minUnits uint32) uint32 {
+	limit := uint32(cmptbdgprog.MaxComputeUnitLimit)
+	if unitsConsumed < cmptbdgprog.MaxComputeUnitLimit && marginPercent <= math.MaxUint64/cmptbdgprog.MaxComputeUnitLimit {
+		if units := unitsConsumed + (unitsConsumed*marginPercent+99)/100; units < cmptbdgprog.MaxComputeUnitLimit {
+			limit = uint32(units)
+		}
+	}
+	if limit < minUnits {
+		limit = minUnits
+	}
+	return limit
+}
+
+// setComputeUnitLimit returns a copy of the message with the compute unit limit. the compute budget program is
+// appended to the readonly unsigned static accounts if the message doesn't have it, so indexes of accounts
+// loaded from address lookup tables are shifted by one.
+func setComputeUnitLimit(message types.Message, units uint32) types.Message {
+	data := cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: units}).Data
+
+	m := message
+	m.Accounts = append([]common.PublicKey{}, message.Accounts...)
+	m.Instructions = append([]types.CompiledInstruction{}, message.Instructions...)
+
+	programIDIndex := -1
+	for i, account := range m.Accounts {
+		if account == common.ComputeBudgetProgramID {
+			programIDIndex = i
+			break
+		}
+	}
+
+	if programIDIndex >= 0 {
+		for i, instruction := range m.Instructions {
+			if instruction.ProgramIDIndex == programIDIndex &&
+				len(instruction.Data) > 0 &&
+				cmptbdgprog.Instruction(instruction.Data[0]) == cmptbdgprog.InstructionSetComputeUnitLimit {
+				m.Instructions[i].Data = data
+				return m
+			}
+		}
+	} else {
+		programIDIndex = len(m.Accounts)
+		m.Accounts = append(m.Accounts, common.ComputeBudgetProgramID)
+		m.Header.NumReadonlyUnsignedAccounts++
+		for i, instruction := range m.Instructions {
+			if instruction.ProgramIDIndex >= programIDIndex {
+				m.Instructions[i].ProgramIDIndex++
+			}
+			accounts := make([]int, 0, len(instruction.Accounts))
+			for _, index := range instruction.Accounts {
+				if index >= programIDIndex {
+					index++
+				}
+				accounts = append(accounts, index)
+			}
+			m.Instructions[i].Accounts = accounts
+		}
+	}
+
+	m.Instructions = append([]types.CompiledInstruction{
+		{
+			ProgramIDIndex: programIDIndex,
+			Accounts:       []int{},
+			Data:           data,
+		},
+	}, m.Instructions...)
+	return m
+}
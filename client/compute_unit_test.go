@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/program/cmptbdgprog"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetComputeUnitLimit(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	transfer := sysprog.Transfer(sysprog.TransferParam{From: feePayer, To: to, Amount: 1})
+	limit := func(units uint32) types.Instruction {
+		return cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: units})
+	}
+	price := cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{MicroLamports: 100})
+
+	tests := []struct {
+		name         string
+		instructions []types.Instruction
+		want         []types.Instruction
+	}{
+		{
+			name:         "insert",
+			instructions: []types.Instruction{transfer},
+			want:         []types.Instruction{limit(1000), transfer},
+		},
+		{
+			name:         "insert with the compute budget program",
+			instructions: []types.Instruction{price, transfer},
+			want:         []types.Instruction{limit(1000), price, transfer},
+		},
+		{
+			name:         "rewrite",
+			instructions: []types.Instruction{price, limit(200000), transfer},
+			want:         []types.Instruction{price, limit(1000), transfer},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := types.NewMessage(types.NewMessageParam{
+				FeePayer:        feePayer,
+				Instructions:    tt.instructions,
+				RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			})
+			got := setComputeUnitLimit(message, 1000)
+			assert.Equal(t, tt.want, got.DecompileInstructions())
+			assert.Equal(t, tt.instructions, message.DecompileInstructions())
+		})
+	}
+}
+
+func TestSetComputeUnitLimit_AddressLookupTables(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	lookupTable := types.AddressLookupTableAccount{
+		Key:       common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"),
+		Addresses: []common.PublicKey{to},
+	}
+	transfer := sysprog.Transfer(sysprog.TransferParam{From: feePayer, To: to, Amount: 1})
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:                   feePayer,
+		Instructions:               []types.Instruction{transfer},
+		RecentBlockhash:            "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		AddressLookupTableAccounts: []types.AddressLookupTableAccount{lookupTable},
+	})
+	assert.Len(t, message.AddressLookupTables, 1)
+
+	limited := setComputeUnitLimit(message, 1000)
+	got, err := limited.DecompileInstructionsWithAddressLookupTables([]types.AddressLookupTableAccount{lookupTable})
+	assert.Nil(t, err)
+	assert.Equal(t, []types.Instruction{
+		cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: 1000}),
+		transfer,
+	}, got)
+}
+
+func TestClient_EstimateComputeUnits(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	transfer := sysprog.Transfer(sysprog.TransferParam{From: feePayer, To: to, Amount: 1})
+
+	tests := []struct {
+		name            string
+		responses       map[string][]string
+		recentBlockhash string
+		cfg             ComputeUnitConfig
+		wantLimit       uint32
+		wantFee         *uint64
+		wantErr         bool
+	}{
+		{
+			name: "default margin",
+			responses: map[string][]string{
+				"simulateTransaction": {`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":{"accounts":null,"err":null,"logs":[],"unitsConsumed":450}},"id":1}`},
+				"getFeeForMessage":    {`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":5000},"id":1}`},
+			},
+			recentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			wantLimit:       495,
+			wantFee:         pointer.Uint64(5000),
+		},
+		{
+			name: "min units without blockhash",
+			responses: map[string][]string{
+				"simulateTransaction": {`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":{"accounts":null,"err":null,"logs":[],"unitsConsumed":450}},"id":1}`},
+			},
+			cfg:       ComputeUnitConfig{MarginPercent: 50, MinUnits: 1000},
+			wantLimit: 1000,
+		},
+		{
+			name: "simulation failed",
+			responses: map[string][]string{
+				"simulateTransaction": {`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":{"accounts":null,"err":{"InstructionError":[1,{"Custom":1}]},"logs":[],"unitsConsumed":150}},"id":1}`},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newSequenceServer(t, tt.responses)
+			defer server.Close()
+
+			c := NewClient(server.URL)
+			got, err := c.EstimateComputeUnits(
+				context.Background(),
+				types.NewMessage(types.NewMessageParam{
+					FeePayer:        feePayer,
+					Instructions:    []types.Instruction{transfer},
+					RecentBlockhash: tt.recentBlockhash,
+				}),
+				tt.cfg,
+			)
+			if tt.wantErr {
+				var instructionErr *types.InstructionError
+				assert.True(t, errors.As(err, &instructionErr))
+				assert.NotNil(t, got.Simulation.Err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(450), got.UnitsConsumed)
+			assert.Equal(t, tt.wantLimit, got.ComputeUnitLimit)
+			assert.Equal(t, tt.wantFee, got.Fee)
+			assert.Equal(t, []types.Instruction{
+				cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: tt.wantLimit}),
+				transfer,
+			}, got.Message.DecompileInstructions())
+		})
+	}
+}
+
+func TestComputeUnitLimit(t *testing.T) {
+	tests := []struct {
+		unitsConsumed uint64
+		marginPercent uint64
+		minUnits      uint32
+		want          uint32
+	}{
+		{unitsConsumed: 450, marginPercent: 10, want: 495},
+		{unitsConsumed: 451, marginPercent: 10, want: 497},
+		{unitsConsumed: 450, marginPercent: 10, minUnits: 1000, want: 1000},
+		{unitsConsumed: 1300000, marginPercent: 10, want: cmptbdgprog.MaxComputeUnitLimit},
+		{unitsConsumed: 2000000, marginPercent: 10, want: cmptbdgprog.MaxComputeUnitLimit},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, computeUnitLimit(tt.unitsConsumed, tt.marginPercent, tt.minUnits))
+	}
+}
@@ -0,0 +1,343 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+const (
+	defaultConfirmPollInterval = 2 * time.Second
+	defaultRebroadcastInterval = 2 * time.Second
+)
+
+// TransactionExpiredError means the transaction can never be processed.
+// for a recent blockhash transaction, the block height has exceeded its last valid block height.
+// for a durable nonce transaction, the nonce account has been advanced by others.
+type TransactionExpiredError struct {
+	Signature            string
+	LastValidBlockHeight uint64
+	BlockHeight          uint64
+	NonceAccount         string
+}
+
+func (e *TransactionExpiredError) Error() string {
+	if e.NonceAccount != "" {
+		return fmt.Sprintf("transaction %v expired, nonce account %v has been advanced", e.Signature, e.NonceAccount)
+	}
+	return fmt.Sprintf("transaction %v expired, block height %v exceeds last valid block height %v", e.Signature, e.BlockHeight, e.LastValidBlockHeight)
+}
+
+// TransactionFailedError means the transaction is confirmed but failed, Err is the on-chain transaction error
+// errors.As also works with the transaction error, e.g. *types.InstructionError.
+type TransactionFailedError struct {
+	Signature string
+	Slot      uint64
+	Err       *types.TransactionError
+}
+
+func (e *TransactionFailedError) Error() string {
+	return fmt.Sprintf("transaction %v failed, err: %v", e.Signature, e.Err)
+}
+
+func (e *TransactionFailedError) Unwrap() error {
+	return e.Err
+}
+
+// ConfirmTimeoutError means the transaction is neither confirmed nor expired in Timeout.
+// Err is the last rpc error if the last poll failed, errors.As also works with it, e.g. *rpc.HTTPError.
+type ConfirmTimeoutError struct {
+	Signature string
+	Timeout   time.Duration
+	Err       error
+}
+
+func (e *ConfirmTimeoutError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("transaction %v was not confirmed in %v, last err: %v", e.Signature, e.Timeout, e.Err)
+	}
+	return fmt.Sprintf("transaction %v was not confirmed in %v", e.Signature, e.Timeout)
+}
+
+func (e *ConfirmTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+type ConfirmTransactionConfig struct {
+	// Commitment is the level to wait for, default is confirmed
+	Commitment rpc.Commitment
+	// LastValidBlockHeight comes with the blockhash from GetLatestBlockhash,
+	// the transaction is expired once the block height exceeds it
+	LastValidBlockHeight uint64
+	// NonceAccount and Nonce are used for a durable nonce transaction instead of LastValidBlockHeight,
+	// the transaction is expired once the nonce account no longer holds Nonce
+	NonceAccount string
+	Nonce        string
+	// PollInterval is how often the status is checked, default is 2s
+	PollInterval time.Duration
+	// Timeout is how long to wait at most, zero means waiting until the transaction is confirmed or expired
+	Timeout time.Duration
+}
+
+// ConfirmTransaction waits until the transaction reaches the commitment.
+// it returns a *TransactionFailedError, *TransactionExpiredError or *ConfirmTimeoutError if the transaction can't be confirmed.
+func (c *Client) ConfirmTransaction(ctx context.Context, signature string, cfg ConfirmTransactionConfig) error {
+	return c.confirmTransaction(ctx, signature, cfg, "", 0)
+}
+
+type SendAndConfirmTransactionConfig struct {
+	SkipPreflight       bool
+	PreflightCommitment rpc.Commitment
+	// Commitment is the level to wait for, default is confirmed
+	Commitment rpc.Commitment
+	// LastValidBlockHeight comes with the blockhash from GetLatestBlockhash.
+	// if it is not provided, the one of the latest blockhash is used as an upper bound.
+	// it is ignored for a durable nonce transaction.
+	LastValidBlockHeight uint64
+	// RebroadcastInterval is how often the transaction is sent again until it is confirmed, default is 2s
+	RebroadcastInterval time.Duration
+	// PollInterval is how often the status is checked, default is 2s
+	PollInterval time.Duration
+	// Timeout is how long to wait at most, zero means waiting until the transaction is confirmed or expired
+	Timeout time.Duration
+}
+
+// SendAndConfirmTransaction sends the transaction and waits until it reaches the commitment.
+// a transaction which advances a nonce account in its first instruction is confirmed by the nonce account.
+// the signature is returned even if the transaction can't be confirmed.
+func (c *Client) SendAndConfirmTransaction(ctx context.Context, tx types.Transaction, cfg SendAndConfirmTransactionConfig) (string, error) {
+	rawTx, err := tx.Serialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize tx, err: %v", err)
+	}
+
+	confirmCfg := ConfirmTransactionConfig{
+		Commitment:           cfg.Commitment,
+		LastValidBlockHeight: cfg.LastValidBlockHeight,
+		PollInterval:         cfg.PollInterval,
+		Timeout:              cfg.Timeout,
+	}
+	if nonceAccount, ok := durableNonceAccount(tx.Message); ok {
+		confirmCfg.LastValidBlockHeight = 0
+		confirmCfg.NonceAccount = nonceAccount.ToBase58()
+		confirmCfg.Nonce = tx.Message.RecentBlockHash
+	} else if confirmCfg.LastValidBlockHeight == 0 {
+		// the blockhash of the tx can't be newer than the latest one, so it can't outlive it
+		latestBlockhash, err := c.GetLatestBlockhashWithConfig(ctx, GetLatestBlockhashConfig{
+			Commitment: commitmentOrDefault(cfg.Commitment),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get latest blockhash, err: %v", err)
+		}
+		confirmCfg.LastValidBlockHeight = latestBlockhash.LatestValidBlockHeight
+	}
+
+	encodedTx := base64.StdEncoding.EncodeToString(rawTx)
+	res, err := c.RpcClient.SendTransactionWithConfig(
+		ctx,
+		encodedTx,
+		rpc.SendTransactionConfig{
+			Encoding:            rpc.SendTransactionConfigEncodingBase64,
+			SkipPreflight:       cfg.SkipPreflight,
+			PreflightCommitment: cfg.PreflightCommitment,
+		},
+	)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return "", err
+	}
+
+	rebroadcastInterval := cfg.RebroadcastInterval
+	if rebroadcastInterval == 0 {
+		rebroadcastInterval = defaultRebroadcastInterval
+	}
+	return res.Result, c.confirmTransaction(ctx, res.Result, confirmCfg, encodedTx, rebroadcastInterval)
+}
+
+// confirmTransaction polls the status until the transaction is confirmed or expired.
+// if encodedTx is not empty, it is sent again every rebroadcastInterval.
+func (c *Client) confirmTransaction(ctx context.Context, signature string, cfg ConfirmTransactionConfig, encodedTx string, rebroadcastInterval time.Duration) error {
+	cfg.Commitment = commitmentOrDefault(cfg.Commitment)
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultConfirmPollInterval
+	}
+
+	parentCtx := ctx
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	pollTicker := time.NewTicker(cfg.PollInterval)
+	defer pollTicker.Stop()
+	var rebroadcast <-chan time.Time
+	if encodedTx != "" {
+		rebroadcastTicker := time.NewTicker(rebroadcastInterval)
+		defer rebroadcastTicker.Stop()
+		rebroadcast = rebroadcastTicker.C
+	}
+
+	var lastErr error
+	for {
+		confirmed, err := c.checkConfirmation(ctx, signature, cfg)
+		if ctx.Err() != nil {
+			break
+		}
+		if confirmed || isFinalConfirmError(err) {
+			return err
+		}
+		// rpc errors, e.g. 429 or 5xx, don't mean the transaction won't land so it is polled again
+		lastErr = err
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				break wait
+			case <-pollTicker.C:
+				break wait
+			case <-rebroadcast:
+				// the node may have already dropped or processed it, the status tells the result
+				_, _ = c.RpcClient.SendTransactionWithConfig(ctx, encodedTx, rpc.SendTransactionConfig{
+					Encoding:      rpc.SendTransactionConfigEncodingBase64,
+					SkipPreflight: true,
+				})
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if parentCtx.Err() != nil {
+		return parentCtx.Err()
+	}
+	return &ConfirmTimeoutError{Signature: signature, Timeout: cfg.Timeout, Err: lastErr}
+}
+
+// isFinalConfirmError reports whether the error tells the transaction will never be confirmed
+func isFinalConfirmError(err error) bool {
+	var expiredErr *TransactionExpiredError
+	var failedErr *TransactionFailedError
+	return errors.As(err, &expiredErr) || errors.As(err, &failedErr)
+}
+
+func (c *Client) checkConfirmation(ctx context.Context, signature string, cfg ConfirmTransactionConfig) (bool, error) {
+	status, err := c.GetSignatureStatus(ctx, signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to get signature status, err: %w", err)
+	}
+	if status != nil {
+		if !commitmentReached(status, cfg.Commitment) {
+			return false, nil
+		}
+		if status.Err != nil {
+			return true, &TransactionFailedError{Signature: signature, Slot: status.Slot, Err: types.ParseTransactionError(status.Err)}
+		}
+		return true, nil
+	}
+
+	expiredErr, err := c.checkExpiry(ctx, signature, cfg)
+	if err != nil || expiredErr == nil {
+		return false, err
+	}
+
+	// the transaction may have landed after the status was fetched
+	status, err = c.GetSignatureStatus(ctx, signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to get signature status, err: %w", err)
+	}
+	if status != nil {
+		return false, nil
+	}
+	return false, expiredErr
+}
+
+func (c *Client) checkExpiry(ctx context.Context, signature string, cfg ConfirmTransactionConfig) (*TransactionExpiredError, error) {
+	if cfg.NonceAccount != "" {
+		accountInfo, err := c.GetAccountInfoWithConfig(ctx, cfg.NonceAccount, GetAccountInfoConfig{
+			Commitment: cfg.Commitment,
+			DataSlice: &rpc.GetAccountInfoConfigDataSlice{
+				Offset: 40,
+				Length: 32,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce account, err: %w", err)
+		}
+		if base58.Encode(accountInfo.Data) == cfg.Nonce {
+			return nil, nil
+		}
+		return &TransactionExpiredError{Signature: signature, NonceAccount: cfg.NonceAccount}, nil
+	}
+
+	if cfg.LastValidBlockHeight == 0 {
+		return nil, nil
+	}
+	blockHeight, err := c.GetBlockHeightWithConfig(ctx, rpc.GetBlockHeightConfig{Commitment: cfg.Commitment})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block height, err: %w", err)
+	}
+	if blockHeight <= cfg.LastValidBlockHeight {
+		return nil, nil
+	}
+	return &TransactionExpiredError{
+		Signature:            signature,
+		LastValidBlockHeight: cfg.LastValidBlockHeight,
+		BlockHeight:          blockHeight,
+	}, nil
+}
+
+// durableNonceAccount returns the nonce account if the first instruction advances a nonce account
+func durableNonceAccount(message types.Message) (common.PublicKey, bool) {
+	if len(message.Instructions) == 0 {
+		return common.PublicKey{}, false
+	}
+	instruction := message.Instructions[0]
+	if instruction.ProgramIDIndex >= len(message.Accounts) ||
+		message.Accounts[instruction.ProgramIDIndex] != common.SystemProgramID ||
+		len(instruction.Data) < 4 ||
+		binary.LittleEndian.Uint32(instruction.Data) != uint32(sysprog.InstructionAdvanceNonceAccount) ||
+		len(instruction.Accounts) == 0 ||
+		instruction.Accounts[0] >= len(message.Accounts) {
+		return common.PublicKey{}, false
+	}
+	return message.Accounts[instruction.Accounts[0]], true
+}
+
+func commitmentOrDefault(commitment rpc.Commitment) rpc.Commitment {
+	if commitment == "" {
+		return rpc.CommitmentConfirmed
+	}
+	return commitment
+}
+
+func commitmentReached(status *rpc.GetSignatureStatusesResultValue, commitment rpc.Commitment) bool {
+	if status.ConfirmationStatus == nil {
+		// nodes without confirmationStatus report null confirmations once the block is rooted
+		return status.Confirmations == nil || commitment != rpc.CommitmentFinalized
+	}
+	return commitmentLevel(*status.ConfirmationStatus) >= commitmentLevel(commitment)
+}
+
+func commitmentLevel(commitment rpc.Commitment) int {
+	switch commitment {
+	case rpc.CommitmentProcessed:
+		return 1
+	case rpc.CommitmentConfirmed:
+		return 2
+	case rpc.CommitmentFinalized:
+		return 3
+	}
+	return 0
+}
@@ -0,0 +1,313 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/memoprog"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSignature = "3E6jD48LnMeNDs1QTXXunXGaqYybZKHXYdriDwqXGJbCXzVkMZNexuiGnTtUSba7PcmbKcsxKsAcBKLSmqjUKDRg"
+
+// newSequenceServer replies each method with its responses in order, the last one is repeated
+func newSequenceServer(t *testing.T, responses map[string][]string) (*httptest.Server, func(method string) int) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		assert.Nil(t, err)
+		var r struct {
+			Method string `json:"method"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &r))
+
+		mu.Lock()
+		bodies := responses[r.Method]
+		if len(bodies) == 0 {
+			mu.Unlock()
+			t.Errorf("unexpected method: %v", r.Method)
+			return
+		}
+		i := calls[r.Method]
+		if i >= len(bodies) {
+			i = len(bodies) - 1
+		}
+		calls[r.Method]++
+		mu.Unlock()
+
+		_, err = rw.Write([]byte(bodies[i]))
+		assert.Nil(t, err)
+	}))
+	return server, func(method string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls[method]
+	}
+}
+
+func signatureStatusResponse(status string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","result":{"context":{"slot":86136583},"value":[%v]},"id":1}`, status)
+}
+
+func nonceAccountResponse(nonce string) string {
+	b, _ := base58.Decode(nonce)
+	return fmt.Sprintf(`{"jsonrpc":"2.0","result":{"context":{"slot":86136583},"value":{"data":["%v","base64"],"executable":false,"lamports":1447680,"owner":"11111111111111111111111111111111","rentEpoch":0}},"id":1}`, base64.StdEncoding.EncodeToString(b))
+}
+
+func TestClient_ConfirmTransaction(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string][]string
+		cfg       ConfirmTransactionConfig
+		err       error
+	}{
+		{
+			name: "confirmed",
+			responses: map[string][]string{
+				"getSignatureStatuses": {
+					signatureStatusResponse(`null`),
+					signatureStatusResponse(`{"confirmationStatus":"processed","confirmations":0,"err":null,"slot":86136551}`),
+					signatureStatusResponse(`{"confirmationStatus":"confirmed","confirmations":1,"err":null,"slot":86136551}`),
+				},
+				"getBlockHeight": {`{"jsonrpc":"2.0","result":100,"id":1}`},
+			},
+			cfg: ConfirmTransactionConfig{
+				LastValidBlockHeight: 150,
+			},
+			err: nil,
+		},
+		{
+			name: "finalized",
+			responses: map[string][]string{
+				"getSignatureStatuses": {
+					signatureStatusResponse(`{"confirmationStatus":"confirmed","confirmations":1,"err":null,"slot":86136551}`),
+					signatureStatusResponse(`{"confirmationStatus":"finalized","confirmations":null,"err":null,"slot":86136551}`),
+				},
+			},
+			cfg: ConfirmTransactionConfig{
+				Commitment:           "finalized",
+				LastValidBlockHeight: 150,
+			},
+			err: nil,
+		},
+		{
+			name: "failed",
+			responses: map[string][]string{
+				"getSignatureStatuses": {
+					signatureStatusResponse(`{"confirmationStatus":"confirmed","confirmations":1,"err":{"InstructionError":[0,{"Custom":1}]},"slot":86136551}`),
+				},
+			},
+			cfg: ConfirmTransactionConfig{
+				LastValidBlockHeight: 150,
+			},
+			err: &TransactionFailedError{
+				Signature: testSignature,
+				Slot:      86136551,
+				Err: types.ParseTransactionError(
+					map[string]interface{}{"InstructionError": []interface{}{float64(0), map[string]interface{}{"Custom": float64(1)}}},
+				),
+			},
+		},
+		{
+			name: "expired",
+			responses: map[string][]string{
+				"getSignatureStatuses": {signatureStatusResponse(`null`)},
+				"getBlockHeight": {
+					`{"jsonrpc":"2.0","result":149,"id":1}`,
+					`{"jsonrpc":"2.0","result":151,"id":1}`,
+				},
+			},
+			cfg: ConfirmTransactionConfig{
+				LastValidBlockHeight: 150,
+			},
+			err: &TransactionExpiredError{
+				Signature:            testSignature,
+				LastValidBlockHeight: 150,
+				BlockHeight:          151,
+			},
+		},
+		{
+			name: "landed after the block height is exceeded",
+			responses: map[string][]string{
+				"getSignatureStatuses": {
+					signatureStatusResponse(`null`),
+					signatureStatusResponse(`{"confirmationStatus":"processed","confirmations":0,"err":null,"slot":86136551}`),
+					signatureStatusResponse(`{"confirmationStatus":"confirmed","confirmations":1,"err":null,"slot":86136551}`),
+				},
+				"getBlockHeight": {`{"jsonrpc":"2.0","result":151,"id":1}`},
+			},
+			cfg: ConfirmTransactionConfig{
+				LastValidBlockHeight: 150,
+			},
+			err: nil,
+		},
+		{
+			name: "nonce advanced",
+			responses: map[string][]string{
+				"getSignatureStatuses": {signatureStatusResponse(`null`)},
+				"getAccountInfo": {
+					nonceAccountResponse("9Sc1kQbeRRuqLaL9ajATvd4VjJuPzuKwcV1QwJq2SPXB"),
+					nonceAccountResponse("GNJs4KYEFhMM6fkxkrdqYHb3BRU9GByFPEXWGbCgEDGe"),
+				},
+			},
+			cfg: ConfirmTransactionConfig{
+				NonceAccount: "DT1gzCAEXwDVaj6dJGf1nBkMuGPbeyb7msEpqXaw3dKA",
+				Nonce:        "9Sc1kQbeRRuqLaL9ajATvd4VjJuPzuKwcV1QwJq2SPXB",
+			},
+			err: &TransactionExpiredError{
+				Signature:    testSignature,
+				NonceAccount: "DT1gzCAEXwDVaj6dJGf1nBkMuGPbeyb7msEpqXaw3dKA",
+			},
+		},
+		{
+			name: "rpc errors are retried",
+			responses: map[string][]string{
+				"getSignatureStatuses": {
+					`{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 42 slots","data":{}},"id":1}`,
+					signatureStatusResponse(`null`),
+					signatureStatusResponse(`{"confirmationStatus":"confirmed","confirmations":1,"err":null,"slot":86136551}`),
+				},
+				"getBlockHeight": {
+					`{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 42 slots","data":{}},"id":1}`,
+					`{"jsonrpc":"2.0","result":100,"id":1}`,
+				},
+			},
+			cfg: ConfirmTransactionConfig{
+				LastValidBlockHeight: 150,
+			},
+			err: nil,
+		},
+		{
+			name: "timeout",
+			responses: map[string][]string{
+				"getSignatureStatuses": {signatureStatusResponse(`null`)},
+				"getBlockHeight":       {`{"jsonrpc":"2.0","result":100,"id":1}`},
+			},
+			cfg: ConfirmTransactionConfig{
+				LastValidBlockHeight: 150,
+				Timeout:              50 * time.Millisecond,
+			},
+			err: &ConfirmTimeoutError{
+				Signature: testSignature,
+				Timeout:   50 * time.Millisecond,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newSequenceServer(t, tt.responses)
+			defer server.Close()
+			c := NewClient(server.URL)
+			cfg := tt.cfg
+			cfg.PollInterval = time.Millisecond
+			err := c.ConfirmTransaction(context.Background(), testSignature, cfg)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestClient_ConfirmTransactionHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Retry-After", "1")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	err := c.ConfirmTransaction(context.Background(), testSignature, ConfirmTransactionConfig{
+		PollInterval: time.Millisecond,
+		Timeout:      50 * time.Millisecond,
+	})
+	var timeoutErr *ConfirmTimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	var httpErr *rpc.HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+}
+
+func TestClient_SendAndConfirmTransaction(t *testing.T) {
+	feePayer, _ := types.AccountFromBase58("5HNxRJoirY4oRTcRwiEYFALSSLn9nMAyLQKDuSuiCJ966816BjwGuamRdTLTsR2FBHiB7CQkGaw6B4ehBMogPRvW")
+	nonceAccount := common.PublicKeyFromString("DT1gzCAEXwDVaj6dJGf1nBkMuGPbeyb7msEpqXaw3dKA")
+	nonce := "9Sc1kQbeRRuqLaL9ajATvd4VjJuPzuKwcV1QwJq2SPXB"
+
+	newTx := func(instructions ...types.Instruction) types.Transaction {
+		tx, err := types.NewTransaction(types.NewTransactionParam{
+			Message: types.NewMessage(types.NewMessageParam{
+				FeePayer:        feePayer.PublicKey,
+				RecentBlockhash: nonce,
+				Instructions:    instructions,
+			}),
+			Signers: []types.Account{feePayer},
+		})
+		assert.Nil(t, err)
+		return tx
+	}
+	memo := memoprog.BuildMemo(memoprog.BuildMemoParam{Memo: []byte("memo")})
+	advanceNonce := sysprog.AdvanceNonceAccount(sysprog.AdvanceNonceAccountParam{
+		Nonce: nonceAccount,
+		Auth:  feePayer.PublicKey,
+	})
+
+	t.Run("recent blockhash", func(t *testing.T) {
+		tx := newTx(memo)
+		signature := base58.Encode(tx.Signatures[0])
+		server, calls := newSequenceServer(t, map[string][]string{
+			"getLatestBlockhash": {`{"jsonrpc":"2.0","result":{"context":{"slot":112872139},"value":{"blockhash":"9K6bT3EGc2vrf8ZB6eTKBk5xuBLnMTQz6FqpAnrLdvkS","lastValidBlockHeight":150}},"id":1}`},
+			"sendTransaction":    {fmt.Sprintf(`{"jsonrpc":"2.0","result":"%v","id":1}`, signature)},
+			"getSignatureStatuses": {
+				signatureStatusResponse(`null`),
+				signatureStatusResponse(`null`),
+				signatureStatusResponse(`{"confirmationStatus":"confirmed","confirmations":1,"err":null,"slot":86136551}`),
+			},
+			"getBlockHeight": {`{"jsonrpc":"2.0","result":100,"id":1}`},
+		})
+		defer server.Close()
+
+		c := NewClient(server.URL)
+		got, err := c.SendAndConfirmTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			PollInterval:        20 * time.Millisecond,
+			RebroadcastInterval: time.Millisecond,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, signature, got)
+		assert.Equal(t, 1, calls("getLatestBlockhash"))
+		assert.Greater(t, calls("sendTransaction"), 1)
+	})
+
+	t.Run("durable nonce", func(t *testing.T) {
+		tx := newTx(advanceNonce, memo)
+		signature := base58.Encode(tx.Signatures[0])
+		server, calls := newSequenceServer(t, map[string][]string{
+			"sendTransaction":      {fmt.Sprintf(`{"jsonrpc":"2.0","result":"%v","id":1}`, signature)},
+			"getSignatureStatuses": {signatureStatusResponse(`null`)},
+			"getAccountInfo": {
+				nonceAccountResponse(nonce),
+				nonceAccountResponse("GNJs4KYEFhMM6fkxkrdqYHb3BRU9GByFPEXWGbCgEDGe"),
+			},
+		})
+		defer server.Close()
+
+		c := NewClient(server.URL)
+		got, err := c.SendAndConfirmTransaction(context.Background(), tx, SendAndConfirmTransactionConfig{
+			PollInterval: time.Millisecond,
+		})
+		assert.Equal(t, &TransactionExpiredError{Signature: signature, NonceAccount: nonceAccount.ToBase58()}, err)
+		assert.Equal(t, signature, got)
+		assert.Equal(t, 0, calls("getLatestBlockhash"))
+	})
+}
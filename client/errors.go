@@ -0,0 +1,60 @@
+package client
+
+import (
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// PreflightFailureError is returned when the preflight simulation of sendTransaction fails.
+// errors.As also works with *rpc.JSONRPCError and the transaction error, e.g. *types.InstructionError.
+type PreflightFailureError struct {
+	JSONRPCError  *rpc.JSONRPCError
+	Err           *types.TransactionError
+	Logs          []string
+	UnitsConsumed *uint64
+}
+
+func (e *PreflightFailureError) Error() string {
+	return e.JSONRPCError.Error()
+}
+
+func (e *PreflightFailureError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+func (e *PreflightFailureError) As(target interface{}) bool {
+	if t, ok := target.(**rpc.JSONRPCError); ok {
+		*t = e.JSONRPCError
+		return true
+	}
+	return false
+}
+
+// newRpcResponseError returns a *rpc.JSONRPCError, or a *PreflightFailureError if the simulation fails
+func newRpcResponseError(res rpc.ErrorResponse) error {
+	jsonRPCErr := rpc.NewJSONRPCError(res)
+	if res.Code != rpc.ErrorCodeSendTransactionPreflightFailure || res.Data == nil {
+		return jsonRPCErr
+	}
+
+	preflightFailureErr := &PreflightFailureError{
+		JSONRPCError: jsonRPCErr,
+		Err:          types.ParseTransactionError(res.Data["err"]),
+	}
+	if logs, ok := res.Data["logs"].([]interface{}); ok {
+		preflightFailureErr.Logs = make([]string, 0, len(logs))
+		for _, log := range logs {
+			if s, ok := log.(string); ok {
+				preflightFailureErr.Logs = append(preflightFailureErr.Logs, s)
+			}
+		}
+	}
+	if unitsConsumed, ok := res.Data["unitsConsumed"].(float64); ok && unitsConsumed >= 0 {
+		u := uint64(unitsConsumed)
+		preflightFailureErr.UnitsConsumed = &u
+	}
+	return preflightFailureErr
+}
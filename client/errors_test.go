@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/program/memoprog"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SendTransaction_PreflightFailure(t *testing.T) {
+	feePayer, _ := types.AccountFromBase58("5HNxRJoirY4oRTcRwiEYFALSSLn9nMAyLQKDuSuiCJ966816BjwGuamRdTLTsR2FBHiB7CQkGaw6B4ehBMogPRvW")
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        feePayer.PublicKey,
+			RecentBlockhash: "9K6bT3EGc2vrf8ZB6eTKBk5xuBLnMTQz6FqpAnrLdvkS",
+			Instructions:    []types.Instruction{memoprog.BuildMemo(memoprog.BuildMemoParam{Memo: []byte("memo")})},
+		}),
+		Signers: []types.Account{feePayer},
+	})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name      string
+		responses map[string][]string
+		err       error
+	}{
+		{
+			name: "preflight failure",
+			responses: map[string][]string{
+				"sendTransaction": {`{"jsonrpc":"2.0","error":{"code":-32002,"message":"Transaction simulation failed: Error processing Instruction 0: custom program error: 0x1","data":{"accounts":null,"err":{"InstructionError":[0,{"Custom":1}]},"logs":["Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [1]","Program log: Error: insufficient funds","Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1"],"unitsConsumed":2519}},"id":1}`},
+			},
+			err: &PreflightFailureError{
+				JSONRPCError: &rpc.JSONRPCError{
+					Code:    -32002,
+					Message: "Transaction simulation failed: Error processing Instruction 0: custom program error: 0x1",
+					Data: map[string]interface{}{
+						"accounts":      nil,
+						"err":           map[string]interface{}{"InstructionError": []interface{}{float64(0), map[string]interface{}{"Custom": float64(1)}}},
+						"logs":          []interface{}{"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [1]", "Program log: Error: insufficient funds", "Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1"},
+						"unitsConsumed": float64(2519),
+					},
+				},
+				Err: &types.TransactionError{
+					Err: &types.InstructionError{Index: 0, Err: types.CustomError(1)},
+					Raw: map[string]interface{}{"InstructionError": []interface{}{float64(0), map[string]interface{}{"Custom": float64(1)}}},
+				},
+				Logs:          []string{"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [1]", "Program log: Error: insufficient funds", "Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1"},
+				UnitsConsumed: pointer.Uint64(2519),
+			},
+		},
+		{
+			name: "other rpc error",
+			responses: map[string][]string{
+				"sendTransaction": {`{"jsonrpc":"2.0","error":{"code":-32003,"message":"Transaction signature verification failure"},"id":1}`},
+			},
+			err: &rpc.JSONRPCError{
+				Code:    -32003,
+				Message: "Transaction signature verification failure",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newSequenceServer(t, tt.responses)
+			defer server.Close()
+			c := NewClient(server.URL)
+			_, err := c.SendTransaction(context.Background(), tx)
+			assert.Equal(t, tt.err, err)
+
+			var jsonRPCErr *rpc.JSONRPCError
+			assert.True(t, errors.As(err, &jsonRPCErr))
+			assert.Equal(t, tt.err.Error(), jsonRPCErr.Error())
+		})
+	}
+
+	t.Run("errors.As the custom error", func(t *testing.T) {
+		server, _ := newSequenceServer(t, tests[0].responses)
+		defer server.Close()
+		c := NewClient(server.URL)
+		_, err := c.SendTransaction(context.Background(), tx)
+
+		var instructionErr *types.InstructionError
+		assert.True(t, errors.As(err, &instructionErr))
+		assert.Equal(t, uint8(0), instructionErr.Index)
+
+		var customErr types.CustomError
+		assert.True(t, errors.As(err, &customErr))
+		assert.Equal(t, types.CustomError(1), customErr)
+	})
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/cmptbdgprog"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DefaultPriorityFeePercentile is used if PriorityFeeConfig.Percentile is zero
+const DefaultPriorityFeePercentile = 75
+
+type PriorityFeeConfig struct {
+	// Percentile of recent prioritization fees to pay, between 1 and 100. the default is 75
+	Percentile uint8
+	// MinMicroLamports is the lowest price per compute unit
+	MinMicroLamports uint64
+	// MaxMicroLamports caps the price per compute unit, 0 means no cap
+	MaxMicroLamports uint64
+	// ComputeUnitLimit adds a SetComputeUnitLimit instruction if it is not zero
+	ComputeUnitLimit uint32
+	// MaxLamports caps the priority fee of a tx which is the price times ComputeUnitLimit,
+	// it only works with ComputeUnitLimit. 0 means no cap
+	MaxLamports uint64
+}
+
+type PriorityFeeEstimate struct {
+	// P50, P75 and P90 are percentiles of recent prioritization fees in micro-lamports per compute unit
+	P50 uint64
+	P75 uint64
+	P90 uint64
+	// MicroLamports is the price per compute unit to pay after caps are applied
+	MicroLamports uint64
+	// Instructions are compute budget instructions which should be prepended to the tx
+	Instructions []types.Instruction
+}
+
+// EstimatePriorityFee estimates a compute unit price by recent prioritization fees of the writable accounts
+// of the message. accounts loaded from address lookup tables are not taken into account.
+func (c *Client) EstimatePriorityFee(ctx context.Context, message types.Message, cfg PriorityFeeConfig) (PriorityFeeEstimate, error) {
+	return c.EstimatePriorityFeeForAccounts(ctx, message.WritableAccounts(), cfg)
+}
+
+// EstimatePriorityFeeForAccounts estimates a compute unit price by recent prioritization fees of the accounts
+func (c *Client) EstimatePriorityFeeForAccounts(ctx context.Context, writableAccounts []common.PublicKey, cfg PriorityFeeConfig) (PriorityFeeEstimate, error) {
+	if cfg.Percentile > 100 {
+		return PriorityFeeEstimate{}, fmt.Errorf("invalid percentile: %v", cfg.Percentile)
+	}
+	res, err := c.GetRecentPrioritizationFees(ctx, writableAccounts)
+	if err != nil {
+		return PriorityFeeEstimate{}, fmt.Errorf("failed to get recent prioritization fees, err: %v", err)
+	}
+	fees := make([]uint64, 0, len(res))
+	for _, v := range res {
+		fees = append(fees, v.PrioritizationFee)
+	}
+	return EstimatePriorityFeeFromFees(fees, cfg), nil
+}
+
+// EstimatePriorityFeeFromFees estimates a compute unit price by prioritization fees, it doesn't call the rpc
+func EstimatePriorityFeeFromFees(fees []uint64, cfg PriorityFeeConfig) PriorityFeeEstimate {
+	sorted := make([]uint64, len(fees))
+	copy(sorted, fees)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := cfg.Percentile
+	if percentile == 0 {
+		percentile = DefaultPriorityFeePercentile
+	}
+
+	price := feePercentile(sorted, percentile)
+	if price < cfg.MinMicroLamports {
+		price = cfg.MinMicroLamports
+	}
+	if cfg.MaxMicroLamports != 0 && price > cfg.MaxMicroLamports {
+		price = cfg.MaxMicroLamports
+	}
+	if cfg.MaxLamports != 0 && cfg.ComputeUnitLimit != 0 && cfg.MaxLamports <= math.MaxUint64/1_000_000 {
+		if maxPrice := cfg.MaxLamports * 1_000_000 / uint64(cfg.ComputeUnitLimit); price > maxPrice {
+			price = maxPrice
+		}
+	}
+
+	instructions := []types.Instruction{}
+	if cfg.ComputeUnitLimit != 0 {
+		instructions = append(instructions, cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{
+			Units: cfg.ComputeUnitLimit,
+		}))
+	}
+	if price != 0 {
+		instructions = append(instructions, cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{
+			MicroLamports: price,
+		}))
+	}
+
+	return PriorityFeeEstimate{
+		P50:           feePercentile(sorted, 50),
+		P75:           feePercentile(sorted, 75),
+		P90:           feePercentile(sorted, 90),
+		MicroLamports: price,
+		Instructions:  instructions,
+	}
+}
+
+// feePercentile returns the nearest-rank percentile of sorted fees
+func feePercentile(sorted []uint64, percentile uint8) uint64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(float64(percentile) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// SetPriorityFee makes the client prepend compute budget instructions estimated by the config when it builds
+// a tx, e.g. QuickSendTransaction. instructions which already have a compute budget instruction are kept as they are.
+// a nil config disables it.
+func (c *Client) SetPriorityFee(cfg *PriorityFeeConfig) {
+	c.priorityFee = cfg
+}
+
+func (c *Client) attachPriorityFee(ctx context.Context, feePayer common.PublicKey, instructions []types.Instruction) ([]types.Instruction, error) {
+	if c.priorityFee == nil {
+		return instructions, nil
+	}
+	for _, instruction := range instructions {
+		if instruction.ProgramID == common.ComputeBudgetProgramID {
+			return instructions, nil
+		}
+	}
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:     feePayer,
+		Instructions: instructions,
+	})
+	estimate, err := c.EstimatePriorityFee(ctx, message, *c.priorityFee)
+	if err != nil {
+		return nil, err
+	}
+	return append(estimate.Instructions, instructions...), nil
+}
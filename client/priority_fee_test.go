@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/cmptbdgprog"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimatePriorityFeeFromFees(t *testing.T) {
+	fees := []uint64{0, 0, 100, 200, 300, 400, 500, 600, 700, 1000}
+	tests := []struct {
+		name string
+		fees []uint64
+		cfg  PriorityFeeConfig
+		want PriorityFeeEstimate
+	}{
+		{
+			name: "default percentile",
+			fees: fees,
+			want: PriorityFeeEstimate{
+				P50:           300,
+				P75:           600,
+				P90:           700,
+				MicroLamports: 600,
+				Instructions: []types.Instruction{
+					cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{MicroLamports: 600}),
+				},
+			},
+		},
+		{
+			name: "max micro lamports",
+			fees: fees,
+			cfg: PriorityFeeConfig{
+				Percentile:       90,
+				MaxMicroLamports: 650,
+				ComputeUnitLimit: 200000,
+			},
+			want: PriorityFeeEstimate{
+				P50:           300,
+				P75:           600,
+				P90:           700,
+				MicroLamports: 650,
+				Instructions: []types.Instruction{
+					cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: 200000}),
+					cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{MicroLamports: 650}),
+				},
+			},
+		},
+		{
+			name: "max lamports",
+			fees: fees,
+			cfg: PriorityFeeConfig{
+				Percentile:       100,
+				ComputeUnitLimit: 400000,
+				MaxLamports:      100,
+			},
+			want: PriorityFeeEstimate{
+				P50:           300,
+				P75:           600,
+				P90:           700,
+				MicroLamports: 250,
+				Instructions: []types.Instruction{
+					cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: 400000}),
+					cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{MicroLamports: 250}),
+				},
+			},
+		},
+		{
+			name: "min micro lamports",
+			fees: []uint64{0, 0, 0},
+			cfg: PriorityFeeConfig{
+				MinMicroLamports: 10,
+			},
+			want: PriorityFeeEstimate{
+				MicroLamports: 10,
+				Instructions: []types.Instruction{
+					cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{MicroLamports: 10}),
+				},
+			},
+		},
+		{
+			name: "no fees",
+			fees: nil,
+			want: PriorityFeeEstimate{
+				Instructions: []types.Instruction{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EstimatePriorityFeeFromFees(tt.fees, tt.cfg))
+		})
+	}
+}
+
+func TestClient_EstimatePriorityFee(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"jsonrpc":"2.0", "id":1, "method":"getRecentPrioritizationFees", "params":[["EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7","A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"]]}`, string(body))
+		_, err = rw.Write([]byte(`{"jsonrpc":"2.0","result":[{"slot":1,"prioritizationFee":0},{"slot":2,"prioritizationFee":100},{"slot":3,"prioritizationFee":200},{"slot":4,"prioritizationFee":300}],"id":1}`))
+		assert.Nil(t, err)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	got, err := c.EstimatePriorityFee(
+		context.Background(),
+		types.NewMessage(types.NewMessageParam{
+			FeePayer: feePayer,
+			Instructions: []types.Instruction{
+				sysprog.Transfer(sysprog.TransferParam{From: feePayer, To: to, Amount: 1}),
+			},
+			RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		}),
+		PriorityFeeConfig{Percentile: 50},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, PriorityFeeEstimate{
+		P50:           100,
+		P75:           200,
+		P90:           300,
+		MicroLamports: 100,
+		Instructions: []types.Instruction{
+			cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{MicroLamports: 100}),
+		},
+	}, got)
+}
+
+func TestClient_QuickSendTransactionWithPriorityFee(t *testing.T) {
+	feePayer, _ := types.AccountFromBase58("4voSPg3tYuWbKzimpQK9EbXHmuyy5fUrtXvpLDMLkmY6TRncaTHAKGD8jUg3maB5Jbrd9CkQg4qjJMyN6sQvnEF2")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+
+	var sent types.Transaction
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		assert.Nil(t, err)
+		var r struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &r))
+		switch r.Method {
+		case "getRecentBlockhash":
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":{"blockhash":"FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5","feeCalculator":{"lamportsPerSignature":5000}}},"id":1}`))
+		case "getRecentPrioritizationFees":
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":[{"slot":1,"prioritizationFee":1000}],"id":1}`))
+		case "sendTransaction":
+			rawTx, err := base64.StdEncoding.DecodeString(r.Params[0].(string))
+			assert.Nil(t, err)
+			sent, err = types.TransactionDeserialize(rawTx)
+			assert.Nil(t, err)
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":"` + testSignature + `","id":1}`))
+		default:
+			t.Errorf("unexpected method: %v", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	c.SetPriorityFee(&PriorityFeeConfig{ComputeUnitLimit: 1000})
+	sig, err := c.QuickSendTransaction(context.Background(), QuickSendTransactionParam{
+		Instructions: []types.Instruction{
+			sysprog.Transfer(sysprog.TransferParam{From: feePayer.PublicKey, To: to, Amount: 1}),
+		},
+		Signers:  []types.Account{feePayer},
+		FeePayer: feePayer.PublicKey,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, testSignature, sig)
+	assert.Equal(t, []types.Instruction{
+		cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: 1000}),
+		cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{MicroLamports: 1000}),
+		sysprog.Transfer(sysprog.TransferParam{From: feePayer.PublicKey, To: to, Amount: 1}),
+	}, sent.Message.DecompileInstructions())
+}
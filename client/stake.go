@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/stakeprog"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// GetStakeAccount returns the state of a stake account
+func (c *Client) GetStakeAccount(ctx context.Context, base58Addr string) (stakeprog.StakeState, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, base58Addr)
+	if err != nil {
+		return stakeprog.StakeState{}, err
+	}
+	if accountInfo.Owner != common.StakeProgramID {
+		return stakeprog.StakeState{}, errors.New("owner mismatch")
+	}
+	return stakeprog.StakeStateDeserialize(accountInfo.Data)
+}
+
+type StakeActivation struct {
+	State    stakeprog.StakeActivationState
+	Active   uint64
+	Inactive uint64
+}
+
+type GetStakeActivationConfig struct {
+	// Epoch is the epoch to calculate activation for, default is the current epoch
+	Epoch *uint64
+	// NewRateActivationEpoch is the epoch which the reduce_stake_warmup_cooldown feature is activated at
+	NewRateActivationEpoch *uint64
+}
+
+// GetStakeActivation calculates activation of a stake account locally by the stake history sysvar.
+// it is a replacement of the deprecated getStakeActivation.
+func (c *Client) GetStakeActivation(ctx context.Context, base58Addr string) (StakeActivation, error) {
+	return c.GetStakeActivationWithConfig(ctx, base58Addr, GetStakeActivationConfig{})
+}
+
+// GetStakeActivationWithConfig calculates activation of a stake account locally by the stake history sysvar
+func (c *Client) GetStakeActivationWithConfig(ctx context.Context, base58Addr string, cfg GetStakeActivationConfig) (StakeActivation, error) {
+	accountInfos, err := c.GetMultipleAccounts(ctx, []string{base58Addr, common.SysVarStakeHistoryPubkey.ToBase58()})
+	if err != nil {
+		return StakeActivation{}, err
+	}
+	stakeAccountInfo, stakeHistoryAccountInfo := accountInfos[0], accountInfos[1]
+	if stakeAccountInfo.Owner != common.StakeProgramID {
+		return StakeActivation{}, errors.New("owner mismatch")
+	}
+	stakeState, err := stakeprog.StakeStateDeserialize(stakeAccountInfo.Data)
+	if err != nil {
+		return StakeActivation{}, fmt.Errorf("failed to deserialize stake account, err: %v", err)
+	}
+	if stakeState.Meta == nil {
+		return StakeActivation{}, errors.New("stake account is not initialized")
+	}
+	stakeHistory, err := stakeprog.StakeHistoryDeserialize(stakeHistoryAccountInfo.Data)
+	if err != nil {
+		return StakeActivation{}, fmt.Errorf("failed to deserialize stake history, err: %v", err)
+	}
+
+	epoch := cfg.Epoch
+	if epoch == nil {
+		res, err := c.RpcClient.GetEpochInfo(ctx)
+		err = checkRpcResult(res.GeneralResponse, err)
+		if err != nil {
+			return StakeActivation{}, err
+		}
+		epoch = &res.Result.Epoch
+	}
+
+	return calculateStakeActivation(stakeAccountInfo.Lamports, stakeState, *epoch, stakeHistory, cfg.NewRateActivationEpoch), nil
+}
+
+func calculateStakeActivation(lamports uint64, stakeState stakeprog.StakeState, epoch uint64, stakeHistory stakeprog.StakeHistory, newRateActivationEpoch *uint64) StakeActivation {
+	rentExemptReserve := stakeState.Meta.RentExemptReserve
+	if stakeState.Stake == nil {
+		return StakeActivation{
+			State:    stakeprog.StakeActivationStateInactive,
+			Inactive: saturatingSub(lamports, rentExemptReserve),
+		}
+	}
+
+	status := stakeState.Stake.Delegation.StakeActivatingAndDeactivating(epoch, stakeHistory, newRateActivationEpoch)
+	activation := StakeActivation{
+		State:  status.State(),
+		Active: status.Effective,
+	}
+	switch activation.State {
+	case stakeprog.StakeActivationStateActivating:
+		activation.Inactive = status.Activating
+	case stakeprog.StakeActivationStateActive, stakeprog.StakeActivationStateDeactivating:
+		activation.Inactive = saturatingSub(saturatingSub(lamports, status.Effective), rentExemptReserve)
+	default:
+		activation.Inactive = saturatingSub(lamports, rentExemptReserve)
+	}
+	return activation
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// GetStakeMinimumDelegation returns the stake minimum delegation, in lamports
+func (c *Client) GetStakeMinimumDelegation(ctx context.Context) (uint64, error) {
+	res, err := c.RpcClient.GetStakeMinimumDelegation(ctx)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return 0, err
+	}
+	return res.Result.Value, nil
+}
+
+// GetStakeMinimumDelegationWithConfig returns the stake minimum delegation, in lamports
+func (c *Client) GetStakeMinimumDelegationWithConfig(ctx context.Context, cfg rpc.GetStakeMinimumDelegationConfig) (uint64, error) {
+	res, err := c.RpcClient.GetStakeMinimumDelegationWithConfig(ctx, cfg)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return 0, err
+	}
+	return res.Result.Value, nil
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"math"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/program/stakeprog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateStakeActivation(t *testing.T) {
+	meta := &stakeprog.Meta{RentExemptReserve: 100}
+	history := stakeprog.StakeHistory{
+		{Epoch: 10, StakeHistoryEntry: stakeprog.StakeHistoryEntry{Effective: 1000, Activating: 1000}},
+	}
+	stakeState := func(activationEpoch, deactivationEpoch uint64) stakeprog.StakeState {
+		return stakeprog.StakeState{
+			Type: stakeprog.StakeStateTypeStake,
+			Meta: meta,
+			Stake: &stakeprog.Stake{
+				Delegation: stakeprog.Delegation{Stake: 1000, ActivationEpoch: activationEpoch, DeactivationEpoch: deactivationEpoch},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		lamports   uint64
+		stakeState stakeprog.StakeState
+		epoch      uint64
+		want       StakeActivation
+	}{
+		{
+			name:       "initialized",
+			lamports:   1100,
+			stakeState: stakeprog.StakeState{Type: stakeprog.StakeStateTypeInitialized, Meta: meta},
+			epoch:      11,
+			want:       StakeActivation{State: stakeprog.StakeActivationStateInactive, Inactive: 1000},
+		},
+		{
+			name:       "activating",
+			lamports:   1100,
+			stakeState: stakeState(10, math.MaxUint64),
+			epoch:      11,
+			want:       StakeActivation{State: stakeprog.StakeActivationStateActivating, Active: 250, Inactive: 750},
+		},
+		{
+			name:       "active",
+			lamports:   1200,
+			stakeState: stakeState(0, math.MaxUint64),
+			epoch:      11,
+			want:       StakeActivation{State: stakeprog.StakeActivationStateActive, Active: 1000, Inactive: 100},
+		},
+		{
+			name:       "deactivating",
+			lamports:   1100,
+			stakeState: stakeState(0, 11),
+			epoch:      11,
+			want:       StakeActivation{State: stakeprog.StakeActivationStateDeactivating, Active: 1000, Inactive: 0},
+		},
+		{
+			name:       "inactive",
+			lamports:   1100,
+			stakeState: stakeState(0, 5),
+			epoch:      11,
+			want:       StakeActivation{State: stakeprog.StakeActivationStateInactive, Inactive: 1000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, calculateStakeActivation(tt.lamports, tt.stakeState, tt.epoch, history, nil))
+		})
+	}
+}
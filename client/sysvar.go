@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/stakeprog"
+	"github.com/portto/solana-go-sdk/program/sysvar"
+)
+
+// GetSysvarClock returns the clock sysvar
+func (c *Client) GetSysvarClock(ctx context.Context) (sysvar.Clock, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarClockPubkey)
+	if err != nil {
+		return sysvar.Clock{}, err
+	}
+	return sysvar.ClockDeserialize(data)
+}
+
+// GetSysvarRent returns the rent sysvar. the minimum balance for rent exemption can be calculated locally by it.
+func (c *Client) GetSysvarRent(ctx context.Context) (sysvar.Rent, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarRentPubkey)
+	if err != nil {
+		return sysvar.Rent{}, err
+	}
+	return sysvar.RentDeserialize(data)
+}
+
+// GetSysvarEpochSchedule returns the epoch schedule sysvar
+func (c *Client) GetSysvarEpochSchedule(ctx context.Context) (sysvar.EpochSchedule, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarEpochSchedulePubkey)
+	if err != nil {
+		return sysvar.EpochSchedule{}, err
+	}
+	return sysvar.EpochScheduleDeserialize(data)
+}
+
+// GetSysvarFees returns the fees sysvar
+func (c *Client) GetSysvarFees(ctx context.Context) (sysvar.Fees, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarFeesPubkey)
+	if err != nil {
+		return sysvar.Fees{}, err
+	}
+	return sysvar.FeesDeserialize(data)
+}
+
+// GetSysvarRecentBlockhashes returns the recent blockhashes sysvar
+func (c *Client) GetSysvarRecentBlockhashes(ctx context.Context) (sysvar.RecentBlockhashes, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarRecentBlockhashsPubkey)
+	if err != nil {
+		return nil, err
+	}
+	return sysvar.RecentBlockhashesDeserialize(data)
+}
+
+// GetSysvarSlotHashes returns the slot hashes sysvar
+func (c *Client) GetSysvarSlotHashes(ctx context.Context) (sysvar.SlotHashes, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarSlotHashesPubkey)
+	if err != nil {
+		return nil, err
+	}
+	return sysvar.SlotHashesDeserialize(data)
+}
+
+// GetSysvarSlotHistory returns the slot history sysvar
+func (c *Client) GetSysvarSlotHistory(ctx context.Context) (sysvar.SlotHistory, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarSlotHistoryPubkey)
+	if err != nil {
+		return sysvar.SlotHistory{}, err
+	}
+	return sysvar.SlotHistoryDeserialize(data)
+}
+
+// GetSysvarStakeHistory returns the stake history sysvar
+func (c *Client) GetSysvarStakeHistory(ctx context.Context) (stakeprog.StakeHistory, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarStakeHistoryPubkey)
+	if err != nil {
+		return nil, err
+	}
+	return stakeprog.StakeHistoryDeserialize(data)
+}
+
+// GetSysvarEpochRewards returns the epoch rewards sysvar
+func (c *Client) GetSysvarEpochRewards(ctx context.Context) (sysvar.EpochRewards, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarEpochRewardsPubkey)
+	if err != nil {
+		return sysvar.EpochRewards{}, err
+	}
+	return sysvar.EpochRewardsDeserialize(data)
+}
+
+// GetSysvarLastRestartSlot returns the last restart slot sysvar
+func (c *Client) GetSysvarLastRestartSlot(ctx context.Context) (sysvar.LastRestartSlot, error) {
+	data, err := c.getSysvarData(ctx, common.SysVarLastRestartSlotPubkey)
+	if err != nil {
+		return sysvar.LastRestartSlot{}, err
+	}
+	return sysvar.LastRestartSlotDeserialize(data)
+}
+
+func (c *Client) getSysvarData(ctx context.Context, pubkey common.PublicKey) ([]byte, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, pubkey.ToBase58())
+	if err != nil {
+		return nil, err
+	}
+	if accountInfo.Owner != common.SysvarProgramID {
+		return nil, errors.New("owner mismatch")
+	}
+	return accountInfo.Data, nil
+}
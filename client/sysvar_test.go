@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/program/sysvar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetSysvarRent(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		responseBody string
+		want         sysvar.Rent
+		err          error
+	}{
+		{
+			name:         "rent",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["SysvarRent111111111111111111111111111111111", {"encoding": "base64"}]}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":77317717},"value":{"data":["mA0AAAAAAAAAAAAAAAAAQDI=","base64"],"executable":false,"lamports":1009200,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0}},"id":1}`,
+			want:         sysvar.DefaultRent,
+		},
+		{
+			name:         "owner mismatch",
+			requestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getAccountInfo", "params":["SysvarRent111111111111111111111111111111111", {"encoding": "base64"}]}`,
+			responseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":77317717},"value":{"data":["mA0AAAAAAAAAAAAAAAAAQDI=","base64"],"executable":false,"lamports":1009200,"owner":"11111111111111111111111111111111","rentEpoch":0}},"id":1}`,
+			err:          errors.New("owner mismatch"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := ioutil.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.JSONEq(t, tt.requestBody, string(body))
+				n, err := rw.Write([]byte(tt.responseBody))
+				assert.Nil(t, err)
+				assert.Equal(t, len([]byte(tt.responseBody)), n)
+			}))
+			defer server.Close()
+			c := NewClient(server.URL)
+			got, err := c.GetSysvarRent(context.Background())
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,8 +2,12 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
+	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/program/tokenprog"
+	"github.com/portto/solana-go-sdk/rpc"
 )
 
 func (c *Client) GetTokenAccount(ctx context.Context, base58Addr string) (tokenprog.TokenAccount, error) {
@@ -13,3 +17,71 @@ func (c *Client) GetTokenAccount(ctx context.Context, base58Addr string) (tokenp
 	}
 	return tokenprog.DeserializeTokenAccount(accountInfo.Data, accountInfo.Owner)
 }
+
+// GetTokenAccountsByDelegate returns all token accounts which approve the delegate
+func (c *Client) GetTokenAccountsByDelegate(ctx context.Context, base58Addr string) (map[common.PublicKey]tokenprog.TokenAccount, error) {
+	res, err := c.RpcClient.GetTokenAccountsByDelegateWithConfig(
+		ctx,
+		base58Addr,
+		rpc.GetTokenAccountsByDelegateConfigFilter{
+			ProgramId: common.TokenProgramID.ToBase58(),
+		},
+		rpc.GetTokenAccountsByDelegateConfig{
+			Encoding: rpc.GetTokenAccountsByDelegateConfigEncodingBase64,
+		},
+	)
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[common.PublicKey]tokenprog.TokenAccount{}
+	for _, v := range res.Result.Value {
+		accountInfo, err := c.rpcAccountInfoToClientAccountInfo(v.Account)
+		if err != nil {
+			return nil, err
+		}
+		tokenAccount, err := tokenprog.DeserializeTokenAccount(accountInfo.Data, accountInfo.Owner)
+		if err != nil {
+			return nil, err
+		}
+		m[common.PublicKeyFromString(v.Pubkey)] = tokenAccount
+	}
+	return m, nil
+}
+
+type TokenLargestAccount struct {
+	Address  common.PublicKey
+	Amount   uint64
+	Decimals uint8
+}
+
+// GetTokenLargestAccounts returns the 20 largest accounts of a particular SPL Token type
+func (c *Client) GetTokenLargestAccounts(ctx context.Context, mintAddr string) ([]TokenLargestAccount, error) {
+	return processGetTokenLargestAccounts(c.RpcClient.GetTokenLargestAccounts(ctx, mintAddr))
+}
+
+// GetTokenLargestAccountsWithConfig returns the 20 largest accounts of a particular SPL Token type
+func (c *Client) GetTokenLargestAccountsWithConfig(ctx context.Context, mintAddr string, cfg rpc.GetTokenLargestAccountsConfig) ([]TokenLargestAccount, error) {
+	return processGetTokenLargestAccounts(c.RpcClient.GetTokenLargestAccountsWithConfig(ctx, mintAddr, cfg))
+}
+
+func processGetTokenLargestAccounts(res rpc.GetTokenLargestAccountsResponse, err error) ([]TokenLargestAccount, error) {
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]TokenLargestAccount, 0, len(res.Result.Value))
+	for _, v := range res.Result.Value {
+		amount, err := strconv.ParseUint(v.Amount, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cast token amount, err: %v", err)
+		}
+		accounts = append(accounts, TokenLargestAccount{
+			Address:  common.PublicKeyFromString(v.Address),
+			Amount:   amount,
+			Decimals: v.Decimals,
+		})
+	}
+	return accounts, nil
+}
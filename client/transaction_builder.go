@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/cmptbdgprog"
+	"github.com/portto/solana-go-sdk/program/memoprog"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// errors returned by TransactionBuilder.Build
+var (
+	ErrTransactionBuilderNoInstructions   = errors.New("no instructions")
+	ErrTransactionBuilderNoFeePayer       = errors.New("no fee payer")
+	ErrTransactionBuilderMissingSigner    = errors.New("missing signer")
+	ErrTransactionBuilderExtraneousSigner = errors.New("extraneous signer")
+	ErrTransactionBuilderTooLarge         = errors.New("transaction too large")
+)
+
+type durableNonce struct {
+	account   common.PublicKey
+	authority common.PublicKey
+}
+
+// TransactionBuilder collects what a tx needs and builds a signed tx. the instructions are ordered as
+// AdvanceNonceAccount, compute budget instructions, added instructions and memos.
+type TransactionBuilder struct {
+	client *Client
+
+	instructions        []types.Instruction
+	feePayer            *common.PublicKey
+	signers             []types.Signer
+	recentBlockhash     string
+	nonce               *durableNonce
+	computeUnitLimit    *uint32
+	computeUnitPrice    *uint64
+	addressLookupTables []types.AddressLookupTableAccount
+	memos               []string
+}
+
+// NewTransactionBuilder returns a builder which fetches the blockhash or nonce by the client
+func (c *Client) NewTransactionBuilder() *TransactionBuilder {
+	return &TransactionBuilder{client: c}
+}
+
+// AddInstructions appends instructions
+func (b *TransactionBuilder) AddInstructions(instructions ...types.Instruction) *TransactionBuilder {
+	b.instructions = append(b.instructions, instructions...)
+	return b
+}
+
+// SetFeePayer sets the fee payer, the default is the first signer
+func (b *TransactionBuilder) SetFeePayer(feePayer common.PublicKey) *TransactionBuilder {
+	b.feePayer = &feePayer
+	return b
+}
+
+// AddSigners adds signers which hold private keys
+func (b *TransactionBuilder) AddSigners(signers ...types.Account) *TransactionBuilder {
+	for _, signer := range signers {
+		b.signers = append(b.signers, types.NewAccountSigner(signer))
+	}
+	return b
+}
+
+// AddExternalSigners adds signers which don't expose private keys, e.g. remote signers
+func (b *TransactionBuilder) AddExternalSigners(signers ...types.Signer) *TransactionBuilder {
+	b.signers = append(b.signers, signers...)
+	return b
+}
+
+// SetRecentBlockhash uses the blockhash instead of fetching the latest one
+func (b *TransactionBuilder) SetRecentBlockhash(blockhash string) *TransactionBuilder {
+	b.recentBlockhash = blockhash
+	return b
+}
+
+// SetDurableNonce makes the tx use the nonce stored in the nonce account as its blockhash.
+// an AdvanceNonceAccount instruction is prepended and the authority has to sign the tx.
+func (b *TransactionBuilder) SetDurableNonce(nonceAccount common.PublicKey, nonceAuthority common.PublicKey) *TransactionBuilder {
+	b.nonce = &durableNonce{
+		account:   nonceAccount,
+		authority: nonceAuthority,
+	}
+	return b
+}
+
+// SetComputeUnitLimit adds a SetComputeUnitLimit instruction
+func (b *TransactionBuilder) SetComputeUnitLimit(units uint32) *TransactionBuilder {
+	b.computeUnitLimit = &units
+	return b
+}
+
+// SetComputeUnitPrice adds a SetComputeUnitPrice instruction
+func (b *TransactionBuilder) SetComputeUnitPrice(microLamports uint64) *TransactionBuilder {
+	b.computeUnitPrice = &microLamports
+	return b
+}
+
+// AddAddressLookupTables makes the builder compile a v0 message which loads accounts from the tables
+func (b *TransactionBuilder) AddAddressLookupTables(addressLookupTables ...types.AddressLookupTableAccount) *TransactionBuilder {
+	b.addressLookupTables = append(b.addressLookupTables, addressLookupTables...)
+	return b
+}
+
+// AddMemo appends a memo instruction
+func (b *TransactionBuilder) AddMemo(memo string) *TransactionBuilder {
+	b.memos = append(b.memos, memo)
+	return b
+}
+
+// Build builds and signs the tx. it checks signers before signing and the size of the tx is at most types.MaxTransactionSize.
+func (b *TransactionBuilder) Build(ctx context.Context) (types.Transaction, error) {
+	if len(b.instructions) == 0 {
+		return types.Transaction{}, ErrTransactionBuilderNoInstructions
+	}
+	var feePayer common.PublicKey
+	switch {
+	case b.feePayer != nil:
+		feePayer = *b.feePayer
+	case len(b.signers) > 0:
+		feePayer = b.signers[0].PublicKey()
+	default:
+		return types.Transaction{}, ErrTransactionBuilderNoFeePayer
+	}
+
+	instructions := make([]types.Instruction, 0, len(b.instructions)+len(b.memos)+3)
+	if b.computeUnitLimit != nil {
+		instructions = append(instructions, cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{
+			Units: *b.computeUnitLimit,
+		}))
+	}
+	if b.computeUnitPrice != nil {
+		instructions = append(instructions, cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{
+			MicroLamports: *b.computeUnitPrice,
+		}))
+	}
+	instructions = append(instructions, b.instructions...)
+	for _, memo := range b.memos {
+		instructions = append(instructions, memoprog.BuildMemo(memoprog.BuildMemoParam{
+			Memo: []byte(memo),
+		}))
+	}
+	instructions, err := b.client.attachPriorityFee(ctx, feePayer, instructions)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	recentBlockhash := b.recentBlockhash
+	if b.nonce != nil {
+		instructions = append([]types.Instruction{
+			sysprog.AdvanceNonceAccount(sysprog.AdvanceNonceAccountParam{
+				Nonce: b.nonce.account,
+				Auth:  b.nonce.authority,
+			}),
+		}, instructions...)
+		recentBlockhash, err = b.client.GetNonceFromNonceAccount(ctx, b.nonce.account.ToBase58())
+		if err != nil {
+			return types.Transaction{}, fmt.Errorf("failed to get nonce, err: %v", err)
+		}
+	} else if recentBlockhash == "" {
+		latestBlockhash, err := b.client.GetLatestBlockhash(ctx)
+		if err != nil {
+			return types.Transaction{}, fmt.Errorf("failed to get latest blockhash, err: %v", err)
+		}
+		recentBlockhash = latestBlockhash.Blockhash
+	}
+
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:                   feePayer,
+		Instructions:               instructions,
+		RecentBlockhash:            recentBlockhash,
+		AddressLookupTableAccounts: b.addressLookupTables,
+	})
+	signers, err := checkSigners(message, b.signers)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	tx, err := types.NewUnsignedTransaction(types.NewTransactionParam{Message: message})
+	if err != nil {
+		return types.Transaction{}, fmt.Errorf("failed to create new tx, err: %v", err)
+	}
+	if size := tx.SerializedSize(); size > types.MaxTransactionSize {
+		return types.Transaction{}, fmt.Errorf("%w, size: %v, max: %v", ErrTransactionBuilderTooLarge, size, types.MaxTransactionSize)
+	}
+
+	if err := tx.PartialSign(ctx, signers...); err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, nil
+}
+
+// Send builds the tx and sends it
+func (b *TransactionBuilder) Send(ctx context.Context) (string, error) {
+	tx, err := b.Build(ctx)
+	if err != nil {
+		return "", err
+	}
+	return b.client.SendTransaction(ctx, tx)
+}
+
+// checkSigners returns deduplicated signers if they are exactly the signers the message requires
+func checkSigners(message types.Message, signers []types.Signer) ([]types.Signer, error) {
+	required := map[common.PublicKey]bool{}
+	for i := 0; i < int(message.Header.NumRequireSignatures); i++ {
+		required[message.Accounts[i]] = true
+	}
+
+	provided := map[common.PublicKey]bool{}
+	deduplicated := make([]types.Signer, 0, len(signers))
+	for _, signer := range signers {
+		pubkey := signer.PublicKey()
+		if !required[pubkey] {
+			return nil, fmt.Errorf("%w, %v", ErrTransactionBuilderExtraneousSigner, pubkey.ToBase58())
+		}
+		if provided[pubkey] {
+			continue
+		}
+		provided[pubkey] = true
+		deduplicated = append(deduplicated, signer)
+	}
+
+	missing := []string{}
+	for i := 0; i < int(message.Header.NumRequireSignatures); i++ {
+		if !provided[message.Accounts[i]] {
+			missing = append(missing, message.Accounts[i].ToBase58())
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w, %v", ErrTransactionBuilderMissingSigner, missing)
+	}
+	return deduplicated, nil
+}
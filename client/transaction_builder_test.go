@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/cmptbdgprog"
+	"github.com/portto/solana-go-sdk/program/memoprog"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionBuilder_Build(t *testing.T) {
+	feePayer := types.NewAccount()
+	nonceAuthority := types.NewAccount()
+	nonceAccount := common.PublicKeyFromString("DuZFRaFBDj2Sc9Lp4FW8nCCCCuCmDwwNdEBjNpDn8XLq")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	transfer := sysprog.Transfer(sysprog.TransferParam{From: feePayer.PublicKey, To: to, Amount: 1})
+	nonce := "9K6bT3EGc2vrf8ZB6eTKBk5xuBLnMTQz6FqpAnrLdvkS"
+
+	tests := []struct {
+		name             string
+		responses        map[string][]string
+		build            func(b *TransactionBuilder) *TransactionBuilder
+		wantBlockhash    string
+		wantInstructions []types.Instruction
+	}{
+		{
+			name: "latest blockhash",
+			responses: map[string][]string{
+				"getLatestBlockhash": {`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":{"blockhash":"FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5","lastValidBlockHeight":150}},"id":1}`},
+			},
+			build: func(b *TransactionBuilder) *TransactionBuilder {
+				return b.AddInstructions(transfer).AddSigners(feePayer)
+			},
+			wantBlockhash:    "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			wantInstructions: []types.Instruction{transfer},
+		},
+		{
+			name: "compute budget and memo",
+			build: func(b *TransactionBuilder) *TransactionBuilder {
+				return b.
+					AddMemo("hello").
+					AddInstructions(transfer).
+					SetComputeUnitPrice(100).
+					SetComputeUnitLimit(1000).
+					SetFeePayer(feePayer.PublicKey).
+					AddSigners(feePayer, feePayer).
+					SetRecentBlockhash("FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+			},
+			wantBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			wantInstructions: []types.Instruction{
+				cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: 1000}),
+				cmptbdgprog.SetComputeUnitPrice(cmptbdgprog.SetComputeUnitPriceParam{MicroLamports: 100}),
+				transfer,
+				memoprog.BuildMemo(memoprog.BuildMemoParam{Memo: []byte("hello")}),
+			},
+		},
+		{
+			name: "durable nonce",
+			responses: map[string][]string{
+				"getAccountInfo": {`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":{"data":["` + base64.StdEncoding.EncodeToString(common.PublicKeyFromString(nonce).Bytes()) + `","base64"],"executable":false,"lamports":1447680,"owner":"11111111111111111111111111111111","rentEpoch":0}},"id":1}`},
+			},
+			build: func(b *TransactionBuilder) *TransactionBuilder {
+				return b.
+					AddInstructions(transfer).
+					SetDurableNonce(nonceAccount, nonceAuthority.PublicKey).
+					AddSigners(feePayer).
+					AddExternalSigners(types.NewAccountSigner(nonceAuthority))
+			},
+			wantBlockhash: nonce,
+			wantInstructions: []types.Instruction{
+				sysprog.AdvanceNonceAccount(sysprog.AdvanceNonceAccountParam{Nonce: nonceAccount, Auth: nonceAuthority.PublicKey}),
+				transfer,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newSequenceServer(t, tt.responses)
+			defer server.Close()
+
+			c := NewClient(server.URL)
+			tx, err := tt.build(c.NewTransactionBuilder()).Build(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantBlockhash, tx.Message.RecentBlockHash)
+			assert.Equal(t, tt.wantInstructions, tx.Message.DecompileInstructions())
+			assert.Equal(t, feePayer.PublicKey, tx.Message.Accounts[0])
+			assert.True(t, tx.IsFullySigned())
+			assert.Nil(t, tx.VerifySignatures())
+		})
+	}
+}
+
+func TestTransactionBuilder_BuildError(t *testing.T) {
+	feePayer := types.NewAccount()
+	other := types.NewAccount()
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	transfer := sysprog.Transfer(sysprog.TransferParam{From: feePayer.PublicKey, To: to, Amount: 1})
+
+	tests := []struct {
+		name  string
+		build func(b *TransactionBuilder) *TransactionBuilder
+		err   error
+	}{
+		{
+			name: "no instructions",
+			build: func(b *TransactionBuilder) *TransactionBuilder {
+				return b.AddSigners(feePayer)
+			},
+			err: ErrTransactionBuilderNoInstructions,
+		},
+		{
+			name: "no fee payer",
+			build: func(b *TransactionBuilder) *TransactionBuilder {
+				return b.AddInstructions(transfer)
+			},
+			err: ErrTransactionBuilderNoFeePayer,
+		},
+		{
+			name: "missing signer",
+			build: func(b *TransactionBuilder) *TransactionBuilder {
+				return b.AddInstructions(transfer).SetFeePayer(other.PublicKey).AddSigners(other)
+			},
+			err: ErrTransactionBuilderMissingSigner,
+		},
+		{
+			name: "extraneous signer",
+			build: func(b *TransactionBuilder) *TransactionBuilder {
+				return b.AddInstructions(transfer).AddSigners(feePayer, other)
+			},
+			err: ErrTransactionBuilderExtraneousSigner,
+		},
+		{
+			name: "too large",
+			build: func(b *TransactionBuilder) *TransactionBuilder {
+				return b.AddInstructions(transfer).AddMemo(strings.Repeat("a", types.MaxTransactionSize)).AddSigners(feePayer)
+			},
+			err: ErrTransactionBuilderTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("")
+			_, err := tt.build(c.NewTransactionBuilder()).SetRecentBlockhash("FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5").Build(context.Background())
+			assert.True(t, errors.Is(err, tt.err), err)
+		})
+	}
+}
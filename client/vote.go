@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/voteprog"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// GetVoteAccount returns the state of a vote account
+func (c *Client) GetVoteAccount(ctx context.Context, base58Addr string) (voteprog.VoteState, error) {
+	accountInfo, err := c.GetAccountInfo(ctx, base58Addr)
+	if err != nil {
+		return voteprog.VoteState{}, err
+	}
+	if accountInfo.Owner != common.VoteProgramID {
+		return voteprog.VoteState{}, errors.New("owner mismatch")
+	}
+	return voteprog.VoteStateDeserialize(accountInfo.Data)
+}
+
+type VoteAccounts struct {
+	Current    []VoteAccountInfo
+	Delinquent []VoteAccountInfo
+}
+
+type VoteAccountInfo struct {
+	VotePubkey       common.PublicKey
+	NodePubkey       common.PublicKey
+	ActivatedStake   uint64
+	EpochVoteAccount bool
+	Commission       uint8
+	LastVote         uint64
+	EpochCredits     []voteprog.EpochCredits
+	RootSlot         uint64
+}
+
+// GetVoteAccounts returns the account info and associated stake for all the voting accounts in the current bank
+func (c *Client) GetVoteAccounts(ctx context.Context) (VoteAccounts, error) {
+	return processGetVoteAccounts(c.RpcClient.GetVoteAccounts(ctx))
+}
+
+// GetVoteAccountsWithConfig returns the account info and associated stake for all the voting accounts in the current bank
+func (c *Client) GetVoteAccountsWithConfig(ctx context.Context, cfg rpc.GetVoteAccountsConfig) (VoteAccounts, error) {
+	return processGetVoteAccounts(c.RpcClient.GetVoteAccountsWithConfig(ctx, cfg))
+}
+
+func processGetVoteAccounts(res rpc.GetVoteAccountsResponse, err error) (VoteAccounts, error) {
+	err = checkRpcResult(res.GeneralResponse, err)
+	if err != nil {
+		return VoteAccounts{}, err
+	}
+	return VoteAccounts{
+		Current:    toVoteAccountInfos(res.Result.Current),
+		Delinquent: toVoteAccountInfos(res.Result.Delinquent),
+	}, nil
+}
+
+func toVoteAccountInfos(values []rpc.GetVoteAccountsResultValue) []VoteAccountInfo {
+	infos := make([]VoteAccountInfo, 0, len(values))
+	for _, v := range values {
+		epochCredits := make([]voteprog.EpochCredits, 0, len(v.EpochCredits))
+		for _, e := range v.EpochCredits {
+			epochCredits = append(epochCredits, voteprog.EpochCredits{
+				Epoch:       e[0],
+				Credits:     e[1],
+				PrevCredits: e[2],
+			})
+		}
+		infos = append(infos, VoteAccountInfo{
+			VotePubkey:       common.PublicKeyFromString(v.VotePubkey),
+			NodePubkey:       common.PublicKeyFromString(v.NodePubkey),
+			ActivatedStake:   v.ActivatedStake,
+			EpochVoteAccount: v.EpochVoteAccount,
+			Commission:       v.Commission,
+			LastVote:         v.LastVote,
+			EpochCredits:     epochCredits,
+			RootSlot:         v.RootSlot,
+		})
+	}
+	return infos
+}
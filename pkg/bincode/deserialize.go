@@ -0,0 +1,241 @@
+package bincode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var ErrDataSizeNotEnough = errors.New("data size is not enough")
+
+// DeserializeData deserializes bincode data into v, which must be a non-nil pointer. it is the
+// counterpart of SerializeData and trailing data is ignored.
+//
+// a byte slice without a compact tag has no length prefix so it takes all the remaining data,
+// it should be the last field.
+func DeserializeData(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("v should be a non-nil pointer, got %v", reflect.TypeOf(v))
+	}
+	d := decoder{data: data}
+	return d.decode(rv.Elem(), tagOptions{})
+}
+
+type decoder struct {
+	data []byte
+}
+
+func (d *decoder) next(n uint64) ([]byte, error) {
+	if uint64(len(d.data)) < n {
+		return nil, ErrDataSizeNotEnough
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+func (d *decoder) decode(v reflect.Value, opts tagOptions) error {
+	if !v.CanSet() {
+		return fmt.Errorf("cannot set value of %v", v.Type())
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := d.next(1)
+		if err != nil {
+			return err
+		}
+		switch b[0] {
+		case 0:
+			v.SetBool(false)
+		case 1:
+			v.SetBool(true)
+		default:
+			return fmt.Errorf("invalid bool value %v", b[0])
+		}
+		return nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		u, err := d.uint(v.Type().Size())
+		if err != nil {
+			return err
+		}
+		// sign extend by shifting the value to the top bits
+		shift := 64 - 8*v.Type().Size()
+		v.SetInt(int64(u<<shift) >> shift)
+		return nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := d.uint(v.Type().Size())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+		return nil
+	case reflect.Float32:
+		u, err := d.uint(4)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(float64(math.Float32frombits(uint32(u))))
+		return nil
+	case reflect.Float64:
+		u, err := d.uint(8)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(math.Float64frombits(u))
+		return nil
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := d.next(uint64(v.Len()))
+			if err != nil {
+				return err
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := d.decode(v.Index(i), tagOptions{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && !opts.compact {
+			b := make([]byte, len(d.data))
+			copy(b, d.data)
+			d.data = nil
+			v.SetBytes(b)
+			return nil
+		}
+		l, err := d.length(opts)
+		if err != nil {
+			return err
+		}
+		// every element takes at least one byte, it prevents a huge allocation by a bad length
+		if l > uint64(len(d.data)) && v.Type().Elem().Size() > 0 {
+			return ErrDataSizeNotEnough
+		}
+		s := reflect.MakeSlice(v.Type(), int(l), int(l))
+		for i := 0; i < int(l); i++ {
+			if err := d.decode(s.Index(i), tagOptions{}); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.String:
+		l, err := d.length(opts)
+		if err != nil {
+			return err
+		}
+		b, err := d.next(l)
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+		return nil
+	case reflect.Map:
+		l, err := d.length(opts)
+		if err != nil {
+			return err
+		}
+		if l > uint64(len(d.data)) {
+			return ErrDataSizeNotEnough
+		}
+		m := reflect.MakeMapWithSize(v.Type(), int(l))
+		for i := uint64(0); i < l; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := d.decode(key, tagOptions{}); err != nil {
+				return err
+			}
+			value := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decode(value, tagOptions{}); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, value)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Ptr:
+		b, err := d.next(1)
+		if err != nil {
+			return err
+		}
+		switch b[0] {
+		case 0:
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		case 1:
+			p := reflect.New(v.Type().Elem())
+			if err := d.decode(p.Elem(), tagOptions{}); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		}
+		return fmt.Errorf("invalid option value %v", b[0])
+	case reflect.Interface:
+		variant, err := d.uint(4)
+		if err != nil {
+			return err
+		}
+		t, ok := lookupEnumType(v.Type(), uint32(variant))
+		if !ok {
+			return fmt.Errorf("unknown enum variant: %v of %v", variant, v.Type())
+		}
+		e := reflect.New(t).Elem()
+		if err := d.decode(e, tagOptions{}); err != nil {
+			return err
+		}
+		v.Set(e)
+		return nil
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			opts := parseTag(t.Field(i))
+			if opts.skip {
+				continue
+			}
+			if err := checkExported(t, t.Field(i)); err != nil {
+				return err
+			}
+			if err := d.decode(v.Field(i), opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupport type: %v", v.Kind())
+}
+
+func (d *decoder) uint(size uintptr) (uint64, error) {
+	b, err := d.next(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(b)), nil
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (d *decoder) length(opts tagOptions) (uint64, error) {
+	if !opts.compact {
+		return d.uint(8)
+	}
+	// compact-u16 takes at most 3 bytes
+	l, n := binary.Uvarint(d.data)
+	if n <= 0 || n > 3 || l > math.MaxUint16 {
+		return 0, errors.New("invalid compact-u16 length")
+	}
+	d.data = d.data[n:]
+	return l, nil
+}
@@ -0,0 +1,141 @@
+package bincode
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type shape interface {
+	isShape()
+}
+
+type circle struct {
+	Radius uint32
+}
+
+func (circle) isShape() {}
+
+type square struct{}
+
+func (square) isShape() {}
+
+func init() {
+	RegisterEnum((*shape)(nil), circle{}, square{})
+}
+
+type point struct {
+	X int16
+	Y int16
+}
+
+type everything struct {
+	Bool    bool
+	Int8    int8
+	Uint16  uint16
+	Int32   int32
+	Int64   int64
+	Float64 float64
+	Key     [4]byte
+	Points  []point
+	Pair    [2]uint64
+	Name    string
+	Scores  map[uint64]string
+	Option  *uint64
+	None    *uint64
+	Shape   shape
+	Big     Uint128
+	Skipped uint64  `bincode:"-"`
+	Compact []uint8 `bincode:"compact"`
+	Rest    []byte
+}
+
+func TestDeserializeData(t *testing.T) {
+	v := everything{
+		Bool:    true,
+		Int8:    -2,
+		Uint16:  0xbeef,
+		Int32:   -70000,
+		Int64:   -1,
+		Float64: 0.25,
+		Key:     [4]byte{1, 2, 3, 4},
+		Points:  []point{{X: 1, Y: -1}, {X: 300, Y: 2}},
+		Pair:    [2]uint64{5, 6},
+		Name:    "hi",
+		Scores:  map[uint64]string{2: "b", 1: "a"},
+		Option:  func() *uint64 { v := uint64(7); return &v }(),
+		Shape:   circle{Radius: 9},
+		Big:     Uint128{Lo: 1, Hi: 2},
+		Compact: []byte{8, 9},
+		Rest:    []byte{10, 11, 12},
+	}
+
+	data, err := SerializeData(v)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		1,
+		0xfe,
+		0xef, 0xbe,
+		0x90, 0xee, 0xfe, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0, 0, 0, 0, 0, 0, 0xd0, 0x3f,
+		1, 2, 3, 4,
+		2, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0xff, 0xff, 0x2c, 0x01, 2, 0,
+		5, 0, 0, 0, 0, 0, 0, 0, 6, 0, 0, 0, 0, 0, 0, 0,
+		2, 0, 0, 0, 0, 0, 0, 0, 'h', 'i',
+		2, 0, 0, 0, 0, 0, 0, 0,
+		1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 'a',
+		2, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 'b',
+		1, 7, 0, 0, 0, 0, 0, 0, 0,
+		0,
+		0, 0, 0, 0, 9, 0, 0, 0,
+		1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0,
+		2, 8, 9,
+		10, 11, 12,
+	}, data)
+
+	var got everything
+	assert.NoError(t, DeserializeData(data, &got))
+	assert.Equal(t, v, got)
+}
+
+func TestDeserializeData_Error(t *testing.T) {
+	type withSlice struct {
+		Values []uint64
+	}
+	type withShape struct {
+		Shape shape
+	}
+	type withOption struct {
+		Option *uint8
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		v    interface{}
+	}{
+		{name: "not enough data", data: []byte{1, 2}, v: new(uint32)},
+		{name: "invalid bool", data: []byte{2}, v: new(bool)},
+		{name: "invalid option", data: []byte{2, 0}, v: new(withOption)},
+		{name: "bad length", data: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}, v: new(withSlice)},
+		{name: "unknown enum variant", data: []byte{2, 0, 0, 0}, v: new(withShape)},
+		{name: "not a pointer", data: []byte{1}, v: uint8(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, DeserializeData(tt.data, tt.v))
+		})
+	}
+
+	var s withShape
+	assert.NoError(t, DeserializeData([]byte{1, 0, 0, 0}, &s))
+	assert.Equal(t, square{}, s.Shape)
+}
+
+func TestInt128_BigInt(t *testing.T) {
+	assert.Equal(t, "36893488147419103233", Uint128{Lo: 1, Hi: 2}.BigInt().String())
+	assert.Equal(t, big.NewInt(-1), Int128{Lo: ^uint64(0), Hi: -1}.BigInt())
+	assert.Equal(t, big.NewInt(-2), Int128{Lo: ^uint64(1), Hi: -1}.BigInt())
+}
@@ -0,0 +1,59 @@
+package bincode
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var enums = struct {
+	sync.RWMutex
+	variants map[reflect.Type][]reflect.Type
+}{
+	variants: map[reflect.Type][]reflect.Type{},
+}
+
+// RegisterEnum registers the variants of a rust enum which is represented by an interface in go.
+// enum should be a nil pointer to the interface, e.g. (*Shape)(nil), and the variant index is the
+// position in variants. an enum field is serialized as a u32 variant index followed by the variant.
+func RegisterEnum(enum interface{}, variants ...interface{}) {
+	t := reflect.TypeOf(enum)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic(fmt.Errorf("enum should be a pointer to an interface, got %v", t))
+	}
+	t = t.Elem()
+
+	types := make([]reflect.Type, 0, len(variants))
+	for _, variant := range variants {
+		vt := reflect.TypeOf(variant)
+		if vt == nil || !vt.Implements(t) {
+			panic(fmt.Errorf("variant %v does not implement %v", vt, t))
+		}
+		types = append(types, vt)
+	}
+
+	enums.Lock()
+	defer enums.Unlock()
+	enums.variants[t] = types
+}
+
+func lookupEnumVariant(enum, variant reflect.Type) (uint32, bool) {
+	enums.RLock()
+	defer enums.RUnlock()
+	for i, t := range enums.variants[enum] {
+		if t == variant {
+			return uint32(i), true
+		}
+	}
+	return 0, false
+}
+
+func lookupEnumType(enum reflect.Type, variant uint32) (reflect.Type, bool) {
+	enums.RLock()
+	defer enums.RUnlock()
+	types := enums.variants[enum]
+	if uint64(variant) >= uint64(len(types)) {
+		return nil, false
+	}
+	return types[variant], true
+}
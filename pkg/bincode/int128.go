@@ -0,0 +1,29 @@
+package bincode
+
+import "math/big"
+
+// Uint128 is a rust u128, it is serialized as the low 64 bits followed by the high 64 bits
+type Uint128 struct {
+	Lo uint64
+	Hi uint64
+}
+
+func (u Uint128) BigInt() *big.Int {
+	i := new(big.Int).SetUint64(u.Hi)
+	i.Lsh(i, 64)
+	return i.Or(i, new(big.Int).SetUint64(u.Lo))
+}
+
+// Int128 is a rust i128 in two's complement
+type Int128 struct {
+	Lo uint64
+	Hi int64
+}
+
+func (i Int128) BigInt() *big.Int {
+	b := Uint128{Lo: i.Lo, Hi: uint64(i.Hi)}.BigInt()
+	if i.Hi < 0 {
+		b.Sub(b, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	return b
+}
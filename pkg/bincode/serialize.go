@@ -3,84 +3,183 @@ package bincode
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
 )
 
+// SerializeData serializes data in bincode format.
+//
+// a byte slice is written as it is without a length prefix, other slices, strings and maps have a u64
+// length prefix. a nil pointer is written as an option none. a struct field can be tagged by
+// `bincode:"-"` to be skipped or by `bincode:"compact"` to use a compact-u16 length prefix. unexported
+// fields are rejected unless they are skipped because DeserializeData can't set them.
+// an interface field is written as an enum registered by RegisterEnum.
 func SerializeData(data interface{}) ([]byte, error) {
 	return serializeData(reflect.ValueOf(data))
 }
 
 func serializeData(v reflect.Value) ([]byte, error) {
+	return appendValue(make([]byte, 0, 64), v, tagOptions{})
+}
+
+func appendValue(b []byte, v reflect.Value, opts tagOptions) ([]byte, error) {
 	switch v.Kind() {
 	case reflect.Bool:
 		if v.Bool() {
-			return []byte{1}, nil
+			return append(b, 1), nil
 		}
-		return []byte{0}, nil
+		return append(b, 0), nil
+	case reflect.Int8:
+		return append(b, uint8(v.Int())), nil
 	case reflect.Uint8:
-		return []byte{uint8(v.Uint())}, nil
+		return append(b, uint8(v.Uint())), nil
 	case reflect.Int16:
-		b := make([]byte, 2)
-		binary.LittleEndian.PutUint16(b, uint16(v.Int()))
-		return b, nil
+		return appendUint16(b, uint16(v.Int())), nil
 	case reflect.Uint16:
-		b := make([]byte, 2)
-		binary.LittleEndian.PutUint16(b, uint16(v.Uint()))
-		return b, nil
+		return appendUint16(b, uint16(v.Uint())), nil
 	case reflect.Int32:
-		b := make([]byte, 4)
-		binary.LittleEndian.PutUint32(b, uint32(v.Int()))
-		return b, nil
+		return appendUint32(b, uint32(v.Int())), nil
 	case reflect.Uint32:
-		b := make([]byte, 4)
-		binary.LittleEndian.PutUint32(b, uint32(v.Uint()))
-		return b, nil
+		return appendUint32(b, uint32(v.Uint())), nil
 	case reflect.Int64:
-		b := make([]byte, 8)
-		binary.LittleEndian.PutUint64(b, uint64(v.Int()))
-		return b, nil
+		return appendUint64(b, uint64(v.Int())), nil
 	case reflect.Uint64:
-		b := make([]byte, 8)
-		binary.LittleEndian.PutUint64(b, v.Uint())
-		return b, nil
-	case reflect.Slice, reflect.Array:
-		switch v.Type().Elem().Kind() {
-		case reflect.Uint8:
-			b := make([]byte, 0, v.Len())
-			for i := 0; i < v.Len(); i++ {
-				b = append(b, byte(v.Index(i).Uint()))
+		return appendUint64(b, v.Uint()), nil
+	case reflect.Float32:
+		return appendUint32(b, math.Float32bits(float32(v.Float()))), nil
+	case reflect.Float64:
+		return appendUint64(b, math.Float64bits(v.Float())), nil
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return appendBytes(b, v), nil
+		}
+		return appendElems(b, v)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if opts.compact {
+				b = append(b, UintToVarLenBytes(uint64(v.Len()))...)
 			}
-			return b, nil
+			return appendBytes(b, v), nil
 		}
-		return nil, fmt.Errorf("unsupport type: %v, elem: %v", v.Kind(), v.Elem().Kind())
+		b = appendLength(b, v.Len(), opts)
+		return appendElems(b, v)
 	case reflect.String:
-		b := make([]byte, 8+len(v.String()))
-		binary.LittleEndian.PutUint64(b, uint64(len(v.String())))
-		copy(b[8:], []byte(v.String()))
-		return b, nil
+		b = appendLength(b, v.Len(), opts)
+		return append(b, v.String()...), nil
+	case reflect.Map:
+		return appendMap(b, v, opts)
 	case reflect.Ptr:
 		if v.IsNil() {
-			return []byte{0}, nil
+			return append(b, 0), nil
 		}
-		d, err := serializeData(v.Elem())
-		if err != nil {
-			return nil, err
-		}
-		b := make([]byte, 1+len(d))
-		b[0] = 1
-		copy(b[1:], d[:])
-		return b, nil
+		return appendValue(append(b, 1), v.Elem(), tagOptions{})
+	case reflect.Interface:
+		return appendEnum(b, v)
 	case reflect.Struct:
-		data := make([]byte, 0, 1024)
+		t := v.Type()
 		for i := 0; i < v.NumField(); i++ {
-			field := v.Field(i)
-			d, err := serializeData(field)
+			opts := parseTag(t.Field(i))
+			if opts.skip {
+				continue
+			}
+			if err := checkExported(t, t.Field(i)); err != nil {
+				return nil, err
+			}
+			var err error
+			b, err = appendValue(b, v.Field(i), opts)
 			if err != nil {
 				return nil, err
 			}
-			data = append(data, d...)
 		}
-		return data, nil
+		return b, nil
 	}
 	return nil, fmt.Errorf("unsupport type: %v", v.Kind())
 }
+
+func appendBytes(b []byte, v reflect.Value) []byte {
+	for i := 0; i < v.Len(); i++ {
+		b = append(b, byte(v.Index(i).Uint()))
+	}
+	return b
+}
+
+func appendElems(b []byte, v reflect.Value) ([]byte, error) {
+	var err error
+	for i := 0; i < v.Len(); i++ {
+		b, err = appendValue(b, v.Index(i), tagOptions{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func appendMap(b []byte, v reflect.Value, opts tagOptions) ([]byte, error) {
+	b = appendLength(b, v.Len(), opts)
+	keys := v.MapKeys()
+	// sort keys to be deterministic, it is the same order as a rust BTreeMap for numbers and strings
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i], keys[j])
+	})
+	var err error
+	for _, key := range keys {
+		b, err = appendValue(b, key, tagOptions{})
+		if err != nil {
+			return nil, err
+		}
+		b, err = appendValue(b, v.MapIndex(key), tagOptions{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func lessKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.String:
+		return a.String() < b.String()
+	}
+	ab, _ := serializeData(a)
+	bb, _ := serializeData(b)
+	return string(ab) < string(bb)
+}
+
+func appendEnum(b []byte, v reflect.Value) ([]byte, error) {
+	if v.IsNil() {
+		return nil, fmt.Errorf("nil enum: %v", v.Type())
+	}
+	variant, ok := lookupEnumVariant(v.Type(), v.Elem().Type())
+	if !ok {
+		return nil, fmt.Errorf("unregistered enum variant: %v of %v", v.Elem().Type(), v.Type())
+	}
+	return appendValue(appendUint32(b, variant), v.Elem(), tagOptions{})
+}
+
+func appendLength(b []byte, l int, opts tagOptions) []byte {
+	if opts.compact {
+		return append(b, UintToVarLenBytes(uint64(l))...)
+	}
+	return appendUint64(b, uint64(l))
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
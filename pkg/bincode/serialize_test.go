@@ -0,0 +1,123 @@
+package bincode
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type triangle struct{}
+
+func (triangle) isShape() {}
+
+func TestSerializeData(t *testing.T) {
+	type withShape struct {
+		Shape shape
+	}
+	type withTags struct {
+		Skipped uint64         `bincode:"-"`
+		Bytes   []byte         `bincode:"compact"`
+		Name    string         `bincode:"compact"`
+		Values  []uint16       `bincode:"compact"`
+		Scores  map[uint8]bool `bincode:"compact"`
+		skipped uint64         `bincode:"-"`
+	}
+
+	tests := []struct {
+		name string
+		v    interface{}
+		want []byte
+	}{
+		{
+			name: "enum variant with data",
+			v:    withShape{Shape: circle{Radius: 9}},
+			want: []byte{0, 0, 0, 0, 9, 0, 0, 0},
+		},
+		{
+			name: "enum unit variant",
+			v:    withShape{Shape: square{}},
+			want: []byte{1, 0, 0, 0},
+		},
+		{
+			name: "uint128",
+			v:    Uint128{Lo: 1, Hi: 2},
+			want: []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "max uint128",
+			v:    Uint128{Lo: ^uint64(0), Hi: ^uint64(0)},
+			want: bytes.Repeat([]byte{0xff}, 16),
+		},
+		{
+			name: "int128",
+			v:    Int128{Lo: 300, Hi: 0},
+			want: []byte{0x2c, 0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "negative int128",
+			v:    Int128{Lo: ^uint64(1), Hi: -1},
+			want: append([]byte{0xfe}, bytes.Repeat([]byte{0xff}, 15)...),
+		},
+		{
+			name: "tags",
+			v: withTags{
+				Skipped: 1,
+				Bytes:   []byte{1, 2},
+				Name:    "hi",
+				Values:  []uint16{0x0102},
+				Scores:  map[uint8]bool{2: false, 1: true},
+				skipped: 1,
+			},
+			want: []byte{
+				2, 1, 2,
+				2, 'h', 'i',
+				1, 0x02, 0x01,
+				2, 1, 1, 2, 0,
+			},
+		},
+		{
+			name: "compact length over 127",
+			v: struct {
+				Bytes []byte `bincode:"compact"`
+			}{Bytes: make([]byte, 200)},
+			want: append([]byte{0xc8, 0x01}, make([]byte, 200)...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SerializeData(tt.v)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSerializeData_Error(t *testing.T) {
+	type withShape struct {
+		Shape shape
+	}
+	type withUnexported struct {
+		Value   uint64
+		private uint64
+	}
+
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{name: "nil enum", v: withShape{}},
+		{name: "unregistered enum variant", v: withShape{Shape: triangle{}}},
+		{name: "unexported field", v: withUnexported{}},
+		{name: "unsupported type", v: struct{ C chan int }{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SerializeData(tt.v)
+			assert.Error(t, err)
+		})
+	}
+
+	var v withUnexported
+	assert.Error(t, DeserializeData(make([]byte, 16), &v))
+}
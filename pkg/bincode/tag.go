@@ -0,0 +1,33 @@
+package bincode
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type tagOptions struct {
+	skip    bool
+	compact bool
+}
+
+func parseTag(field reflect.StructField) tagOptions {
+	var opts tagOptions
+	for _, s := range strings.Split(field.Tag.Get("bincode"), ",") {
+		switch strings.TrimSpace(s) {
+		case "-":
+			opts.skip = true
+		case "compact":
+			opts.compact = true
+		}
+	}
+	return opts
+}
+
+// checkExported rejects unexported fields which can't be set when deserializing
+func checkExported(t reflect.Type, field reflect.StructField) error {
+	if field.PkgPath != "" {
+		return fmt.Errorf("unexported field %v of %v, export it or tag it by `bincode:\"-\"`", field.Name, t)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Package bip39 implements mnemonic sentences of BIP-0039, https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	wordCount = 2048
+	// every word is an 11 bits index of the wordlist
+	bitsPerWord = 11
+
+	seedIterations = 2048
+	seedSize       = 64
+)
+
+var (
+	ErrInvalidEntropySize = errors.New("entropy size should be 128, 160, 192, 224 or 256 bits")
+	ErrInvalidWordCount   = errors.New("word count should be 12, 15, 18, 21 or 24")
+	ErrInvalidWord        = errors.New("word is not in the wordlist")
+	ErrChecksumMismatch   = errors.New("mnemonic checksum mismatch")
+	ErrUnknownLanguage    = errors.New("failed to detect the mnemonic language")
+)
+
+// NewEntropy returns random entropy of the bit size, it should be 128 for 12 words or 256 for 24 words
+func NewEntropy(bitSize int) ([]byte, error) {
+	if err := validateEntropySize(bitSize); err != nil {
+		return nil, err
+	}
+	entropy := make([]byte, bitSize/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	return entropy, nil
+}
+
+// GenerateMnemonic generates a random mnemonic with the number of words
+func GenerateMnemonic(words int, language Language) (string, error) {
+	if words%3 != 0 || words < 12 || words > 24 {
+		return "", ErrInvalidWordCount
+	}
+	// each 3 words carry 32 bits entropy and 1 bit checksum
+	entropy, err := NewEntropy(words / 3 * 32)
+	if err != nil {
+		return "", err
+	}
+	return NewMnemonic(entropy, language)
+}
+
+// NewMnemonic encodes the entropy to a mnemonic
+func NewMnemonic(entropy []byte, language Language) (string, error) {
+	if err := validateEntropySize(len(entropy) * 8); err != nil {
+		return "", err
+	}
+	w, err := getWordlist(language)
+	if err != nil {
+		return "", err
+	}
+
+	// the checksum is the first ENT/32 bits of the sha256 of the entropy
+	hash := sha256.Sum256(entropy)
+	bits := append(append(make([]byte, 0, len(entropy)+1), entropy...), hash[0])
+
+	n := (len(entropy)*8 + len(entropy)/4) / bitsPerWord
+	words := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		idx := 0
+		for j := i * bitsPerWord; j < (i+1)*bitsPerWord; j++ {
+			idx = idx<<1 | getBit(bits, j)
+		}
+		words = append(words, w.words[idx])
+	}
+	return strings.Join(words, separator(language)), nil
+}
+
+// MnemonicToEntropy decodes a mnemonic and verifies its checksum
+func MnemonicToEntropy(mnemonic string, language Language) ([]byte, error) {
+	w, err := getWordlist(language)
+	if err != nil {
+		return nil, err
+	}
+	words := strings.Fields(nfkd(mnemonic))
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, ErrInvalidWordCount
+	}
+
+	bitLen := len(words) * bitsPerWord
+	bits := make([]byte, (bitLen+7)/8)
+	for i, word := range words {
+		idx, ok := w.index[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWord, word)
+		}
+		for j := 0; j < bitsPerWord; j++ {
+			if idx&(1<<(bitsPerWord-1-j)) != 0 {
+				setBit(bits, i*bitsPerWord+j)
+			}
+		}
+	}
+
+	checksumLen := bitLen / 33
+	entropy := bits[:(bitLen-checksumLen)/8]
+	hash := sha256.Sum256(entropy)
+	for i := 0; i < checksumLen; i++ {
+		if getBit(hash[:], i) != getBit(bits, len(entropy)*8+i) {
+			return nil, ErrChecksumMismatch
+		}
+	}
+	return append([]byte{}, entropy...), nil
+}
+
+// ValidateMnemonic checks the words and the checksum of a mnemonic
+func ValidateMnemonic(mnemonic string, language Language) error {
+	_, err := MnemonicToEntropy(mnemonic, language)
+	return err
+}
+
+// DetectLanguage returns the first language which the mnemonic is valid in
+func DetectLanguage(mnemonic string) (Language, error) {
+	for _, language := range Languages {
+		if ValidateMnemonic(mnemonic, language) == nil {
+			return language, nil
+		}
+	}
+	return "", ErrUnknownLanguage
+}
+
+// NewSeed derives a 64 bytes seed from a mnemonic and a passphrase. the mnemonic is not validated,
+// call ValidateMnemonic first if it is needed.
+func NewSeed(mnemonic string, passphrase string) []byte {
+	return pbkdf2.Key(
+		[]byte(nfkd(mnemonic)),
+		[]byte("mnemonic"+nfkd(passphrase)),
+		seedIterations,
+		seedSize,
+		sha512.New,
+	)
+}
+
+// NewSeedWithValidation validates the mnemonic in any supported language and derives the seed
+func NewSeedWithValidation(mnemonic string, passphrase string) ([]byte, error) {
+	if _, err := DetectLanguage(mnemonic); err != nil {
+		return nil, err
+	}
+	return NewSeed(mnemonic, passphrase), nil
+}
+
+func validateEntropySize(bitSize int) error {
+	if bitSize%32 != 0 || bitSize < 128 || bitSize > 256 {
+		return ErrInvalidEntropySize
+	}
+	return nil
+}
+
+func nfkd(s string) string {
+	return norm.NFKD.String(s)
+}
+
+func getBit(b []byte, i int) int {
+	return int(b[i/8]>>(7-i%8)) & 1
+}
+
+func setBit(b []byte, i int) {
+	b[i/8] |= 1 << (7 - i%8)
+}
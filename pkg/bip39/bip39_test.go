@@ -0,0 +1,156 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonic(t *testing.T) {
+	type args struct {
+		entropy    string
+		language   Language
+		passphrase string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mnemonic string
+		seed     string
+	}{
+		{
+			args: args{
+				entropy:    "00000000000000000000000000000000",
+				language:   English,
+				passphrase: "TREZOR",
+			},
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			args: args{
+				entropy:    "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+				language:   English,
+				passphrase: "TREZOR",
+			},
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			args: args{
+				entropy:    "ffffffffffffffffffffffffffffffff",
+				language:   English,
+				passphrase: "TREZOR",
+			},
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			seed:     "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+		{
+			args: args{
+				entropy:    "0000000000000000000000000000000000000000000000000000000000000000",
+				language:   English,
+				passphrase: "TREZOR",
+			},
+			mnemonic: strings.Repeat("abandon ", 23) + "art",
+			seed:     "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+		{
+			args: args{
+				entropy:    "00000000000000000000000000000000",
+				language:   Japanese,
+				passphrase: "㍍ガバヴァぱばぐゞちぢ十人十色",
+			},
+			mnemonic: strings.Repeat("あいこくしん　", 11) + "あおぞら",
+			seed:     "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entropy, _ := hex.DecodeString(tt.args.entropy)
+
+			mnemonic, err := NewMnemonic(entropy, tt.args.language)
+			assert.Nil(t, err)
+			// the wordlists are not in the same unicode normalization form
+			assert.Equal(t, nfkd(tt.mnemonic), nfkd(mnemonic))
+
+			gotEntropy, err := MnemonicToEntropy(mnemonic, tt.args.language)
+			assert.Nil(t, err)
+			assert.Equal(t, entropy, gotEntropy)
+
+			language, err := DetectLanguage(mnemonic)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.args.language, language)
+
+			assert.Equal(t, tt.seed, hex.EncodeToString(NewSeed(mnemonic, tt.args.passphrase)))
+		})
+	}
+}
+
+func TestGenerateMnemonic(t *testing.T) {
+	for _, language := range Languages {
+		for _, words := range []int{12, 24} {
+			mnemonic, err := GenerateMnemonic(words, language)
+			assert.Nil(t, err)
+			assert.Len(t, strings.Fields(nfkd(mnemonic)), words)
+			assert.Nil(t, ValidateMnemonic(mnemonic, language))
+		}
+	}
+
+	_, err := GenerateMnemonic(13, English)
+	assert.Equal(t, ErrInvalidWordCount, err)
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		language Language
+		err      error
+	}{
+		{
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			language: English,
+			err:      nil,
+		},
+		{
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			language: English,
+			err:      ErrChecksumMismatch,
+		},
+		{
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon solana",
+			language: English,
+			err:      ErrInvalidWord,
+		},
+		{
+			mnemonic: "abandon abandon abandon",
+			language: English,
+			err:      ErrInvalidWordCount,
+		},
+		{
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			language: French,
+			err:      ErrInvalidWord,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMnemonic(tt.mnemonic, tt.language)
+			assert.True(t, errors.Is(err, tt.err), err)
+		})
+	}
+}
+
+func TestWordlist(t *testing.T) {
+	for _, language := range Languages {
+		words, err := Wordlist(language)
+		assert.Nil(t, err)
+		assert.Len(t, words, wordCount)
+	}
+
+	_, err := Wordlist("klingon")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,93 @@
+package bip39
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed wordlists/*.txt
+var wordlistFS embed.FS
+
+type Language string
+
+const (
+	English            Language = "english"
+	Japanese           Language = "japanese"
+	Korean             Language = "korean"
+	Spanish            Language = "spanish"
+	ChineseSimplified  Language = "chinese_simplified"
+	ChineseTraditional Language = "chinese_traditional"
+	French             Language = "french"
+	Italian            Language = "italian"
+	Czech              Language = "czech"
+)
+
+// Languages is all supported languages
+var Languages = []Language{
+	English,
+	Japanese,
+	Korean,
+	Spanish,
+	ChineseSimplified,
+	ChineseTraditional,
+	French,
+	Italian,
+	Czech,
+}
+
+type wordlist struct {
+	words []string
+	index map[string]int
+}
+
+var (
+	wordlistsMu sync.Mutex
+	wordlists   = map[Language]*wordlist{}
+)
+
+func getWordlist(language Language) (*wordlist, error) {
+	wordlistsMu.Lock()
+	defer wordlistsMu.Unlock()
+
+	if w, ok := wordlists[language]; ok {
+		return w, nil
+	}
+	b, err := wordlistFS.ReadFile(fmt.Sprintf("wordlists/%v.txt", language))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported language: %v", language)
+	}
+	words := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(words) != wordCount {
+		return nil, fmt.Errorf("invalid wordlist %v, expected %v words but got %v", language, wordCount, len(words))
+	}
+	w := &wordlist{
+		words: words,
+		index: make(map[string]int, len(words)),
+	}
+	for i, word := range words {
+		w.index[nfkd(word)] = i
+	}
+	wordlists[language] = w
+	return w, nil
+}
+
+// Wordlist returns a copy of the wordlist of the language
+func Wordlist(language Language) ([]string, error) {
+	w, err := getWordlist(language)
+	if err != nil {
+		return nil, err
+	}
+	words := make([]string, len(w.words))
+	copy(words, w.words)
+	return words, nil
+}
+
+// separator returns the word separator of the language, japanese uses an ideographic space
+func separator(language Language) string {
+	if language == Japanese {
+		return "　"
+	}
+	return " "
+}
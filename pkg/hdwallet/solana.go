@@ -0,0 +1,49 @@
+package hdwallet
+
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/pkg/bip39"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// SolanaPath returns the derivation path used by phantom, solflare and solana-keygen, m/44'/501'/index'/0'
+func SolanaPath(index uint32) string {
+	return fmt.Sprintf("m/44'/501'/%d'/0'", index)
+}
+
+// AccountFromSeed derives an account from a bip39 seed by the path
+func AccountFromSeed(seed []byte, path string) (types.Account, error) {
+	key, err := Derived(path, seed)
+	if err != nil {
+		return types.Account{}, err
+	}
+	return types.AccountFromSeed(key.PrivateKey)
+}
+
+// AccountFromMnemonic validates the mnemonic and derives an account by the path
+func AccountFromMnemonic(mnemonic, passphrase, path string) (types.Account, error) {
+	seed, err := bip39.NewSeedWithValidation(mnemonic, passphrase)
+	if err != nil {
+		return types.Account{}, err
+	}
+	return AccountFromSeed(seed, path)
+}
+
+// AccountsFromMnemonic derives count accounts on the solana path from the start index,
+// it is how wallets enumerate the accounts of an imported mnemonic.
+func AccountsFromMnemonic(mnemonic, passphrase string, start, count uint32) ([]types.Account, error) {
+	seed, err := bip39.NewSeedWithValidation(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]types.Account, 0, count)
+	for i := uint32(0); i < count; i++ {
+		account, err := AccountFromSeed(seed, SolanaPath(start+i))
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
@@ -0,0 +1,46 @@
+package hdwallet
+
+import (
+	"testing"
+
+	"github.com/portto/solana-go-sdk/pkg/bip39"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMnemonic = "neither lonely flavor argue grass remind eye tag avocado spot unusual intact"
+
+func TestSolanaPath(t *testing.T) {
+	assert.Equal(t, "m/44'/501'/0'/0'", SolanaPath(0))
+	assert.Equal(t, "m/44'/501'/12'/0'", SolanaPath(12))
+}
+
+func TestAccountFromMnemonic(t *testing.T) {
+	account, err := AccountFromMnemonic(testMnemonic, "", SolanaPath(1))
+	assert.Nil(t, err)
+	assert.Equal(t, "GcXbfQ5yY3uxCyBNDPBbR5FjumHf89E7YHXuULfGDBBv", account.PublicKey.ToBase58())
+
+	_, err = AccountFromMnemonic("neither lonely flavor argue grass remind eye tag avocado spot unusual unusual", "", SolanaPath(0))
+	assert.Equal(t, bip39.ErrUnknownLanguage, err)
+
+	_, err = AccountFromMnemonic(testMnemonic, "", "m/44/501")
+	assert.NotNil(t, err)
+}
+
+func TestAccountsFromMnemonic(t *testing.T) {
+	accounts, err := AccountsFromMnemonic(testMnemonic, "", 0, 3)
+	assert.Nil(t, err)
+	assert.Len(t, accounts, 3)
+
+	want := []string{
+		"5vftMkHL72JaJG6ExQfGAsT2uGVHpRR7oTNUPMs68Y2N",
+		"GcXbfQ5yY3uxCyBNDPBbR5FjumHf89E7YHXuULfGDBBv",
+		"7QPgyQwNLqnoSwHEuK8wKy2Y3Ani6EHoZRihTuWkwxbc",
+	}
+	for i, account := range accounts {
+		assert.Equal(t, want[i], account.PublicKey.ToBase58())
+	}
+
+	accounts, err = AccountsFromMnemonic(testMnemonic, "", 2, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, want[2], accounts[0].PublicKey.ToBase58())
+}
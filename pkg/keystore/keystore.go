@@ -0,0 +1,252 @@
+// Package keystore stores an account at rest encrypted by a password. the key is derived by scrypt or
+// argon2id and the private key is sealed by AES-256-GCM.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	Version = 1
+
+	cipherAES256GCM = "aes-256-gcm"
+	keyLen          = 32
+	saltLen         = 32
+
+	// upper bounds of kdf params so a crafted keystore can't make decryption take gigabytes or minutes
+	maxScryptN        = 1 << 20
+	maxScryptMemory   = 1 << 30 // bytes, scrypt takes 128*N*R
+	maxScryptP        = 16
+	maxArgon2idTime   = 64
+	maxArgon2idMemory = 1 << 20 // KiB
+)
+
+var (
+	ErrWrongPassword      = errors.New("wrong password or corrupted keystore")
+	ErrUnsupportedVersion = errors.New("unsupported keystore version")
+	ErrInvalidKDFParams   = errors.New("invalid kdf params")
+)
+
+type KDF string
+
+const (
+	KDFScrypt   KDF = "scrypt"
+	KDFArgon2id KDF = "argon2id"
+)
+
+// KDFParams is the parameters of a key derivation function, it is ScryptParams or Argon2idParams
+type KDFParams interface {
+	kdf() KDF
+	deriveKey(password, salt []byte) ([]byte, error)
+}
+
+type ScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// DefaultScryptParams costs about 128 MiB memory
+var DefaultScryptParams = ScryptParams{N: 1 << 17, R: 8, P: 1}
+
+func (p ScryptParams) kdf() KDF {
+	return KDFScrypt
+}
+
+func (p ScryptParams) deriveKey(password, salt []byte) ([]byte, error) {
+	if p.N <= 1 || p.N > maxScryptN || p.R <= 0 || p.P <= 0 || p.P > maxScryptP || p.R > maxScryptMemory/(128*p.N) {
+		return nil, fmt.Errorf("%w, scrypt n: %v, r: %v, p: %v", ErrInvalidKDFParams, p.N, p.R, p.P)
+	}
+	return scrypt.Key(password, salt, p.N, p.R, p.P, keyLen)
+}
+
+type Argon2idParams struct {
+	Time uint32 `json:"time"`
+	// Memory is in KiB
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// DefaultArgon2idParams costs 64 MiB memory
+var DefaultArgon2idParams = Argon2idParams{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+func (p Argon2idParams) kdf() KDF {
+	return KDFArgon2id
+}
+
+func (p Argon2idParams) deriveKey(password, salt []byte) ([]byte, error) {
+	if p.Time == 0 || p.Time > maxArgon2idTime || p.Memory > maxArgon2idMemory || p.Threads == 0 {
+		return nil, fmt.Errorf("%w, argon2id time: %v, memory: %v, threads: %v", ErrInvalidKDFParams, p.Time, p.Memory, p.Threads)
+	}
+	return argon2.IDKey(password, salt, p.Time, p.Memory, p.Threads, keyLen), nil
+}
+
+type keystoreJSON struct {
+	Version int        `json:"version"`
+	Pubkey  string     `json:"pubkey"`
+	Crypto  cryptoJSON `json:"crypto"`
+}
+
+type cryptoJSON struct {
+	Cipher     string          `json:"cipher"`
+	Ciphertext string          `json:"ciphertext"`
+	Nonce      string          `json:"nonce"`
+	KDF        KDF             `json:"kdf"`
+	KDFParams  json.RawMessage `json:"kdfparams"`
+	Salt       string          `json:"salt"`
+}
+
+// Encrypt encrypts the account by the password, the result is json
+func Encrypt(account types.Account, password string, params KDFParams) ([]byte, error) {
+	if params == nil {
+		return nil, errors.New("kdf params is required")
+	}
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key, err := params.deriveKey([]byte(password), salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key, err: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// the public key is authenticated so it can't be swapped
+	ciphertext := aead.Seal(nil, nonce, account.PrivateKey, account.PublicKey.Bytes())
+
+	kdfParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(keystoreJSON{
+		Version: Version,
+		Pubkey:  account.PublicKey.ToBase58(),
+		Crypto: cryptoJSON{
+			Cipher:     cipherAES256GCM,
+			Ciphertext: hex.EncodeToString(ciphertext),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        params.kdf(),
+			KDFParams:  kdfParams,
+			Salt:       hex.EncodeToString(salt),
+		},
+	})
+}
+
+// Decrypt decrypts a keystore by the password
+func Decrypt(data []byte, password string) (types.Account, error) {
+	var ks keystoreJSON
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return types.Account{}, fmt.Errorf("failed to parse keystore, err: %v", err)
+	}
+	if ks.Version != Version {
+		return types.Account{}, fmt.Errorf("%w: %v", ErrUnsupportedVersion, ks.Version)
+	}
+	if ks.Crypto.Cipher != cipherAES256GCM {
+		return types.Account{}, fmt.Errorf("unsupported cipher: %v", ks.Crypto.Cipher)
+	}
+
+	var params KDFParams
+	switch ks.Crypto.KDF {
+	case KDFScrypt:
+		var p ScryptParams
+		if err := json.Unmarshal(ks.Crypto.KDFParams, &p); err != nil {
+			return types.Account{}, fmt.Errorf("failed to parse kdf params, err: %v", err)
+		}
+		params = p
+	case KDFArgon2id:
+		var p Argon2idParams
+		if err := json.Unmarshal(ks.Crypto.KDFParams, &p); err != nil {
+			return types.Account{}, fmt.Errorf("failed to parse kdf params, err: %v", err)
+		}
+		params = p
+	default:
+		return types.Account{}, fmt.Errorf("unsupported kdf: %v", ks.Crypto.KDF)
+	}
+
+	salt, err := hex.DecodeString(ks.Crypto.Salt)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to decode salt, err: %v", err)
+	}
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to decode nonce, err: %v", err)
+	}
+	ciphertext, err := hex.DecodeString(ks.Crypto.Ciphertext)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to decode ciphertext, err: %v", err)
+	}
+
+	key, err := params.deriveKey([]byte(password), salt)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to derive key, err: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return types.Account{}, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return types.Account{}, fmt.Errorf("invalid nonce size %v", len(nonce))
+	}
+	pubkey := common.PublicKeyFromString(ks.Pubkey)
+	privateKey, err := aead.Open(nil, nonce, ciphertext, pubkey.Bytes())
+	if err != nil {
+		return types.Account{}, ErrWrongPassword
+	}
+	account, err := types.AccountFromBytes(privateKey)
+	if err != nil {
+		return types.Account{}, err
+	}
+	if account.PublicKey != pubkey {
+		return types.Account{}, errors.New("public key mismatch")
+	}
+	return account, nil
+}
+
+// SaveFile encrypts the account and writes it to the path, the file is only readable by the owner
+func SaveFile(path string, account types.Account, password string, params KDFParams) error {
+	data, err := Encrypt(account, password, params)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0o600)
+}
+
+// LoadFile reads and decrypts a keystore file
+func LoadFile(path string, password string) (types.Account, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return types.Account{}, err
+	}
+	return Decrypt(data, password)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
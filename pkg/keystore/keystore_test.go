@@ -0,0 +1,106 @@
+package keystore
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// cheap params to keep tests fast
+var (
+	testScryptParams   = ScryptParams{N: 1 << 10, R: 8, P: 1}
+	testArgon2idParams = Argon2idParams{Time: 1, Memory: 1024, Threads: 1}
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	account := types.NewAccount()
+
+	tests := []struct {
+		name   string
+		params KDFParams
+	}{
+		{name: "scrypt", params: testScryptParams},
+		{name: "argon2id", params: testArgon2idParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Encrypt(account, "password", tt.params)
+			assert.NoError(t, err)
+
+			var ks keystoreJSON
+			assert.NoError(t, json.Unmarshal(data, &ks))
+			assert.Equal(t, account.PublicKey.ToBase58(), ks.Pubkey)
+			assert.Equal(t, tt.params.kdf(), ks.Crypto.KDF)
+
+			got, err := Decrypt(data, "password")
+			assert.NoError(t, err)
+			assert.Equal(t, account, got)
+
+			_, err = Decrypt(data, "wrong")
+			assert.ErrorIs(t, err, ErrWrongPassword)
+		})
+	}
+}
+
+func TestDecrypt_Tampered(t *testing.T) {
+	account := types.NewAccount()
+	data, err := Encrypt(account, "password", testScryptParams)
+	assert.NoError(t, err)
+
+	var ks keystoreJSON
+	assert.NoError(t, json.Unmarshal(data, &ks))
+	ks.Pubkey = types.NewAccount().PublicKey.ToBase58()
+	tampered, err := json.Marshal(ks)
+	assert.NoError(t, err)
+	_, err = Decrypt(tampered, "password")
+	assert.ErrorIs(t, err, ErrWrongPassword)
+
+	ks.Version = 2
+	tampered, err = json.Marshal(ks)
+	assert.NoError(t, err)
+	_, err = Decrypt(tampered, "password")
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestDecrypt_KDFParamsTooLarge(t *testing.T) {
+	account := types.NewAccount()
+
+	tests := []struct {
+		name      string
+		params    KDFParams
+		kdfParams string
+	}{
+		{name: "scrypt n", params: testScryptParams, kdfParams: `{"n":2097152,"r":8,"p":1}`},
+		{name: "scrypt memory", params: testScryptParams, kdfParams: `{"n":1048576,"r":16,"p":1}`},
+		{name: "scrypt p", params: testScryptParams, kdfParams: `{"n":1024,"r":8,"p":1000}`},
+		{name: "argon2id memory", params: testArgon2idParams, kdfParams: `{"time":1,"memory":4194304,"threads":1}`},
+		{name: "argon2id time", params: testArgon2idParams, kdfParams: `{"time":100000,"memory":1024,"threads":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Encrypt(account, "password", tt.params)
+			assert.NoError(t, err)
+
+			var ks keystoreJSON
+			assert.NoError(t, json.Unmarshal(data, &ks))
+			ks.Crypto.KDFParams = json.RawMessage(tt.kdfParams)
+			crafted, err := json.Marshal(ks)
+			assert.NoError(t, err)
+			_, err = Decrypt(crafted, "password")
+			assert.ErrorIs(t, err, ErrInvalidKDFParams)
+		})
+	}
+}
+
+func TestSaveLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore", "signer.json")
+	account := types.NewAccount()
+
+	assert.NoError(t, SaveFile(path, account, "password", testArgon2idParams))
+	got, err := LoadFile(path, "password")
+	assert.NoError(t, err)
+	assert.Equal(t, account, got)
+}
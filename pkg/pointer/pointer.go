@@ -24,6 +24,10 @@ func Uint64(v uint64) *uint64 {
 	return &v
 }
 
+func Int(v int) *int {
+	return &v
+}
+
 func Int64(v int64) *int64 {
 	return &v
 }
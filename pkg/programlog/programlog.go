@@ -0,0 +1,288 @@
+// Package programlog parses the log messages of a transaction into the invocation tree of programs
+package programlog
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+const (
+	prefixProgram       = "Program "
+	prefixProgramLog    = "Program log: "
+	prefixProgramData   = "Program data: "
+	prefixProgramReturn = "Program return: "
+	logTruncated        = "Log truncated"
+)
+
+// Invocation is a program invocation, an instruction of the transaction or a cross-program invocation
+type Invocation struct {
+	ProgramID common.PublicKey
+	// Depth starts from 1 for instructions of the transaction
+	Depth int
+	// Logs are "Program log:" messages without the prefix
+	Logs []string
+	// Data are the fields of each "Program data:" line, e.g. anchor events
+	Data [][][]byte
+	// ReturnData is set by "Program return:"
+	ReturnData []byte
+	// ComputeUnitsConsumed includes the compute units consumed by inner invocations
+	ComputeUnitsConsumed uint64
+	// Success and Err are both empty if the logs are truncated before the invocation ends
+	Success bool
+	Err     string
+	// OtherLogs are lines which are not written in a known format, e.g. messages of the system program
+	OtherLogs   []string
+	Invocations []*Invocation
+}
+
+// Result is the parsed logs of a transaction
+type Result struct {
+	// Invocations are the instructions of the transaction in order, unless the logs are truncated
+	Invocations []*Invocation
+	// Truncated means the runtime dropped the logs after the log limit is reached
+	Truncated bool
+	// OtherLogs are lines which are outside any invocation
+	OtherLogs []string
+}
+
+// Parse parses log messages from GetTransaction or SimulateTransaction.
+// it doesn't fail on unknown or malformed lines, they are kept in OtherLogs.
+func Parse(logs []string) Result {
+	var result Result
+	var stack []*Invocation
+
+	other := func(line string) {
+		if len(stack) == 0 {
+			result.OtherLogs = append(result.OtherLogs, line)
+			return
+		}
+		top := stack[len(stack)-1]
+		top.OtherLogs = append(top.OtherLogs, line)
+	}
+
+	for _, line := range logs {
+		var top *Invocation
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		switch {
+		case line == logTruncated:
+			result.Truncated = true
+
+		case strings.HasPrefix(line, prefixProgramLog):
+			if top == nil {
+				other(line)
+				continue
+			}
+			top.Logs = append(top.Logs, strings.TrimPrefix(line, prefixProgramLog))
+
+		case strings.HasPrefix(line, prefixProgramData):
+			fields, ok := decodeFields(strings.Fields(strings.TrimPrefix(line, prefixProgramData)))
+			if top == nil || !ok {
+				other(line)
+				continue
+			}
+			top.Data = append(top.Data, fields)
+
+		case strings.HasPrefix(line, prefixProgramReturn):
+			parts := strings.Fields(strings.TrimPrefix(line, prefixProgramReturn))
+			if top == nil || len(parts) == 0 || len(parts) > 2 {
+				other(line)
+				continue
+			}
+			programID, ok := parseProgramID(parts[0])
+			if !ok || programID != top.ProgramID {
+				other(line)
+				continue
+			}
+			data := []byte{}
+			if len(parts) == 2 {
+				var err error
+				data, err = base64.StdEncoding.DecodeString(parts[1])
+				if err != nil {
+					other(line)
+					continue
+				}
+			}
+			top.ReturnData = data
+
+		case strings.HasPrefix(line, prefixProgram):
+			parts := strings.SplitN(strings.TrimPrefix(line, prefixProgram), " ", 2)
+			if len(parts) != 2 {
+				other(line)
+				continue
+			}
+			programID, ok := parseProgramID(parts[0])
+			if !ok {
+				other(line)
+				continue
+			}
+			rest := parts[1]
+
+			switch {
+			case strings.HasPrefix(rest, "invoke [") && strings.HasSuffix(rest, "]"):
+				depth, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rest, "invoke ["), "]"))
+				if err != nil {
+					other(line)
+					continue
+				}
+				invocation := &Invocation{ProgramID: programID, Depth: depth}
+				if top == nil {
+					result.Invocations = append(result.Invocations, invocation)
+				} else {
+					top.Invocations = append(top.Invocations, invocation)
+				}
+				stack = append(stack, invocation)
+
+			case rest == "success":
+				i := findInvocation(stack, programID)
+				if i < 0 {
+					other(line)
+					continue
+				}
+				stack[i].Success = true
+				stack = stack[:i]
+
+			case strings.HasPrefix(rest, "failed: "):
+				i := findInvocation(stack, programID)
+				if i < 0 {
+					other(line)
+					continue
+				}
+				stack[i].Err = strings.TrimPrefix(rest, "failed: ")
+				stack = stack[:i]
+
+			case strings.HasPrefix(rest, "consumed "):
+				// Program <id> consumed <n> of <m> compute units
+				fields := strings.Fields(rest)
+				if len(fields) != 6 || fields[2] != "of" || top == nil || top.ProgramID != programID {
+					other(line)
+					continue
+				}
+				consumed, err := strconv.ParseUint(fields[1], 10, 64)
+				if err != nil {
+					other(line)
+					continue
+				}
+				top.ComputeUnitsConsumed = consumed
+
+			default:
+				other(line)
+			}
+
+		default:
+			other(line)
+		}
+	}
+
+	return result
+}
+
+// FailedInvocation returns the innermost failed invocation, it is nil if nothing failed
+func (r Result) FailedInvocation() *Invocation {
+	var failed *Invocation
+	walk(r.Invocations, func(invocation *Invocation) {
+		if invocation.Err != "" && (failed == nil || invocation.Depth > failed.Depth) {
+			failed = invocation
+		}
+	})
+	return failed
+}
+
+// ComputeUnitsByProgram sums the compute units consumed by each program itself,
+// the units consumed by inner invocations are attributed to the invoked programs.
+func (r Result) ComputeUnitsByProgram() map[common.PublicKey]uint64 {
+	units := map[common.PublicKey]uint64{}
+	walk(r.Invocations, func(invocation *Invocation) {
+		units[invocation.ProgramID] += invocation.ExclusiveComputeUnits()
+	})
+	return units
+}
+
+// ExclusiveComputeUnits is ComputeUnitsConsumed without the units consumed by inner invocations
+func (i *Invocation) ExclusiveComputeUnits() uint64 {
+	var inner uint64
+	for _, invocation := range i.Invocations {
+		inner += invocation.ComputeUnitsConsumed
+	}
+	if inner > i.ComputeUnitsConsumed {
+		return 0
+	}
+	return i.ComputeUnitsConsumed - inner
+}
+
+// AnchorEvent is an event emitted by the emit! macro of anchor
+type AnchorEvent struct {
+	ProgramID     common.PublicKey
+	Discriminator [8]byte
+	// Data is the borsh serialized event without the discriminator
+	Data []byte
+}
+
+// AnchorEvents returns "Program data:" lines which look like anchor events in order
+func (r Result) AnchorEvents() []AnchorEvent {
+	var events []AnchorEvent
+	walk(r.Invocations, func(invocation *Invocation) {
+		for _, fields := range invocation.Data {
+			if len(fields) != 1 || len(fields[0]) < 8 {
+				continue
+			}
+			event := AnchorEvent{ProgramID: invocation.ProgramID, Data: fields[0][8:]}
+			copy(event.Discriminator[:], fields[0][:8])
+			events = append(events, event)
+		}
+	})
+	return events
+}
+
+// AnchorEventDiscriminator returns the discriminator of an anchor event by its name
+func AnchorEventDiscriminator(name string) [8]byte {
+	var discriminator [8]byte
+	h := sha256.Sum256([]byte("event:" + name))
+	copy(discriminator[:], h[:8])
+	return discriminator
+}
+
+// walk visits invocations in the order they are invoked
+func walk(invocations []*Invocation, fn func(*Invocation)) {
+	for _, invocation := range invocations {
+		fn(invocation)
+		walk(invocation.Invocations, fn)
+	}
+}
+
+// findInvocation returns the index of the innermost invocation of the program in the stack, or -1
+func findInvocation(stack []*Invocation, programID common.PublicKey) int {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].ProgramID == programID {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseProgramID(s string) (common.PublicKey, bool) {
+	b, err := base58.Decode(s)
+	if err != nil || len(b) != common.PublicKeyLength {
+		return common.PublicKey{}, false
+	}
+	return common.PublicKeyFromBytes(b), true
+}
+
+func decodeFields(fields []string) ([][]byte, bool) {
+	decoded := make([][]byte, 0, len(fields))
+	for _, field := range fields {
+		b, err := base64.StdEncoding.DecodeString(field)
+		if err != nil {
+			return nil, false
+		}
+		decoded = append(decoded, b)
+	}
+	return decoded, true
+}
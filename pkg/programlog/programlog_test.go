@@ -0,0 +1,224 @@
+package programlog
+
+import (
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAnchorProgramID = common.PublicKeyFromString("whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc")
+
+func TestParse(t *testing.T) {
+	type args struct {
+		logs []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want Result
+	}{
+		{
+			name: "success with inner invocations",
+			args: args{
+				logs: []string{
+					"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL invoke [1]",
+					"Program log: Create",
+					"Program 11111111111111111111111111111111 invoke [2]",
+					"Program 11111111111111111111111111111111 success",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+					"Program log: Instruction: InitializeAccount3",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 3412 of 177045 compute units",
+					"Program return: TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA pQAAAAAAAAA=",
+					"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA success",
+					"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL consumed 27016 of 200000 compute units",
+					"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL success",
+					"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr invoke [1]",
+					`Program log: Memo (len 4): "memo"`,
+					"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr consumed 7206 of 172984 compute units",
+					"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr success",
+				},
+			},
+			want: Result{
+				Invocations: []*Invocation{
+					{
+						ProgramID:            common.SPLAssociatedTokenAccountProgramID,
+						Depth:                1,
+						Logs:                 []string{"Create"},
+						ComputeUnitsConsumed: 27016,
+						Success:              true,
+						Invocations: []*Invocation{
+							{
+								ProgramID: common.SystemProgramID,
+								Depth:     2,
+								Success:   true,
+							},
+							{
+								ProgramID:            common.TokenProgramID,
+								Depth:                2,
+								Logs:                 []string{"Instruction: InitializeAccount3"},
+								ReturnData:           []byte{165, 0, 0, 0, 0, 0, 0, 0},
+								ComputeUnitsConsumed: 3412,
+								Success:              true,
+							},
+						},
+					},
+					{
+						ProgramID:            common.MemoProgramID,
+						Depth:                1,
+						Logs:                 []string{`Memo (len 4): "memo"`},
+						ComputeUnitsConsumed: 7206,
+						Success:              true,
+					},
+				},
+			},
+		},
+		{
+			name: "failed",
+			args: args{
+				logs: []string{
+					"Program 11111111111111111111111111111111 invoke [1]",
+					"Transfer: insufficient lamports 0, need 1000",
+					"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+				},
+			},
+			want: Result{
+				Invocations: []*Invocation{
+					{
+						ProgramID: common.SystemProgramID,
+						Depth:     1,
+						Err:       "custom program error: 0x1",
+						OtherLogs: []string{"Transfer: insufficient lamports 0, need 1000"},
+					},
+				},
+			},
+		},
+		{
+			name: "program data",
+			args: args{
+				logs: []string{
+					"Program whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc invoke [1]",
+					"Program data: AQIDBAUGBwgJCg== CwwN",
+					"Program data: invalid!",
+					"Program whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc consumed 1000 of 200000 compute units",
+					"Program whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc success",
+				},
+			},
+			want: Result{
+				Invocations: []*Invocation{
+					{
+						ProgramID:            testAnchorProgramID,
+						Depth:                1,
+						Data:                 [][][]byte{{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, {11, 12, 13}}},
+						ComputeUnitsConsumed: 1000,
+						Success:              true,
+						OtherLogs:            []string{"Program data: invalid!"},
+					},
+				},
+			},
+		},
+		{
+			name: "truncated",
+			args: args{
+				logs: []string{
+					"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr invoke [1]",
+					"Program log: Memo",
+					"Log truncated",
+				},
+			},
+			want: Result{
+				Invocations: []*Invocation{
+					{
+						ProgramID: common.MemoProgramID,
+						Depth:     1,
+						Logs:      []string{"Memo"},
+					},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name: "outside invocations",
+			args: args{
+				logs: []string{
+					"Program log: orphan",
+					"Program is not deployed",
+				},
+			},
+			want: Result{
+				OtherLogs: []string{"Program log: orphan", "Program is not deployed"},
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				logs: nil,
+			},
+			want: Result{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.args.logs)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResult_ComputeUnitsByProgram(t *testing.T) {
+	result := Parse([]string{
+		"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL invoke [1]",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 3412 of 177045 compute units",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA success",
+		"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL consumed 27016 of 200000 compute units",
+		"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL success",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [1]",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 2000 of 172984 compute units",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA success",
+	})
+	assert.Equal(t, map[common.PublicKey]uint64{
+		common.SPLAssociatedTokenAccountProgramID: 23604,
+		common.TokenProgramID:                     5412,
+	}, result.ComputeUnitsByProgram())
+}
+
+func TestResult_FailedInvocation(t *testing.T) {
+	result := Parse([]string{
+		"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL invoke [1]",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+		"Program log: Error: insufficient funds",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1",
+		"Program ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL failed: custom program error: 0x1",
+	})
+	failed := result.FailedInvocation()
+	assert.Equal(t, common.TokenProgramID, failed.ProgramID)
+	assert.Equal(t, "custom program error: 0x1", failed.Err)
+	assert.Equal(t, []string{"Error: insufficient funds"}, failed.Logs)
+
+	assert.Nil(t, Parse([]string{
+		"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr invoke [1]",
+		"Program MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr success",
+	}).FailedInvocation())
+}
+
+func TestResult_AnchorEvents(t *testing.T) {
+	result := Parse([]string{
+		"Program whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc invoke [1]",
+		"Program data: AQIDBAUGBwgJCg==",
+		"Program data: AQID",
+		"Program whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc success",
+	})
+	assert.Equal(t, []AnchorEvent{
+		{
+			ProgramID:     testAnchorProgramID,
+			Discriminator: [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			Data:          []byte{9, 10},
+		},
+	}, result.AnchorEvents())
+}
+
+func TestAnchorEventDiscriminator(t *testing.T) {
+	// sha256("event:MyEvent")[:8]
+	assert.Equal(t, [8]byte{0x60, 0xb8, 0xc5, 0xf3, 0x8b, 0x02, 0x5a, 0x94}, AnchorEventDiscriminator("MyEvent"))
+}
@@ -0,0 +1,52 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// Command signs messages by an external process. every sign runs the command once, the request is
+// written to its stdin and the response is read from its stdout.
+type Command struct {
+	pubkey common.PublicKey
+	name   string
+	args   []string
+	// Env is the environment of the process, nil means the current environment
+	Env []string
+}
+
+func NewCommand(pubkey common.PublicKey, name string, args ...string) *Command {
+	return &Command{
+		pubkey: pubkey,
+		name:   name,
+		args:   args,
+	}
+}
+
+func (c *Command) PublicKey() common.PublicKey {
+	return c.pubkey
+}
+
+func (c *Command) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	payload, err := json.Marshal(NewSignRequest(c.pubkey, message))
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.name, c.args...)
+	cmd.Env = c.Env
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run %v, err: %v, stderr: %v", c.name, err, strings.TrimSpace(stderr.String()))
+	}
+	return parseSignResponse(stdout.Bytes())
+}
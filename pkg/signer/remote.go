@@ -0,0 +1,87 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// Remote signs messages by posting them to a http signer service
+type Remote struct {
+	url        string
+	pubkey     common.PublicKey
+	httpClient *http.Client
+	header     http.Header
+}
+
+type RemoteOption func(*Remote)
+
+// WithHTTPClient replaces the default http client
+func WithHTTPClient(h *http.Client) RemoteOption {
+	return func(r *Remote) {
+		r.httpClient = h
+	}
+}
+
+// WithHeader adds a header to every request, e.g. an authorization header
+func WithHeader(key, value string) RemoteOption {
+	return func(r *Remote) {
+		r.header.Add(key, value)
+	}
+}
+
+func NewRemote(url string, pubkey common.PublicKey, opts ...RemoteOption) *Remote {
+	r := &Remote{
+		url:        url,
+		pubkey:     pubkey,
+		httpClient: http.DefaultClient,
+		header:     http.Header{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Remote) PublicKey() common.PublicKey {
+	return r.pubkey
+}
+
+func (r *Remote) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	payload, err := json.Marshal(NewSignRequest(r.pubkey, message))
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", r.url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to do http.NewRequestWithContext, err: %v", err)
+	}
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do request, err: %v", err)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body, err: %v", err)
+	}
+	if res.StatusCode < 200 || res.StatusCode > 300 {
+		// the body may carry an error message
+		if _, err := parseSignResponse(body); err != nil {
+			return nil, fmt.Errorf("get status code: %v, err: %v", res.StatusCode, err)
+		}
+		return nil, fmt.Errorf("get status code: %v", res.StatusCode)
+	}
+	return parseSignResponse(body)
+}
@@ -0,0 +1,60 @@
+// Package signer implements types.Signer by keys outside the process.
+//
+// a remote signer and a command signer share the same json protocol. the request is
+//
+//	{"pubkey": "<base58 public key>", "message": "<base64 message>"}
+//
+// and the response is
+//
+//	{"signature": "<base58 signature>"}
+//
+// or {"error": "<reason>"} if the signer refuses to sign.
+package signer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+type SignRequest struct {
+	Pubkey  string `json:"pubkey"`
+	Message string `json:"message"`
+}
+
+type SignResponse struct {
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewSignRequest encodes a sign request of the message
+func NewSignRequest(pubkey common.PublicKey, message []byte) SignRequest {
+	return SignRequest{
+		Pubkey:  pubkey.ToBase58(),
+		Message: base64.StdEncoding.EncodeToString(message),
+	}
+}
+
+// DecodeMessage returns the message of the request, it is used by signer services
+func (r SignRequest) DecodeMessage() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(r.Message)
+}
+
+func parseSignResponse(body []byte) ([]byte, error) {
+	var res SignResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse sign response, err: %v", err)
+	}
+	if res.Error != "" {
+		return nil, errors.New(res.Error)
+	}
+	sig, err := base58.Decode(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature, err: %v", err)
+	}
+	return sig, nil
+}
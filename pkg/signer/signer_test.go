@@ -0,0 +1,98 @@
+package signer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAccount, _ = types.AccountFromBase58("5HNxRJoirY4oRTcRwiEYFALSSLn9nMAyLQKDuSuiCJ966816BjwGuamRdTLTsR2FBHiB7CQkGaw6B4ehBMogPRvW")
+
+// sign is a signer service backed by testAccount
+func sign(body []byte) SignResponse {
+	var req SignRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return SignResponse{Error: err.Error()}
+	}
+	if req.Pubkey != testAccount.PublicKey.ToBase58() {
+		return SignResponse{Error: "unknown pubkey"}
+	}
+	message, err := req.DecodeMessage()
+	if err != nil {
+		return SignResponse{Error: err.Error()}
+	}
+	return SignResponse{Signature: base58.Encode(testAccount.Sign(message))}
+}
+
+func TestRemote_Sign(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(rw).Encode(SignResponse{Error: "unauthorized"})
+			return
+		}
+		body, _ := ioutil.ReadAll(req.Body)
+		_ = json.NewEncoder(rw).Encode(sign(body))
+	}))
+	defer server.Close()
+
+	message := []byte("hello")
+
+	s := NewRemote(server.URL, testAccount.PublicKey, WithHeader("Authorization", "Bearer token"))
+	assert.Equal(t, testAccount.PublicKey, s.PublicKey())
+	sig, err := s.Sign(context.Background(), message)
+	assert.Nil(t, err)
+	assert.True(t, ed25519.Verify(testAccount.PublicKey.Bytes(), message, sig))
+
+	_, err = NewRemote(server.URL, testAccount.PublicKey).Sign(context.Background(), message)
+	assert.EqualError(t, err, "get status code: 401, err: unauthorized")
+
+	_, err = NewRemote(server.URL, types.NewAccount().PublicKey, WithHeader("Authorization", "Bearer token")).Sign(context.Background(), message)
+	assert.EqualError(t, err, "unknown pubkey")
+}
+
+// TestHelperProcess isn't a real test, it is the external process used by TestCommand_Sign
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	body, _ := ioutil.ReadAll(os.Stdin)
+	b, _ := json.Marshal(sign(body))
+	fmt.Print(string(b))
+	os.Exit(0)
+}
+
+func TestCommand_Sign(t *testing.T) {
+	message := []byte("hello")
+
+	s := NewCommand(testAccount.PublicKey, os.Args[0], "-test.run=TestHelperProcess")
+	s.Env = []string{"GO_WANT_HELPER_PROCESS=1"}
+	sig, err := s.Sign(context.Background(), message)
+	assert.Nil(t, err)
+	assert.True(t, ed25519.Verify(testAccount.PublicKey.Bytes(), message, sig))
+
+	// the tx verifies signatures of external signers
+	tx, err := types.NewTransaction(types.NewTransactionParam{
+		Message: types.NewMessage(types.NewMessageParam{
+			FeePayer:        testAccount.PublicKey,
+			RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		}),
+		ExternalSigners: []types.Signer{s},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, tx.Signatures, 1)
+
+	s = NewCommand(testAccount.PublicKey, os.Args[0], "-test.run=TestHelperProcess")
+	_, err = s.Sign(context.Background(), message)
+	assert.NotNil(t, err)
+}
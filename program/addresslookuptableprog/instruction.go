@@ -0,0 +1,174 @@
+package addresslookuptableprog
+
+import (
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/bincode"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+type Instruction uint32
+
+const (
+	InstructionCreateLookupTable Instruction = iota
+	InstructionFreezeLookupTable
+	InstructionExtendLookupTable
+	InstructionDeactivateLookupTable
+	InstructionCloseLookupTable
+)
+
+type CreateLookupTableParam struct {
+	LookupTable common.PublicKey
+	Authority   common.PublicKey
+	Payer       common.PublicKey
+	RecentSlot  uint64
+	BumpSeed    uint8
+}
+
+// CreateLookupTable create an address lookup table. the lookup table address and bump seed can be derived by FindLookupTableAddress
+func CreateLookupTable(param CreateLookupTableParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+		RecentSlot  uint64
+		BumpSeed    uint8
+	}{
+		Instruction: InstructionCreateLookupTable,
+		RecentSlot:  param.RecentSlot,
+		BumpSeed:    param.BumpSeed,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.LookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+			{PubKey: param.Payer, IsSigner: true, IsWritable: true},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type FreezeLookupTableParam struct {
+	LookupTable common.PublicKey
+	Authority   common.PublicKey
+}
+
+// FreezeLookupTable permanently freeze an address lookup table, making it immutable
+func FreezeLookupTable(param FreezeLookupTableParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionFreezeLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.LookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type ExtendLookupTableParam struct {
+	LookupTable common.PublicKey
+	Authority   common.PublicKey
+	// Payer is optional, it is required if the lookup table needs more lamports to stay rent exempt
+	Payer     *common.PublicKey
+	Addresses []common.PublicKey
+}
+
+// ExtendLookupTable append addresses to an address lookup table
+func ExtendLookupTable(param ExtendLookupTableParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction  Instruction
+		AddressCount uint64
+	}{
+		Instruction:  InstructionExtendLookupTable,
+		AddressCount: uint64(len(param.Addresses)),
+	})
+	if err != nil {
+		panic(err)
+	}
+	for _, address := range param.Addresses {
+		data = append(data, address.Bytes()...)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: param.LookupTable, IsSigner: false, IsWritable: true},
+		{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+	}
+	if param.Payer != nil {
+		accounts = append(accounts,
+			types.AccountMeta{PubKey: *param.Payer, IsSigner: true, IsWritable: true},
+			types.AccountMeta{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type DeactivateLookupTableParam struct {
+	LookupTable common.PublicKey
+	Authority   common.PublicKey
+}
+
+// DeactivateLookupTable deactivate an address lookup table, it can be closed after the deactivation slot is no longer "recent"
+func DeactivateLookupTable(param DeactivateLookupTableParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionDeactivateLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.LookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type CloseLookupTableParam struct {
+	LookupTable common.PublicKey
+	Authority   common.PublicKey
+	Recipient   common.PublicKey
+}
+
+// CloseLookupTable close a deactivated address lookup table and reclaim its lamports
+func CloseLookupTable(param CloseLookupTableParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionCloseLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.LookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: param.Authority, IsSigner: true, IsWritable: false},
+			{PubKey: param.Recipient, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
@@ -0,0 +1,218 @@
+package addresslookuptableprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestCreateLookupTable(t *testing.T) {
+	type args struct {
+		param CreateLookupTableParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: CreateLookupTableParam{
+					LookupTable: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"),
+					Authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Payer:       common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					RecentSlot:  151565329,
+					BumpSeed:    255,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{0, 0, 0, 0, 17, 180, 8, 9, 0, 0, 0, 0, 255},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CreateLookupTable(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateLookupTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFreezeLookupTable(t *testing.T) {
+	type args struct {
+		param FreezeLookupTableParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: FreezeLookupTableParam{
+					LookupTable: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"),
+					Authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{1, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FreezeLookupTable(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FreezeLookupTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtendLookupTable(t *testing.T) {
+	type args struct {
+		param ExtendLookupTableParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: ExtendLookupTableParam{
+					LookupTable: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"),
+					Authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Addresses: []common.PublicKey{
+						common.SystemProgramID,
+						common.SysVarClockPubkey,
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{2, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 6, 167, 213, 23, 24, 199, 116, 201, 40, 86, 99, 152, 105, 29, 94, 182, 139, 94, 184, 163, 155, 75, 109, 92, 115, 85, 91, 33, 0, 0, 0, 0},
+			},
+		},
+		{
+			args: args{
+				param: ExtendLookupTableParam{
+					LookupTable: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"),
+					Authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Payer:       pointer.Pubkey(common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")),
+					Addresses: []common.PublicKey{
+						common.SystemProgramID,
+					},
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtendLookupTable(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtendLookupTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeactivateLookupTable(t *testing.T) {
+	type args struct {
+		param DeactivateLookupTableParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: DeactivateLookupTableParam{
+					LookupTable: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"),
+					Authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{3, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeactivateLookupTable(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeactivateLookupTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloseLookupTable(t *testing.T) {
+	type args struct {
+		param CloseLookupTableParam
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				param: CloseLookupTableParam{
+					LookupTable: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"),
+					Authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					Recipient:   common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"),
+				},
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"), IsSigner: false, IsWritable: true},
+				},
+				Data: []byte{4, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CloseLookupTable(tt.args.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CloseLookupTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
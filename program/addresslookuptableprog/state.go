@@ -0,0 +1,94 @@
+package addresslookuptableprog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// LookupTableMetaSize is the size of the metadata header stored before the addresses
+const LookupTableMetaSize = 56
+
+// LookupTableMaxAddresses is the max number of addresses a lookup table can store
+const LookupTableMaxAddresses = 256
+
+// LookupTableStatus is the state type discriminator of a lookup table account
+type LookupTableStatus uint32
+
+const (
+	LookupTableStatusUninitialized LookupTableStatus = iota
+	LookupTableStatusLookupTable
+)
+
+var (
+	ErrInvalidAccountDataSize = errors.New("invalid account data size")
+	ErrUninitializedAccount   = errors.New("uninitialized lookup table account")
+)
+
+type AddressLookupTableMeta struct {
+	// DeactivationSlot is math.MaxUint64 if the lookup table is still active
+	DeactivationSlot uint64
+	// LastExtendedSlot is the slot where the lookup table was last extended
+	LastExtendedSlot uint64
+	// LastExtendedSlotStartIndex is the start index of addresses appended in the last extended slot
+	LastExtendedSlotStartIndex uint8
+	// Authority is nil if the lookup table is frozen
+	Authority *common.PublicKey
+}
+
+type AddressLookupTable struct {
+	AddressLookupTableMeta
+	Addresses []common.PublicKey
+}
+
+// IsActive returns whether the lookup table has not been deactivated
+func (t AddressLookupTable) IsActive() bool {
+	return t.DeactivationSlot == math.MaxUint64
+}
+
+// IsFrozen returns whether the lookup table can no longer be modified
+func (t AddressLookupTable) IsFrozen() bool {
+	return t.Authority == nil
+}
+
+func DeserializeLookupTable(data []byte) (AddressLookupTable, error) {
+	if len(data) < LookupTableMetaSize {
+		return AddressLookupTable{}, fmt.Errorf("%w, data size is not enough for lookup table meta", ErrInvalidAccountDataSize)
+	}
+	if (len(data)-LookupTableMetaSize)%32 != 0 {
+		return AddressLookupTable{}, fmt.Errorf("%w, addresses data size is not a multiple of 32", ErrInvalidAccountDataSize)
+	}
+
+	if LookupTableStatus(binary.LittleEndian.Uint32(data[:4])) != LookupTableStatusLookupTable {
+		return AddressLookupTable{}, ErrUninitializedAccount
+	}
+
+	deactivationSlot := binary.LittleEndian.Uint64(data[4:12])
+	lastExtendedSlot := binary.LittleEndian.Uint64(data[12:20])
+	lastExtendedSlotStartIndex := data[20]
+
+	var authority *common.PublicKey
+	if data[21] == 1 {
+		a := common.PublicKeyFromBytes(data[22:54])
+		authority = &a
+	}
+
+	// data[54:56] is padding
+	addresses := make([]common.PublicKey, 0, (len(data)-LookupTableMetaSize)/32)
+	for current := LookupTableMetaSize; current < len(data); current += 32 {
+		addresses = append(addresses, common.PublicKeyFromBytes(data[current:current+32]))
+	}
+
+	return AddressLookupTable{
+		AddressLookupTableMeta: AddressLookupTableMeta{
+			DeactivationSlot:           deactivationSlot,
+			LastExtendedSlot:           lastExtendedSlot,
+			LastExtendedSlotStartIndex: lastExtendedSlotStartIndex,
+			Authority:                  authority,
+		},
+		Addresses: addresses,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package addresslookuptableprog
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+)
+
+func TestDeserializeLookupTable(t *testing.T) {
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    AddressLookupTable
+		wantErr bool
+	}{
+		{
+			args: args{
+				data: []byte{1, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 18, 180, 8, 9, 0, 0, 0, 0, 0, 1, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 6, 167, 213, 23, 24, 199, 116, 201, 40, 86, 99, 152, 105, 29, 94, 182, 139, 94, 184, 163, 155, 75, 109, 92, 115, 85, 91, 33, 0, 0, 0, 0},
+			},
+			want: AddressLookupTable{
+				AddressLookupTableMeta: AddressLookupTableMeta{
+					DeactivationSlot:           math.MaxUint64,
+					LastExtendedSlot:           151565330,
+					LastExtendedSlotStartIndex: 0,
+					Authority:                  pointer.Pubkey(common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")),
+				},
+				Addresses: []common.PublicKey{
+					common.SystemProgramID,
+					common.SysVarClockPubkey,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{
+				data: []byte{1, 0, 0, 0, 88, 180, 8, 9, 0, 0, 0, 0, 18, 180, 8, 9, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+			want: AddressLookupTable{
+				AddressLookupTableMeta: AddressLookupTableMeta{
+					DeactivationSlot:           151565400,
+					LastExtendedSlot:           151565330,
+					LastExtendedSlotStartIndex: 1,
+					Authority:                  nil,
+				},
+				Addresses: []common.PublicKey{
+					common.SystemProgramID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			args: args{
+				data: []byte{1, 0, 0, 0},
+			},
+			want:    AddressLookupTable{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeLookupTable(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeserializeLookupTable() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeserializeLookupTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
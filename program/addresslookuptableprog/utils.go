@@ -0,0 +1,24 @@
+package addresslookuptableprog
+
+import (
+	"encoding/binary"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// FindLookupTableAddress derive a lookup table address from its authority and a recent slot
+func FindLookupTableAddress(authority common.PublicKey, recentSlot uint64) (common.PublicKey, uint8, error) {
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, recentSlot)
+	pubkey, bumpSeed, err := common.FindProgramAddress(
+		[][]byte{
+			authority.Bytes(),
+			slot,
+		},
+		common.AddressLookupTableProgramID,
+	)
+	if err != nil {
+		return common.PublicKey{}, 0, err
+	}
+	return pubkey, uint8(bumpSeed), nil
+}
@@ -0,0 +1,40 @@
+package addresslookuptableprog
+
+import (
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindLookupTableAddress(t *testing.T) {
+	type args struct {
+		authority  common.PublicKey
+		recentSlot uint64
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantPubkey   common.PublicKey
+		wantBumpSeed uint8
+		wantErr      error
+	}{
+		{
+			args: args{
+				authority:  common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				recentSlot: 151565329,
+			},
+			wantPubkey:   common.PublicKeyFromString("7ojmqUatFeorxdxCYZCi5UuSCidgt11r1Jd2ZFazDAiN"),
+			wantBumpSeed: 255,
+			wantErr:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pubkey, bumpSeed, err := FindLookupTableAddress(tt.args.authority, tt.args.recentSlot)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantPubkey, pubkey)
+			assert.Equal(t, tt.wantBumpSeed, bumpSeed)
+		})
+	}
+}
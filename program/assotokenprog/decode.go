@@ -0,0 +1,45 @@
+package assotokenprog
+
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DecodeInstruction decodes an associated token program instruction to its param,
+// CreateAssociatedTokenAccountParam or CreateAssociatedTokenAccountIdempotentParam
+func DecodeInstruction(instruction types.Instruction) (interface{}, error) {
+	if instruction.ProgramID != common.SPLAssociatedTokenAccountProgramID {
+		return nil, types.ErrProgramIDMismatch
+	}
+	accounts := instruction.Accounts
+	if len(accounts) < 4 {
+		return nil, fmt.Errorf("%w, expected %v, got %v", types.ErrNotEnoughAccounts, 4, len(accounts))
+	}
+
+	// the create instruction used to have no data
+	instructionType := InstructionCreate
+	if len(instruction.Data) > 0 {
+		instructionType = Instruction(instruction.Data[0])
+	}
+
+	switch instructionType {
+	case InstructionCreate:
+		return CreateAssociatedTokenAccountParam{
+			Funder:                 accounts[0].PubKey,
+			Owner:                  accounts[2].PubKey,
+			Mint:                   accounts[3].PubKey,
+			AssociatedTokenAccount: accounts[1].PubKey,
+		}, nil
+	case InstructionCreateIdempotent:
+		return CreateAssociatedTokenAccountIdempotentParam{
+			Funder:                 accounts[0].PubKey,
+			Owner:                  accounts[2].PubKey,
+			Mint:                   accounts[3].PubKey,
+			AssociatedTokenAccount: accounts[1].PubKey,
+		}, nil
+	}
+
+	return nil, types.ErrUnknownInstruction
+}
@@ -0,0 +1,65 @@
+package assotokenprog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstruction(t *testing.T) {
+	funder := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	owner := common.PublicKeyFromString("5JksDo879mvhxnBPLKPQLvgemxi4et75ipWC9BaLTHBK")
+	mint := common.PublicKeyFromString("G1dYC47buM23b4kdWsa7utfEGM95t2LL3fZn535W5pYC")
+	ata := common.PublicKeyFromString("8qJdAUsYNCRDDfs7ANyCoLPUj9CfnTM1aJU6Sndbviro")
+
+	legacyCreate := CreateAssociatedTokenAccount(CreateAssociatedTokenAccountParam{Funder: funder, Owner: owner, Mint: mint, AssociatedTokenAccount: ata})
+	legacyCreate.Data = nil
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+		err         error
+	}{
+		{
+			name:        "create",
+			instruction: CreateAssociatedTokenAccount(CreateAssociatedTokenAccountParam{Funder: funder, Owner: owner, Mint: mint, AssociatedTokenAccount: ata}),
+			want:        CreateAssociatedTokenAccountParam{Funder: funder, Owner: owner, Mint: mint, AssociatedTokenAccount: ata},
+		},
+		{
+			name:        "create without data",
+			instruction: legacyCreate,
+			want:        CreateAssociatedTokenAccountParam{Funder: funder, Owner: owner, Mint: mint, AssociatedTokenAccount: ata},
+		},
+		{
+			name:        "create idempotent",
+			instruction: CreateAssociatedTokenAccountIdempotent(CreateAssociatedTokenAccountIdempotentParam{Funder: funder, Owner: owner, Mint: mint, AssociatedTokenAccount: ata}),
+			want:        CreateAssociatedTokenAccountIdempotentParam{Funder: funder, Owner: owner, Mint: mint, AssociatedTokenAccount: ata},
+		},
+		{
+			name:        "program id mismatch",
+			instruction: types.Instruction{ProgramID: common.TokenProgramID, Data: []byte{0}},
+			err:         types.ErrProgramIDMismatch,
+		},
+		{
+			name:        "unknown instruction",
+			instruction: types.Instruction{ProgramID: common.SPLAssociatedTokenAccountProgramID, Accounts: make([]types.AccountMeta, 7), Data: []byte{9}},
+			err:         types.ErrUnknownInstruction,
+		},
+		{
+			name:        "not enough accounts",
+			instruction: types.Instruction{ProgramID: common.SPLAssociatedTokenAccountProgramID, Accounts: make([]types.AccountMeta, 3), Data: []byte{0}},
+			err:         types.ErrNotEnoughAccounts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.True(t, errors.Is(err, tt.err), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
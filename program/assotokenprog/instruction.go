@@ -20,7 +20,7 @@ type CreateAssociatedTokenAccountParam struct {
 	AssociatedTokenAccount common.PublicKey
 }
 
-// CreateAssociatedTokenAccount is the only instruction in associated token program
+// CreateAssociatedTokenAccount creates an associated token account, it fails if the account exists
 func CreateAssociatedTokenAccount(param CreateAssociatedTokenAccountParam) types.Instruction {
 	data, err := borsh.Serialize(struct {
 		Instruction Instruction
@@ -45,3 +45,36 @@ func CreateAssociatedTokenAccount(param CreateAssociatedTokenAccountParam) types
 		Data: data,
 	}
 }
+
+type CreateAssociatedTokenAccountIdempotentParam struct {
+	Funder                 common.PublicKey
+	Owner                  common.PublicKey
+	Mint                   common.PublicKey
+	AssociatedTokenAccount common.PublicKey
+}
+
+// CreateAssociatedTokenAccountIdempotent creates an associated token account, it does nothing if the account exists
+func CreateAssociatedTokenAccountIdempotent(param CreateAssociatedTokenAccountIdempotentParam) types.Instruction {
+	data, err := borsh.Serialize(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionCreateIdempotent,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.SPLAssociatedTokenAccountProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Funder, IsSigner: true, IsWritable: true},
+			{PubKey: param.AssociatedTokenAccount, IsSigner: false, IsWritable: true},
+			{PubKey: param.Owner, IsSigner: false, IsWritable: false},
+			{PubKey: param.Mint, IsSigner: false, IsWritable: false},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
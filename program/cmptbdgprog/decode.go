@@ -0,0 +1,54 @@
+package cmptbdgprog
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DecodeInstruction decodes a compute budget instruction to its param, e.g. SetComputeUnitPriceParam
+func DecodeInstruction(instruction types.Instruction) (interface{}, error) {
+	if instruction.ProgramID != common.ComputeBudgetProgramID {
+		return nil, types.ErrProgramIDMismatch
+	}
+	if len(instruction.Data) < 1 {
+		return nil, fmt.Errorf("%w, data size is not enough for instruction type", types.ErrInvalidInstructionData)
+	}
+	data := instruction.Data[1:]
+
+	switch Instruction(instruction.Data[0]) {
+	case InstructionRequestUnits:
+		if len(data) < 8 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return RequestUnitsParam{
+			Units:         binary.LittleEndian.Uint32(data[:4]),
+			AdditionalFee: binary.LittleEndian.Uint32(data[4:8]),
+		}, nil
+	case InstructionRequestHeapFrame:
+		if len(data) < 4 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return RequestHeapFrameParam{
+			Bytes: binary.LittleEndian.Uint32(data[:4]),
+		}, nil
+	case InstructionSetComputeUnitLimit:
+		if len(data) < 4 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return SetComputeUnitLimitParam{
+			Units: binary.LittleEndian.Uint32(data[:4]),
+		}, nil
+	case InstructionSetComputeUnitPrice:
+		if len(data) < 8 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return SetComputeUnitPriceParam{
+			MicroLamports: binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+	}
+
+	return nil, types.ErrUnknownInstruction
+}
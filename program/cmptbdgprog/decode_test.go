@@ -0,0 +1,62 @@
+package cmptbdgprog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstruction(t *testing.T) {
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+		err         error
+	}{
+		{
+			name:        "request units",
+			instruction: RequestUnits(RequestUnitsParam{Units: 1000, AdditionalFee: 5000}),
+			want:        RequestUnitsParam{Units: 1000, AdditionalFee: 5000},
+		},
+		{
+			name:        "request heap frame",
+			instruction: RequestHeapFrame(RequestHeapFrameParam{Bytes: 256 * 1024}),
+			want:        RequestHeapFrameParam{Bytes: 256 * 1024},
+		},
+		{
+			name:        "set compute unit limit",
+			instruction: SetComputeUnitLimit(SetComputeUnitLimitParam{Units: 300000}),
+			want:        SetComputeUnitLimitParam{Units: 300000},
+		},
+		{
+			name:        "set compute unit price",
+			instruction: SetComputeUnitPrice(SetComputeUnitPriceParam{MicroLamports: 10000}),
+			want:        SetComputeUnitPriceParam{MicroLamports: 10000},
+		},
+		{
+			name:        "program id mismatch",
+			instruction: types.Instruction{ProgramID: common.SystemProgramID, Data: []byte{2, 0, 0, 0, 0}},
+			err:         types.ErrProgramIDMismatch,
+		},
+		{
+			name:        "unknown instruction",
+			instruction: types.Instruction{ProgramID: common.ComputeBudgetProgramID, Data: []byte{255}},
+			err:         types.ErrUnknownInstruction,
+		},
+		{
+			name:        "invalid data",
+			instruction: types.Instruction{ProgramID: common.ComputeBudgetProgramID, Data: []byte{3, 1, 0, 0, 0}},
+			err:         types.ErrInvalidInstructionData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.True(t, errors.Is(err, tt.err), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
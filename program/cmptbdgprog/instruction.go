@@ -15,6 +15,9 @@ const (
 	InstructionSetComputeUnitPrice
 )
 
+// MaxComputeUnitLimit is the most compute units a transaction can request
+const MaxComputeUnitLimit = 1_400_000
+
 type RequestUnitsParam struct {
 	Units         uint32
 	AdditionalFee uint32
@@ -0,0 +1,146 @@
+package decoder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/assotokenprog"
+	"github.com/portto/solana-go-sdk/program/cmptbdgprog"
+	"github.com/portto/solana-go-sdk/program/memoprog"
+	"github.com/portto/solana-go-sdk/program/metaplex/tokenmeta"
+	"github.com/portto/solana-go-sdk/program/stakeprog"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/program/tokenprog"
+	"github.com/portto/solana-go-sdk/program/voteprog"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// ErrUnknownProgram is returned when no decoder is registered for the program id
+var ErrUnknownProgram = errors.New("unknown program")
+
+// DecodeFunc decodes an instruction to a typed value, usually a Param of the program package
+type DecodeFunc func(instruction types.Instruction) (interface{}, error)
+
+// Registry maps program ids to their decoders
+type Registry struct {
+	decoders map[common.PublicKey]DecodeFunc
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		decoders: map[common.PublicKey]DecodeFunc{},
+	}
+}
+
+// NewDefaultRegistry returns a registry with decoders of all built-in program packages
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(common.SystemProgramID, sysprog.DecodeInstruction)
+	r.Register(common.TokenProgramID, tokenprog.DecodeInstruction)
+	r.Register(common.Token2022ProgramID, tokenprog.DecodeInstruction)
+	r.Register(common.StakeProgramID, stakeprog.DecodeInstruction)
+	r.Register(common.VoteProgramID, voteprog.DecodeInstruction)
+	r.Register(common.SPLAssociatedTokenAccountProgramID, assotokenprog.DecodeInstruction)
+	r.Register(common.ComputeBudgetProgramID, cmptbdgprog.DecodeInstruction)
+	r.Register(common.MemoProgramID, memoprog.DecodeInstruction)
+	r.Register(common.MetaplexTokenMetaProgramID, tokenmeta.DecodeInstruction)
+	return r
+}
+
+// Register sets the decoder of a program, it replaces the existing one
+func (r *Registry) Register(programID common.PublicKey, fn DecodeFunc) {
+	r.decoders[programID] = fn
+}
+
+// Decode decodes an instruction by the decoder of its program
+func (r *Registry) Decode(instruction types.Instruction) (interface{}, error) {
+	fn, ok := r.decoders[instruction.ProgramID]
+	if !ok {
+		return nil, fmt.Errorf("%w, %v", ErrUnknownProgram, instruction.ProgramID.ToBase58())
+	}
+	return fn(instruction)
+}
+
+// DecodedInstruction is an instruction of a transaction and its decoded value.
+// Err is set when the instruction can't be decoded, e.g. ErrUnknownProgram.
+type DecodedInstruction struct {
+	Instruction       types.Instruction
+	Value             interface{}
+	Err               error
+	InnerInstructions []DecodedInstruction
+}
+
+// DecodeTransaction decodes all instructions of a fetched transaction.
+// meta is optional, if it is provided, inner instructions are decoded and accounts loaded from
+// address lookup tables are resolved by its loaded addresses.
+func (r *Registry) DecodeTransaction(tx types.Transaction, meta *client.TransactionMeta) ([]DecodedInstruction, error) {
+	message := tx.Message
+	var addressLookupTableAccounts []types.AddressLookupTableAccount
+	if meta != nil {
+		message, addressLookupTableAccounts = withLoadedAddresses(message, meta)
+	} else if len(message.AddressLookupTables) > 0 {
+		return nil, errors.New("transaction loads accounts from address lookup tables, meta is required")
+	}
+
+	instructions, err := message.DecompileInstructionsWithAddressLookupTables(addressLookupTableAccounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompile instructions, err: %v", err)
+	}
+	decoded := make([]DecodedInstruction, 0, len(instructions))
+	for _, instruction := range instructions {
+		decoded = append(decoded, r.decode(instruction))
+	}
+
+	if meta == nil {
+		return decoded, nil
+	}
+	for _, innerInstruction := range meta.InnerInstructions {
+		if innerInstruction.Index >= uint64(len(decoded)) {
+			return nil, fmt.Errorf("inner instruction index %v out of range", innerInstruction.Index)
+		}
+		message.Instructions = innerInstruction.Instructions
+		instructions, err := message.DecompileInstructionsWithAddressLookupTables(addressLookupTableAccounts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompile inner instructions of instruction #%d, err: %v", innerInstruction.Index+1, err)
+		}
+		for _, instruction := range instructions {
+			decoded[innerInstruction.Index].InnerInstructions = append(decoded[innerInstruction.Index].InnerInstructions, r.decode(instruction))
+		}
+	}
+	return decoded, nil
+}
+
+func (r *Registry) decode(instruction types.Instruction) DecodedInstruction {
+	value, err := r.Decode(instruction)
+	return DecodedInstruction{
+		Instruction: instruction,
+		Value:       value,
+		Err:         err,
+	}
+}
+
+// withLoadedAddresses replaces the message's address table lookups by a single lookup which loads
+// the addresses listed in meta. rpc nodes have resolved them so the lookup tables needn't be fetched.
+func withLoadedAddresses(message types.Message, meta *client.TransactionMeta) (types.Message, []types.AddressLookupTableAccount) {
+	loaded := meta.LoadedAddresses
+	if len(loaded.Writable)+len(loaded.Readonly) == 0 {
+		message.AddressLookupTables = nil
+		return message, nil
+	}
+
+	addresses := make([]common.PublicKey, 0, len(loaded.Writable)+len(loaded.Readonly))
+	lookup := types.CompiledAddressLookupTable{}
+	for _, address := range loaded.Writable {
+		lookup.WritableIndexes = append(lookup.WritableIndexes, uint8(len(addresses)))
+		addresses = append(addresses, common.PublicKeyFromString(address))
+	}
+	for _, address := range loaded.Readonly {
+		lookup.ReadonlyIndexes = append(lookup.ReadonlyIndexes, uint8(len(addresses)))
+		addresses = append(addresses, common.PublicKeyFromString(address))
+	}
+	message.AddressLookupTables = []types.CompiledAddressLookupTable{lookup}
+	return message, []types.AddressLookupTableAccount{{Key: lookup.AccountKey, Addresses: addresses}}
+}
@@ -0,0 +1,133 @@
+package decoder
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/program/cmptbdgprog"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Decode(t *testing.T) {
+	from := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	unknown := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+
+	r := NewDefaultRegistry()
+
+	got, err := r.Decode(sysprog.Transfer(sysprog.TransferParam{From: from, To: to, Amount: 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, sysprog.TransferParam{From: from, To: to, Amount: 1}, got)
+
+	_, err = r.Decode(types.Instruction{ProgramID: unknown})
+	assert.True(t, errors.Is(err, ErrUnknownProgram), err)
+
+	r.Register(unknown, func(instruction types.Instruction) (interface{}, error) {
+		return string(instruction.Data), nil
+	})
+	got, err = r.Decode(types.Instruction{ProgramID: unknown, Data: []byte("hi")})
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", got)
+}
+
+func TestRegistry_DecodeMalformed(t *testing.T) {
+	r := NewDefaultRegistry()
+	rnd := rand.New(rand.NewSource(1))
+	for programID, fn := range r.decoders {
+		for i := 0; i < 5000; i++ {
+			data := make([]byte, 1+rnd.Intn(300))
+			rnd.Read(data)
+			// cover every instruction type of both 1-byte and 4-byte discriminators
+			data[0] = byte(i)
+			if len(data) >= 4 && i%2 == 0 {
+				data[1], data[2], data[3] = 0, 0, 0
+			}
+			instruction := types.Instruction{
+				ProgramID: programID,
+				Accounts:  make([]types.AccountMeta, rnd.Intn(16)),
+				Data:      data,
+			}
+			assert.NotPanics(t, func() {
+				_, _ = fn(instruction)
+			}, "program: %v, data: %v", programID.ToBase58(), data)
+		}
+	}
+}
+
+func TestRegistry_DecodeTransaction(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	unknown := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+	lookupTable := common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde")
+
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer: feePayer,
+		Instructions: []types.Instruction{
+			cmptbdgprog.SetComputeUnitLimit(cmptbdgprog.SetComputeUnitLimitParam{Units: 1000}),
+			sysprog.Transfer(sysprog.TransferParam{From: feePayer, To: to, Amount: 1}),
+			{ProgramID: unknown, Accounts: []types.AccountMeta{{PubKey: feePayer, IsSigner: true, IsWritable: true}}},
+		},
+		RecentBlockhash:            "9rAtxuhtKn8qagc3UtZFyhLrw5zgh6FDHDy7ugoWdDQh",
+		AddressLookupTableAccounts: []types.AddressLookupTableAccount{{Key: lookupTable, Addresses: []common.PublicKey{to}}},
+	})
+	assert.Len(t, message.AddressLookupTables, 1)
+
+	indexOf := func(pubkey common.PublicKey) int {
+		for i, account := range message.Accounts {
+			if account == pubkey {
+				return i
+			}
+		}
+		return len(message.Accounts) // the only loaded account
+	}
+
+	meta := &client.TransactionMeta{
+		InnerInstructions: []client.TransactionMetaInnerInstruction{
+			{
+				Index: 2,
+				Instructions: []types.CompiledInstruction{
+					{
+						ProgramIDIndex: indexOf(common.SystemProgramID),
+						Accounts:       []int{indexOf(feePayer), indexOf(to)},
+						Data:           sysprog.Transfer(sysprog.TransferParam{From: feePayer, To: to, Amount: 2}).Data,
+					},
+				},
+			},
+		},
+		LoadedAddresses: rpc.TransactionLoadedAddresses{
+			Writable: []string{to.ToBase58()},
+			Readonly: []string{},
+		},
+	}
+
+	r := NewDefaultRegistry()
+	got, err := r.DecodeTransaction(types.Transaction{Message: message}, meta)
+	assert.NoError(t, err)
+	assert.Len(t, got, 3)
+
+	assert.Equal(t, cmptbdgprog.SetComputeUnitLimitParam{Units: 1000}, got[0].Value)
+	assert.NoError(t, got[0].Err)
+
+	assert.Equal(t, sysprog.TransferParam{From: feePayer, To: to, Amount: 1}, got[1].Value)
+	assert.NoError(t, got[1].Err)
+	assert.True(t, got[1].Instruction.Accounts[1].IsWritable)
+	assert.False(t, got[1].Instruction.Accounts[1].IsSigner)
+
+	assert.Nil(t, got[2].Value)
+	assert.True(t, errors.Is(got[2].Err, ErrUnknownProgram), got[2].Err)
+	assert.Len(t, got[2].InnerInstructions, 1)
+	assert.Equal(t, sysprog.TransferParam{From: feePayer, To: to, Amount: 2}, got[2].InnerInstructions[0].Value)
+
+	_, err = r.DecodeTransaction(types.Transaction{Message: message}, nil)
+	assert.Error(t, err)
+
+	meta.InnerInstructions[0].Index = 3
+	_, err = r.DecodeTransaction(types.Transaction{Message: message}, meta)
+	assert.Error(t, err)
+}
@@ -0,0 +1,21 @@
+package memoprog
+
+import (
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DecodeInstruction decodes a memo instruction to BuildMemoParam
+func DecodeInstruction(instruction types.Instruction) (interface{}, error) {
+	if instruction.ProgramID != common.MemoProgramID {
+		return nil, types.ErrProgramIDMismatch
+	}
+	var signerPubkeys []common.PublicKey
+	for _, account := range instruction.Accounts {
+		signerPubkeys = append(signerPubkeys, account.PubKey)
+	}
+	return BuildMemoParam{
+		SignerPubkeys: signerPubkeys,
+		Memo:          instruction.Data,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package memoprog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstruction(t *testing.T) {
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+		err         error
+	}{
+		{
+			name: "memo with signers",
+			instruction: BuildMemo(BuildMemoParam{
+				SignerPubkeys: []common.PublicKey{common.PublicKeyFromString("S1gner1111111111111111111111111111111111111")},
+				Memo:          []byte("👻"),
+			}),
+			want: BuildMemoParam{
+				SignerPubkeys: []common.PublicKey{common.PublicKeyFromString("S1gner1111111111111111111111111111111111111")},
+				Memo:          []byte("👻"),
+			},
+		},
+		{
+			name:        "memo",
+			instruction: BuildMemo(BuildMemoParam{Memo: []byte("memo")}),
+			want:        BuildMemoParam{Memo: []byte("memo")},
+		},
+		{
+			name:        "program id mismatch",
+			instruction: types.Instruction{ProgramID: common.TokenProgramID, Data: []byte("memo")},
+			err:         types.ErrProgramIDMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.True(t, errors.Is(err, tt.err), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
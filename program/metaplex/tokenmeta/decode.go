@@ -0,0 +1,294 @@
+package tokenmeta
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/near/borsh-go"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DecodeInstruction decodes a token metadata instruction to its param, e.g. CreateMetadataAccountV2Param.
+// only instructions which have a builder in this package are supported.
+func DecodeInstruction(instruction types.Instruction) (interface{}, error) {
+	if instruction.ProgramID != common.MetaplexTokenMetaProgramID {
+		return nil, types.ErrProgramIDMismatch
+	}
+	if len(instruction.Data) < 1 {
+		return nil, fmt.Errorf("%w, data size is not enough for instruction type", types.ErrInvalidInstructionData)
+	}
+	accounts := instruction.Accounts
+
+	switch Instruction(instruction.Data[0]) {
+	case InstructionCreateMetadataAccount:
+		var data struct {
+			Instruction Instruction
+			Data        Data
+			IsMutable   bool
+		}
+		if err := check(accounts, 5, instruction.Data, &data); err != nil {
+			return nil, err
+		}
+		return CreateMetadataAccountParam{
+			Metadata:                accounts[0].PubKey,
+			Mint:                    accounts[1].PubKey,
+			MintAuthority:           accounts[2].PubKey,
+			Payer:                   accounts[3].PubKey,
+			UpdateAuthority:         accounts[4].PubKey,
+			UpdateAuthorityIsSigner: accounts[4].IsSigner,
+			IsMutable:               data.IsMutable,
+			MintData:                data.Data,
+		}, nil
+
+	case InstructionUpdateMetadataAccount:
+		var data struct {
+			Instruction         Instruction
+			Data                *Data
+			NewUpdateAuthority  *common.PublicKey
+			PrimarySaleHappened *bool
+		}
+		if err := check(accounts, 2, instruction.Data, &data); err != nil {
+			return nil, err
+		}
+		return UpdateMetadataAccountParam{
+			MetadataAccount:     accounts[0].PubKey,
+			UpdateAuthority:     accounts[1].PubKey,
+			Data:                data.Data,
+			NewUpdateAuthority:  data.NewUpdateAuthority,
+			PrimarySaleHappened: data.PrimarySaleHappened,
+		}, nil
+
+	case InstructionCreateMasterEdition:
+		var data struct {
+			Instruction Instruction
+			MaxSupply   *uint64
+		}
+		if err := check(accounts, 6, instruction.Data, &data); err != nil {
+			return nil, err
+		}
+		return CreateMasterEditionParam{
+			Edition:         accounts[0].PubKey,
+			Mint:            accounts[1].PubKey,
+			UpdateAuthority: accounts[2].PubKey,
+			MintAuthority:   accounts[3].PubKey,
+			Metadata:        accounts[5].PubKey,
+			Payer:           accounts[4].PubKey,
+			MaxSupply:       data.MaxSupply,
+		}, nil
+
+	case InstructionSignMetadata:
+		if err := check(accounts, 2, nil, nil); err != nil {
+			return nil, err
+		}
+		return SignMetadataParam{
+			Metadata: accounts[0].PubKey,
+			Creator:  accounts[1].PubKey,
+		}, nil
+
+	case InstructionMintNewEditionFromMasterEditionViaToken:
+		var data struct {
+			Instruction Instruction
+			Edition     uint64
+		}
+		if err := check(accounts, 11, instruction.Data, &data); err != nil {
+			return nil, err
+		}
+		return MintNewEditionFromMasterEditionViaTokeParam{
+			NewMetaData:                accounts[0].PubKey,
+			NewEdition:                 accounts[1].PubKey,
+			MasterEdition:              accounts[2].PubKey,
+			NewMint:                    accounts[3].PubKey,
+			EditionMark:                accounts[4].PubKey,
+			NewMintAuthority:           accounts[5].PubKey,
+			Payer:                      accounts[6].PubKey,
+			TokenAccountOwner:          accounts[7].PubKey,
+			TokenAccount:               accounts[8].PubKey,
+			NewMetadataUpdateAuthority: accounts[9].PubKey,
+			MasterMetadata:             accounts[10].PubKey,
+			Edition:                    data.Edition,
+		}, nil
+
+	case InstructionCreateMetadataAccountV2:
+		var data struct {
+			Instruction Instruction
+			Data        DataV2
+			IsMutable   bool
+		}
+		if err := check(accounts, 5, instruction.Data, &data); err != nil {
+			return nil, err
+		}
+		return CreateMetadataAccountV2Param{
+			Metadata:                accounts[0].PubKey,
+			Mint:                    accounts[1].PubKey,
+			MintAuthority:           accounts[2].PubKey,
+			Payer:                   accounts[3].PubKey,
+			UpdateAuthority:         accounts[4].PubKey,
+			UpdateAuthorityIsSigner: accounts[4].IsSigner,
+			IsMutable:               data.IsMutable,
+			Data:                    data.Data,
+		}, nil
+
+	case InstructionCreateMasterEditionV3:
+		var data struct {
+			Instruction Instruction
+			MaxSupply   *uint64
+		}
+		if err := check(accounts, 6, instruction.Data, &data); err != nil {
+			return nil, err
+		}
+		return CreateMasterEditionV3Param{
+			Edition:         accounts[0].PubKey,
+			Mint:            accounts[1].PubKey,
+			UpdateAuthority: accounts[2].PubKey,
+			MintAuthority:   accounts[3].PubKey,
+			Metadata:        accounts[5].PubKey,
+			Payer:           accounts[4].PubKey,
+			MaxSupply:       data.MaxSupply,
+		}, nil
+
+	case InstructionVerifyCollection:
+		if err := check(accounts, 6, nil, nil); err != nil {
+			return nil, err
+		}
+		return VerifyCollectionParam{
+			Payer:                          accounts[2].PubKey,
+			Metadata:                       accounts[0].PubKey,
+			CollectionAuthority:            accounts[1].PubKey,
+			CollectionMint:                 accounts[3].PubKey,
+			Collection:                     accounts[4].PubKey,
+			CollectionMasterEditionAccount: accounts[5].PubKey,
+		}, nil
+
+	case InstructionUnverifyCollection:
+		if err := check(accounts, 5, nil, nil); err != nil {
+			return nil, err
+		}
+		return UnverifyCollectionParam{
+			Metadata:                       accounts[0].PubKey,
+			CollectionAuthority:            accounts[1].PubKey,
+			CollectionMint:                 accounts[2].PubKey,
+			Collection:                     accounts[3].PubKey,
+			CollectionMasterEditionAccount: accounts[4].PubKey,
+			CollectionAuthorityRecord:      optionalAccount(accounts, 5),
+		}, nil
+
+	case InstructionSetAndVerifyCollection:
+		if err := check(accounts, 7, nil, nil); err != nil {
+			return nil, err
+		}
+		return SetAndVerifyCollectionParam{
+			Payer:                          accounts[2].PubKey,
+			Metadata:                       accounts[0].PubKey,
+			CollectionAuthority:            accounts[1].PubKey,
+			UpdateAuthority:                accounts[3].PubKey,
+			CollectionMint:                 accounts[4].PubKey,
+			Collection:                     accounts[5].PubKey,
+			CollectionMasterEditionAccount: accounts[6].PubKey,
+			CollectionAuthorityRecord:      optionalAccount(accounts, 7),
+		}, nil
+
+	case InstructionBurnANFT:
+		if err := check(accounts, 6, nil, nil); err != nil {
+			return nil, err
+		}
+		return BurnANFTParam{
+			Metadata:             accounts[0].PubKey,
+			Owner:                accounts[1].PubKey,
+			Mint:                 accounts[2].PubKey,
+			TokenAccount:         accounts[3].PubKey,
+			MasterEditionAccount: accounts[4].PubKey,
+			SplTokenProgram:      accounts[5].PubKey,
+			CollectionMetadata:   optionalAccount(accounts, 6),
+		}, nil
+	}
+
+	return nil, types.ErrUnknownInstruction
+}
+
+// check checks the number of accounts and deserializes data into v if v is not nil
+func check(accounts []types.AccountMeta, accountsLen int, data []byte, v interface{}) error {
+	if len(accounts) < accountsLen {
+		return fmt.Errorf("%w, expected %v, got %v", types.ErrNotEnoughAccounts, accountsLen, len(accounts))
+	}
+	if v == nil {
+		return nil
+	}
+	// borsh-go allocates strings by their length prefixes before reading them, so the prefixes are checked
+	// first to keep a malformed instruction from allocating gigabytes
+	if _, err := checkLengths(reflect.TypeOf(v).Elem(), data); err != nil {
+		return fmt.Errorf("%w, %v", types.ErrInvalidInstructionData, err)
+	}
+	if err := borsh.Deserialize(v, data); err != nil {
+		return fmt.Errorf("%w, %v", types.ErrInvalidInstructionData, err)
+	}
+	return nil
+}
+
+// checkLengths walks the borsh layout of t and returns the data left after it. it fails if a length prefix
+// is longer than the remaining data.
+func checkLengths(t reflect.Type, data []byte) ([]byte, error) {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Uint8, reflect.Int8, reflect.Uint16, reflect.Int16,
+		reflect.Uint32, reflect.Int32, reflect.Uint64, reflect.Int64:
+		n := int(t.Size())
+		if len(data) < n {
+			return nil, fmt.Errorf("data size is not enough for %v", t)
+		}
+		return data[n:], nil
+	case reflect.String, reflect.Slice:
+		if len(data) < 4 {
+			return nil, fmt.Errorf("data size is not enough for %v length", t)
+		}
+		n := uint64(binary.LittleEndian.Uint32(data[:4]))
+		data = data[4:]
+		// every element takes at least 1 byte
+		if n > uint64(len(data)) {
+			return nil, fmt.Errorf("%v length %v exceeds the remaining data size %v", t, n, len(data))
+		}
+		if t.Kind() == reflect.String {
+			return data[n:], nil
+		}
+		var err error
+		for i := uint64(0); i < n; i++ {
+			if data, err = checkLengths(t.Elem(), data); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	case reflect.Array:
+		var err error
+		for i := 0; i < t.Len(); i++ {
+			if data, err = checkLengths(t.Elem(), data); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	case reflect.Ptr:
+		if len(data) < 1 {
+			return nil, fmt.Errorf("data size is not enough for %v option", t)
+		}
+		if data[0] == 0 {
+			return data[1:], nil
+		}
+		return checkLengths(t.Elem(), data[1:])
+	case reflect.Struct:
+		var err error
+		for i := 0; i < t.NumField(); i++ {
+			if data, err = checkLengths(t.Field(i).Type, data); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+// optionalAccount returns the empty pubkey if the account is omitted
+func optionalAccount(accounts []types.AccountMeta, i int) common.PublicKey {
+	if i >= len(accounts) {
+		return common.PublicKey{}
+	}
+	return accounts[i].PubKey
+}
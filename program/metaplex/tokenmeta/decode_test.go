@@ -0,0 +1,158 @@
+package tokenmeta
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstruction(t *testing.T) {
+	metadata := common.PublicKeyFromString("DC2mkgwhy56w3viNtHDjJQmc7SGu2QX785bS4aexojwX")
+	mint := common.PublicKeyFromString("GphF2vTuzhwhLWBWWvD8y5QLCPp1aQC5EnzrWsnbiWPx")
+	mintAuth := common.PublicKeyFromString("9BKWqDHfHZh9j39xakYVMdr6hXmCLHH5VfCpeq2idU9L")
+	payer := common.PublicKeyFromString("9FYsKrNuEweb55Wa2jaj8wTKYDBvuCG3huhakEj96iN9")
+	updateAuth := common.PublicKeyFromString("HNGVuL5kqjDehw7KR63w9gxow32sX6xzRNgLb8GkbwCM")
+	creator := common.PublicKeyFromString("7FzXBBPjzrNJbm9MrZKZcyvP3ojVeYPUG2XkBPVZvuBu")
+
+	data := Data{
+		Name:                 "Test NFT",
+		Symbol:               "TST",
+		Uri:                  "https://test.com/metadata",
+		SellerFeeBasisPoints: 10,
+		Creators:             &[]Creator{{Address: creator, Verified: true, Share: 100}},
+	}
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+		err         error
+	}{
+		{
+			name: "create metadata account",
+			instruction: CreateMetadataAccount(CreateMetadataAccountParam{
+				Metadata:                metadata,
+				Mint:                    mint,
+				MintAuthority:           mintAuth,
+				Payer:                   payer,
+				UpdateAuthority:         updateAuth,
+				UpdateAuthorityIsSigner: true,
+				IsMutable:               true,
+				MintData:                data,
+			}),
+			want: CreateMetadataAccountParam{
+				Metadata:                metadata,
+				Mint:                    mint,
+				MintAuthority:           mintAuth,
+				Payer:                   payer,
+				UpdateAuthority:         updateAuth,
+				UpdateAuthorityIsSigner: true,
+				IsMutable:               true,
+				MintData:                data,
+			},
+		},
+		{
+			name: "update metadata account",
+			instruction: UpdateMetadataAccount(UpdateMetadataAccountParam{
+				MetadataAccount:     metadata,
+				UpdateAuthority:     updateAuth,
+				NewUpdateAuthority:  &payer,
+				PrimarySaleHappened: pointer.Bool(true),
+			}),
+			want: UpdateMetadataAccountParam{
+				MetadataAccount:     metadata,
+				UpdateAuthority:     updateAuth,
+				NewUpdateAuthority:  &payer,
+				PrimarySaleHappened: pointer.Bool(true),
+			},
+		},
+		{
+			name: "create master edition",
+			instruction: CreateMasterEdition(CreateMasterEditionParam{
+				Edition:         creator,
+				Mint:            mint,
+				UpdateAuthority: updateAuth,
+				MintAuthority:   mintAuth,
+				Metadata:        metadata,
+				Payer:           payer,
+				MaxSupply:       pointer.Uint64(1),
+			}),
+			want: CreateMasterEditionParam{
+				Edition:         creator,
+				Mint:            mint,
+				UpdateAuthority: updateAuth,
+				MintAuthority:   mintAuth,
+				Metadata:        metadata,
+				Payer:           payer,
+				MaxSupply:       pointer.Uint64(1),
+			},
+		},
+		{
+			name:        "sign metadata",
+			instruction: SignMetadata(SignMetadataParam{Metadata: metadata, Creator: creator}),
+			want:        SignMetadataParam{Metadata: metadata, Creator: creator},
+		},
+		{
+			name: "set and verify collection without record",
+			instruction: SetAndVerifyCollection(SetAndVerifyCollectionParam{
+				Payer:                          payer,
+				Metadata:                       metadata,
+				CollectionAuthority:            updateAuth,
+				UpdateAuthority:                updateAuth,
+				CollectionMint:                 mint,
+				Collection:                     creator,
+				CollectionMasterEditionAccount: mintAuth,
+			}),
+			want: SetAndVerifyCollectionParam{
+				Payer:                          payer,
+				Metadata:                       metadata,
+				CollectionAuthority:            updateAuth,
+				UpdateAuthority:                updateAuth,
+				CollectionMint:                 mint,
+				Collection:                     creator,
+				CollectionMasterEditionAccount: mintAuth,
+			},
+		},
+		{
+			name:        "program id mismatch",
+			instruction: types.Instruction{ProgramID: common.SystemProgramID, Data: []byte{7}},
+			err:         types.ErrProgramIDMismatch,
+		},
+		{
+			name:        "unknown instruction",
+			instruction: types.Instruction{ProgramID: common.MetaplexTokenMetaProgramID, Data: []byte{byte(InstructionPuffMetadata)}},
+			err:         types.ErrUnknownInstruction,
+		},
+		{
+			name:        "invalid data",
+			instruction: types.Instruction{ProgramID: common.MetaplexTokenMetaProgramID, Accounts: make([]types.AccountMeta, 11), Data: []byte{byte(InstructionMintNewEditionFromMasterEditionViaToken), 1}},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "huge string length",
+			instruction: types.Instruction{ProgramID: common.MetaplexTokenMetaProgramID, Accounts: make([]types.AccountMeta, 5), Data: []byte{byte(InstructionCreateMetadataAccountV2), 0xff, 0xff, 0xff, 0xff, 'a'}},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "huge creators length",
+			instruction: types.Instruction{ProgramID: common.MetaplexTokenMetaProgramID, Accounts: make([]types.AccountMeta, 2), Data: []byte{byte(InstructionUpdateMetadataAccount), 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff, 0}},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "not enough accounts",
+			instruction: types.Instruction{ProgramID: common.MetaplexTokenMetaProgramID, Accounts: make([]types.AccountMeta, 1), Data: []byte{byte(InstructionSignMetadata)}},
+			err:         types.ErrNotEnoughAccounts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.True(t, errors.Is(err, tt.err), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package stakeprog
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DecodeInstruction decodes a stake program instruction to its param, e.g. DelegateStakeParam for a delegate instruction
+func DecodeInstruction(instruction types.Instruction) (interface{}, error) {
+	if instruction.ProgramID != common.StakeProgramID {
+		return nil, types.ErrProgramIDMismatch
+	}
+	if len(instruction.Data) < 4 {
+		return nil, fmt.Errorf("%w, data size is not enough for instruction type", types.ErrInvalidInstructionData)
+	}
+	accounts := instruction.Accounts
+	data := instruction.Data[4:]
+
+	switch Instruction(binary.LittleEndian.Uint32(instruction.Data[:4])) {
+	case InstructionInitialize:
+		if err := check(accounts, 1, data, 112); err != nil {
+			return nil, err
+		}
+		return InitializeParam{
+			Stake: accounts[0].PubKey,
+			Auth: Authorized{
+				Staker:     common.PublicKeyFromBytes(data[:32]),
+				Withdrawer: common.PublicKeyFromBytes(data[32:64]),
+			},
+			Lockup: Lockup{
+				UnixTimestamp: int64(binary.LittleEndian.Uint64(data[64:72])),
+				Epoch:         binary.LittleEndian.Uint64(data[72:80]),
+				Cusodian:      common.PublicKeyFromBytes(data[80:112]),
+			},
+		}, nil
+
+	case InstructionAuthorize:
+		if err := check(accounts, 3, data, 36); err != nil {
+			return nil, err
+		}
+		return AuthorizeParam{
+			Stake:     accounts[0].PubKey,
+			Auth:      accounts[2].PubKey,
+			NewAuth:   common.PublicKeyFromBytes(data[:32]),
+			AuthType:  StakeAuthorizationType(binary.LittleEndian.Uint32(data[32:36])),
+			Custodian: optionalAccount(accounts, 3),
+		}, nil
+
+	case InstructionDelegateStake:
+		if err := check(accounts, 6, data, 0); err != nil {
+			return nil, err
+		}
+		return DelegateStakeParam{
+			Stake: accounts[0].PubKey,
+			Auth:  accounts[5].PubKey,
+			Vote:  accounts[1].PubKey,
+		}, nil
+
+	case InstructionSplit:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		return SplitParam{
+			Stake:      accounts[0].PubKey,
+			Auth:       accounts[2].PubKey,
+			SplitStake: accounts[1].PubKey,
+			Lamports:   binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+
+	case InstructionWithdraw:
+		if err := check(accounts, 5, data, 8); err != nil {
+			return nil, err
+		}
+		return WithdrawParam{
+			Stake:     accounts[0].PubKey,
+			Auth:      accounts[4].PubKey,
+			To:        accounts[1].PubKey,
+			Lamports:  binary.LittleEndian.Uint64(data[:8]),
+			Custodian: optionalAccount(accounts, 5),
+		}, nil
+
+	case InstructionDeactivate:
+		if err := check(accounts, 3, data, 0); err != nil {
+			return nil, err
+		}
+		return DeactivateParam{
+			Stake: accounts[0].PubKey,
+			Auth:  accounts[2].PubKey,
+		}, nil
+
+	case InstructionSetLockup:
+		if err := check(accounts, 2, data, 0); err != nil {
+			return nil, err
+		}
+		lockup, err := decodeLockupParam(data)
+		if err != nil {
+			return nil, err
+		}
+		return SetLockupParam{
+			Stake:  accounts[0].PubKey,
+			Auth:   accounts[1].PubKey,
+			Lockup: lockup,
+		}, nil
+
+	case InstructionMerge:
+		if err := check(accounts, 5, data, 0); err != nil {
+			return nil, err
+		}
+		return MergeParam{
+			From: accounts[1].PubKey,
+			Auth: accounts[4].PubKey,
+			To:   accounts[0].PubKey,
+		}, nil
+
+	case InstructionAuthorizeWithSeed:
+		if err := check(accounts, 3, data, 36); err != nil {
+			return nil, err
+		}
+		seed, rest, err := decodeString(data[36:])
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 32 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return AuthorizeWithSeedParam{
+			Stake:     accounts[0].PubKey,
+			AuthBase:  accounts[1].PubKey,
+			AuthSeed:  seed,
+			AuthOwner: common.PublicKeyFromBytes(rest[:32]),
+			NewAuth:   common.PublicKeyFromBytes(data[:32]),
+			AuthType:  StakeAuthorizationType(binary.LittleEndian.Uint32(data[32:36])),
+			Custodian: optionalAccount(accounts, 3),
+		}, nil
+
+	case InstructionInitializeChecked:
+		if err := check(accounts, 4, data, 0); err != nil {
+			return nil, err
+		}
+		return InitializeCheckedParam{
+			Stake: accounts[0].PubKey,
+			Auth: Authorized{
+				Staker:     accounts[2].PubKey,
+				Withdrawer: accounts[3].PubKey,
+			},
+		}, nil
+
+	case InstructionAuthorizeChecked:
+		if err := check(accounts, 4, data, 4); err != nil {
+			return nil, err
+		}
+		return AuthorizeCheckedParam{
+			Stake:     accounts[0].PubKey,
+			Auth:      accounts[2].PubKey,
+			NewAuth:   accounts[3].PubKey,
+			AuthType:  StakeAuthorizationType(binary.LittleEndian.Uint32(data[:4])),
+			Custodian: optionalAccount(accounts, 4),
+		}, nil
+
+	case InstructionAuthorizeCheckedWithSeed:
+		if err := check(accounts, 4, data, 4); err != nil {
+			return nil, err
+		}
+		seed, rest, err := decodeString(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 32 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return AuthorizeCheckedWithSeedParam{
+			Stake:     accounts[0].PubKey,
+			AuthBase:  accounts[1].PubKey,
+			AuthSeed:  seed,
+			AuthOwner: common.PublicKeyFromBytes(rest[:32]),
+			NewAuth:   accounts[3].PubKey,
+			AuthType:  StakeAuthorizationType(binary.LittleEndian.Uint32(data[:4])),
+			Custodian: optionalAccount(accounts, 4),
+		}, nil
+
+	case InstructionSetLockupChecked:
+		if err := check(accounts, 2, data, 0); err != nil {
+			return nil, err
+		}
+		lockup, _, err := decodeLockupTime(data)
+		if err != nil {
+			return nil, err
+		}
+		return SetLockupCheckedParam{
+			Stake:         accounts[0].PubKey,
+			Auth:          accounts[1].PubKey,
+			UnixTimestamp: lockup.UnixTimestamp,
+			Epoch:         lockup.Epoch,
+			NewCustodian:  optionalAccount(accounts, 2),
+		}, nil
+
+	case InstructionGetMinimumDelegation:
+		return GetMinimumDelegationParam{}, nil
+
+	case InstructionDeactivateDelinquent:
+		if err := check(accounts, 3, data, 0); err != nil {
+			return nil, err
+		}
+		return DeactivateDelinquentParam{
+			Stake:          accounts[0].PubKey,
+			DelinquentVote: accounts[1].PubKey,
+			ReferenceVote:  accounts[2].PubKey,
+		}, nil
+
+	case InstructionRedelegate:
+		if err := check(accounts, 5, data, 0); err != nil {
+			return nil, err
+		}
+		return RedelegateParam{
+			Stake:              accounts[0].PubKey,
+			Auth:               accounts[4].PubKey,
+			UninitializedStake: accounts[1].PubKey,
+			Vote:               accounts[2].PubKey,
+		}, nil
+
+	case InstructionMoveStake:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		return MoveStakeParam{
+			From:     accounts[0].PubKey,
+			To:       accounts[1].PubKey,
+			Auth:     accounts[2].PubKey,
+			Lamports: binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+
+	case InstructionMoveLamports:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		return MoveLamportsParam{
+			From:     accounts[0].PubKey,
+			To:       accounts[1].PubKey,
+			Auth:     accounts[2].PubKey,
+			Lamports: binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+	}
+
+	return nil, types.ErrUnknownInstruction
+}
+
+func check(accounts []types.AccountMeta, accountsLen int, data []byte, dataLen int) error {
+	if len(accounts) < accountsLen {
+		return fmt.Errorf("%w, expected %v, got %v", types.ErrNotEnoughAccounts, accountsLen, len(accounts))
+	}
+	if len(data) < dataLen {
+		return fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+	}
+	return nil
+}
+
+func decodeLockupParam(data []byte) (LockupParam, error) {
+	lockup, rest, err := decodeLockupTime(data)
+	if err != nil {
+		return LockupParam{}, err
+	}
+	custodian, _, ok := decodeOption(rest, 32)
+	if !ok {
+		return LockupParam{}, fmt.Errorf("%w, invalid custodian", types.ErrInvalidInstructionData)
+	}
+	if custodian != nil {
+		v := common.PublicKeyFromBytes(custodian)
+		lockup.Cusodian = &v
+	}
+	return lockup, nil
+}
+
+// decodeLockupTime decodes the optional unix timestamp and epoch of a lockup and returns the remaining data
+func decodeLockupTime(data []byte) (LockupParam, []byte, error) {
+	var lockup LockupParam
+	unixTimestamp, rest, ok := decodeOption(data, 8)
+	if !ok {
+		return LockupParam{}, nil, fmt.Errorf("%w, invalid unix timestamp", types.ErrInvalidInstructionData)
+	}
+	if unixTimestamp != nil {
+		v := int64(binary.LittleEndian.Uint64(unixTimestamp))
+		lockup.UnixTimestamp = &v
+	}
+	epoch, rest, ok := decodeOption(rest, 8)
+	if !ok {
+		return LockupParam{}, nil, fmt.Errorf("%w, invalid epoch", types.ErrInvalidInstructionData)
+	}
+	if epoch != nil {
+		v := binary.LittleEndian.Uint64(epoch)
+		lockup.Epoch = &v
+	}
+	return lockup, rest, nil
+}
+
+func optionalAccount(accounts []types.AccountMeta, i int) *common.PublicKey {
+	if i >= len(accounts) {
+		return nil
+	}
+	pubkey := accounts[i].PubKey
+	return &pubkey
+}
+
+// decodeOption decodes a bincode option of a fixed size value, the value is nil for none
+func decodeOption(data []byte, size int) ([]byte, []byte, bool) {
+	if len(data) < 1 {
+		return nil, nil, false
+	}
+	switch data[0] {
+	case 0:
+		return nil, data[1:], true
+	case 1:
+		if len(data) < 1+size {
+			return nil, nil, false
+		}
+		return data[1 : 1+size], data[1+size:], true
+	}
+	return nil, nil, false
+}
+
+// decodeString decodes a bincode string and returns the remaining data
+func decodeString(data []byte) (string, []byte, error) {
+	if len(data) < 8 {
+		return "", nil, fmt.Errorf("%w, data size is not enough for string length", types.ErrInvalidInstructionData)
+	}
+	l := binary.LittleEndian.Uint64(data[:8])
+	if uint64(len(data)-8) < l {
+		return "", nil, fmt.Errorf("%w, data size is not enough for string", types.ErrInvalidInstructionData)
+	}
+	return string(data[8 : 8+l]), data[8+l:], nil
+}
@@ -0,0 +1,183 @@
+package stakeprog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstruction(t *testing.T) {
+	stake := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	other := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+		err         error
+	}{
+		{
+			name: "initialize",
+			instruction: Initialize(InitializeParam{
+				Stake:  stake,
+				Auth:   Authorized{Staker: auth, Withdrawer: other},
+				Lockup: Lockup{UnixTimestamp: 1, Epoch: 2, Cusodian: other},
+			}),
+			want: InitializeParam{
+				Stake:  stake,
+				Auth:   Authorized{Staker: auth, Withdrawer: other},
+				Lockup: Lockup{UnixTimestamp: 1, Epoch: 2, Cusodian: other},
+			},
+		},
+		{
+			name:        "authorize",
+			instruction: Authorize(AuthorizeParam{Stake: stake, Auth: auth, NewAuth: other, AuthType: StakeAuthorizationTypeWithdrawer}),
+			want:        AuthorizeParam{Stake: stake, Auth: auth, NewAuth: other, AuthType: StakeAuthorizationTypeWithdrawer},
+		},
+		{
+			name:        "authorize with custodian",
+			instruction: Authorize(AuthorizeParam{Stake: stake, Auth: auth, NewAuth: other, AuthType: StakeAuthorizationTypeStaker, Custodian: &other}),
+			want:        AuthorizeParam{Stake: stake, Auth: auth, NewAuth: other, AuthType: StakeAuthorizationTypeStaker, Custodian: &other},
+		},
+		{
+			name:        "delegate stake",
+			instruction: DelegateStake(DelegateStakeParam{Stake: stake, Auth: auth, Vote: other}),
+			want:        DelegateStakeParam{Stake: stake, Auth: auth, Vote: other},
+		},
+		{
+			name:        "split",
+			instruction: Split(SplitParam{Stake: stake, Auth: auth, SplitStake: other, Lamports: 100}),
+			want:        SplitParam{Stake: stake, Auth: auth, SplitStake: other, Lamports: 100},
+		},
+		{
+			name:        "withdraw",
+			instruction: Withdraw(WithdrawParam{Stake: stake, Auth: auth, To: other, Lamports: 100}),
+			want:        WithdrawParam{Stake: stake, Auth: auth, To: other, Lamports: 100},
+		},
+		{
+			name:        "deactivate",
+			instruction: Deactivate(DeactivateParam{Stake: stake, Auth: auth}),
+			want:        DeactivateParam{Stake: stake, Auth: auth},
+		},
+		{
+			name:        "set lockup",
+			instruction: SetLockup(SetLockupParam{Stake: stake, Auth: auth, Lockup: LockupParam{Epoch: pointer.Uint64(10), Cusodian: &other}}),
+			want:        SetLockupParam{Stake: stake, Auth: auth, Lockup: LockupParam{Epoch: pointer.Uint64(10), Cusodian: &other}},
+		},
+		{
+			name:        "merge",
+			instruction: Merge(MergeParam{From: stake, Auth: auth, To: other}),
+			want:        MergeParam{From: stake, Auth: auth, To: other},
+		},
+		{
+			name: "authorize with seed",
+			instruction: AuthorizeWithSeed(AuthorizeWithSeedParam{
+				Stake:     stake,
+				AuthBase:  auth,
+				AuthSeed:  "seed",
+				AuthOwner: common.SystemProgramID,
+				NewAuth:   other,
+				AuthType:  StakeAuthorizationTypeStaker,
+			}),
+			want: AuthorizeWithSeedParam{
+				Stake:     stake,
+				AuthBase:  auth,
+				AuthSeed:  "seed",
+				AuthOwner: common.SystemProgramID,
+				NewAuth:   other,
+				AuthType:  StakeAuthorizationTypeStaker,
+			},
+		},
+		{
+			name:        "initialize checked",
+			instruction: InitializeChecked(InitializeCheckedParam{Stake: stake, Auth: Authorized{Staker: auth, Withdrawer: other}}),
+			want:        InitializeCheckedParam{Stake: stake, Auth: Authorized{Staker: auth, Withdrawer: other}},
+		},
+		{
+			name:        "authorize checked",
+			instruction: AuthorizeChecked(AuthorizeCheckedParam{Stake: stake, Auth: auth, NewAuth: other, AuthType: StakeAuthorizationTypeWithdrawer, Custodian: &other}),
+			want:        AuthorizeCheckedParam{Stake: stake, Auth: auth, NewAuth: other, AuthType: StakeAuthorizationTypeWithdrawer, Custodian: &other},
+		},
+		{
+			name: "authorize checked with seed",
+			instruction: AuthorizeCheckedWithSeed(AuthorizeCheckedWithSeedParam{
+				Stake:     stake,
+				AuthBase:  auth,
+				AuthSeed:  "seed",
+				AuthOwner: common.SystemProgramID,
+				NewAuth:   other,
+				AuthType:  StakeAuthorizationTypeWithdrawer,
+			}),
+			want: AuthorizeCheckedWithSeedParam{
+				Stake:     stake,
+				AuthBase:  auth,
+				AuthSeed:  "seed",
+				AuthOwner: common.SystemProgramID,
+				NewAuth:   other,
+				AuthType:  StakeAuthorizationTypeWithdrawer,
+			},
+		},
+		{
+			name:        "set lockup checked",
+			instruction: SetLockupChecked(SetLockupCheckedParam{Stake: stake, Auth: auth, UnixTimestamp: pointer.Int64(1), NewCustodian: &other}),
+			want:        SetLockupCheckedParam{Stake: stake, Auth: auth, UnixTimestamp: pointer.Int64(1), NewCustodian: &other},
+		},
+		{
+			name:        "get minimum delegation",
+			instruction: GetMinimumDelegation(GetMinimumDelegationParam{}),
+			want:        GetMinimumDelegationParam{},
+		},
+		{
+			name:        "deactivate delinquent",
+			instruction: DeactivateDelinquent(DeactivateDelinquentParam{Stake: stake, DelinquentVote: auth, ReferenceVote: other}),
+			want:        DeactivateDelinquentParam{Stake: stake, DelinquentVote: auth, ReferenceVote: other},
+		},
+		{
+			name:        "redelegate",
+			instruction: Redelegate(RedelegateParam{Stake: stake, Auth: auth, UninitializedStake: other, Vote: common.SystemProgramID}),
+			want:        RedelegateParam{Stake: stake, Auth: auth, UninitializedStake: other, Vote: common.SystemProgramID},
+		},
+		{
+			name:        "move stake",
+			instruction: MoveStake(MoveStakeParam{From: stake, To: other, Auth: auth, Lamports: 100}),
+			want:        MoveStakeParam{From: stake, To: other, Auth: auth, Lamports: 100},
+		},
+		{
+			name:        "move lamports",
+			instruction: MoveLamports(MoveLamportsParam{From: stake, To: other, Auth: auth, Lamports: 100}),
+			want:        MoveLamportsParam{From: stake, To: other, Auth: auth, Lamports: 100},
+		},
+		{
+			name:        "program id mismatch",
+			instruction: types.Instruction{ProgramID: common.SystemProgramID, Data: []byte{5, 0, 0, 0}},
+			err:         types.ErrProgramIDMismatch,
+		},
+		{
+			name:        "unknown instruction",
+			instruction: types.Instruction{ProgramID: common.StakeProgramID, Data: []byte{255, 0, 0, 0}},
+			err:         types.ErrUnknownInstruction,
+		},
+		{
+			name:        "invalid lockup",
+			instruction: types.Instruction{ProgramID: common.StakeProgramID, Accounts: make([]types.AccountMeta, 2), Data: []byte{6, 0, 0, 0, 2}},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "not enough accounts",
+			instruction: types.Instruction{ProgramID: common.StakeProgramID, Accounts: make([]types.AccountMeta, 3), Data: []byte{2, 0, 0, 0}},
+			err:         types.ErrNotEnoughAccounts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.True(t, errors.Is(err, tt.err), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package stakeprog
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/pkg/bincode"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/program/sysvar"
 	"github.com/portto/solana-go-sdk/types"
 )
 
@@ -20,6 +25,15 @@ const (
 	InstructionSetLockup
 	InstructionMerge
 	InstructionAuthorizeWithSeed
+	InstructionInitializeChecked
+	InstructionAuthorizeChecked
+	InstructionAuthorizeCheckedWithSeed
+	InstructionSetLockupChecked
+	InstructionGetMinimumDelegation
+	InstructionDeactivateDelinquent
+	InstructionRedelegate
+	InstructionMoveStake
+	InstructionMoveLamports
 )
 
 type StakeAuthorizationType uint32
@@ -346,3 +360,338 @@ func AuthorizeWithSeed(param AuthorizeWithSeedParam) types.Instruction {
 		Data:      data,
 	}
 }
+
+type InitializeCheckedParam struct {
+	Stake common.PublicKey
+	Auth  Authorized
+}
+
+// InitializeChecked initializes a stake account without lockup, the withdrawer must sign
+func InitializeChecked(param InitializeCheckedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionInitializeChecked,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Auth.Staker, IsSigner: false, IsWritable: false},
+			{PubKey: param.Auth.Withdrawer, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type AuthorizeCheckedParam struct {
+	Stake     common.PublicKey
+	Auth      common.PublicKey
+	NewAuth   common.PublicKey
+	AuthType  StakeAuthorizationType
+	Custodian *common.PublicKey
+}
+
+// AuthorizeChecked is the same as Authorize but the new authority must sign
+func AuthorizeChecked(param AuthorizeCheckedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		StakeAuthorizationType StakeAuthorizationType
+	}{
+		Instruction:            InstructionAuthorizeChecked,
+		StakeAuthorizationType: param.AuthType,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 5)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		types.AccountMeta{PubKey: param.NewAuth, IsSigner: true, IsWritable: false},
+	)
+	if param.Custodian != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.Custodian, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type AuthorizeCheckedWithSeedParam struct {
+	Stake     common.PublicKey
+	AuthBase  common.PublicKey
+	AuthSeed  string
+	AuthOwner common.PublicKey
+	NewAuth   common.PublicKey
+	AuthType  StakeAuthorizationType
+	Custodian *common.PublicKey
+}
+
+// AuthorizeCheckedWithSeed is the same as AuthorizeWithSeed but the new authority must sign
+func AuthorizeCheckedWithSeed(param AuthorizeCheckedWithSeedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		StakeAuthorizationType StakeAuthorizationType
+		AuthSeed               string
+		AuthOwner              common.PublicKey
+	}{
+		Instruction:            InstructionAuthorizeCheckedWithSeed,
+		StakeAuthorizationType: param.AuthType,
+		AuthSeed:               param.AuthSeed,
+		AuthOwner:              param.AuthOwner,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 5)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.AuthBase, IsSigner: true, IsWritable: false},
+		types.AccountMeta{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: param.NewAuth, IsSigner: true, IsWritable: false},
+	)
+	if param.Custodian != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.Custodian, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type SetLockupCheckedParam struct {
+	Stake         common.PublicKey
+	Auth          common.PublicKey
+	UnixTimestamp *int64
+	Epoch         *uint64
+	NewCustodian  *common.PublicKey
+}
+
+// SetLockupChecked is the same as SetLockup but the new custodian must sign
+func SetLockupChecked(param SetLockupCheckedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction   Instruction
+		UnixTimestamp *int64
+		Epoch         *uint64
+	}{
+		Instruction:   InstructionSetLockupChecked,
+		UnixTimestamp: param.UnixTimestamp,
+		Epoch:         param.Epoch,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+	)
+	if param.NewCustodian != nil {
+		accounts = append(accounts, types.AccountMeta{PubKey: *param.NewCustodian, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type GetMinimumDelegationParam struct{}
+
+// GetMinimumDelegation returns the minimum delegation in the return data, it is usually used with simulation
+func GetMinimumDelegation(param GetMinimumDelegationParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionGetMinimumDelegation,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}
+}
+
+type DeactivateDelinquentParam struct {
+	Stake          common.PublicKey
+	DelinquentVote common.PublicKey
+	ReferenceVote  common.PublicKey
+}
+
+// DeactivateDelinquent deactivates a stake delegated to a vote account which has been delinquent
+// for at least 5 epochs. the reference vote account must have voted in each of the last 5 epochs.
+func DeactivateDelinquent(param DeactivateDelinquentParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionDeactivateDelinquent,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+			{PubKey: param.DelinquentVote, IsSigner: false, IsWritable: false},
+			{PubKey: param.ReferenceVote, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type RedelegateParam struct {
+	Stake              common.PublicKey
+	Auth               common.PublicKey
+	UninitializedStake common.PublicKey
+	Vote               common.PublicKey
+}
+
+// Redelegate moves a delegated stake to an uninitialized stake account which is delegated to another vote account.
+// Deprecated: the instruction is disabled on the cluster, use MoveStake instead.
+func Redelegate(param RedelegateParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionRedelegate,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Stake, IsSigner: false, IsWritable: true},
+			{PubKey: param.UninitializedStake, IsSigner: false, IsWritable: true},
+			{PubKey: param.Vote, IsSigner: false, IsWritable: false},
+			{PubKey: common.StakeConfigPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type MoveStakeParam struct {
+	From     common.PublicKey
+	To       common.PublicKey
+	Auth     common.PublicKey
+	Lamports uint64
+}
+
+// MoveStake moves active stake between two stake accounts which share the same authorities and vote account
+func MoveStake(param MoveStakeParam) types.Instruction {
+	return moveInstruction(InstructionMoveStake, param.From, param.To, param.Auth, param.Lamports)
+}
+
+type MoveLamportsParam struct {
+	From     common.PublicKey
+	To       common.PublicKey
+	Auth     common.PublicKey
+	Lamports uint64
+}
+
+// MoveLamports moves free lamports, which are neither stake nor rent exempt reserve, between two stake accounts
+func MoveLamports(param MoveLamportsParam) types.Instruction {
+	return moveInstruction(InstructionMoveLamports, param.From, param.To, param.Auth, param.Lamports)
+}
+
+func moveInstruction(instruction Instruction, from, to, auth common.PublicKey, lamports uint64) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+		Lamports    uint64
+	}{
+		Instruction: instruction,
+		Lamports:    lamports,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: from, IsSigner: false, IsWritable: true},
+			{PubKey: to, IsSigner: false, IsWritable: true},
+			{PubKey: auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type CreateAccountWithSeedAndDelegateStakeParam struct {
+	From   common.PublicKey
+	Base   common.PublicKey
+	Seed   string
+	Vote   common.PublicKey
+	Auth   Authorized
+	Lockup Lockup
+	// Amount is the lamports to delegate
+	Amount uint64
+	// Rent sizes the rent exempt reserve of AccountSize, the default is sysvar.DefaultRent
+	Rent *sysvar.Rent
+}
+
+// CreateAccountWithSeedAndDelegateStake returns instructions which create a stake account with seed,
+// initialize it and delegate Amount to the vote account. the stake account is common.CreateWithSeed(Base, Seed, common.StakeProgramID)
+// and is funded with the rent exempt reserve of AccountSize plus Amount. the staker must sign for the delegation.
+func CreateAccountWithSeedAndDelegateStake(param CreateAccountWithSeedAndDelegateStakeParam) []types.Instruction {
+	if len(param.Seed) > common.MaxSeedLength {
+		panic(fmt.Errorf("seed is longer than %v bytes", common.MaxSeedLength))
+	}
+	if param.Amount == 0 {
+		panic(errors.New("amount to delegate is required"))
+	}
+	rent := sysvar.DefaultRent
+	if param.Rent != nil {
+		rent = *param.Rent
+	}
+	lamports := rent.MinimumBalance(AccountSize) + param.Amount
+	if lamports < param.Amount {
+		panic(errors.New("lamports overflow"))
+	}
+
+	stake := common.CreateWithSeed(param.Base, param.Seed, common.StakeProgramID)
+	return []types.Instruction{
+		sysprog.CreateAccountWithSeed(sysprog.CreateAccountWithSeedParam{
+			From:     param.From,
+			New:      stake,
+			Base:     param.Base,
+			Owner:    common.StakeProgramID,
+			Seed:     param.Seed,
+			Lamports: lamports,
+			Space:    AccountSize,
+		}),
+		Initialize(InitializeParam{
+			Stake:  stake,
+			Auth:   param.Auth,
+			Lockup: param.Lockup,
+		}),
+		DelegateStake(DelegateStakeParam{
+			Stake: stake,
+			Auth:  param.Auth.Staker,
+			Vote:  param.Vote,
+		}),
+	}
+}
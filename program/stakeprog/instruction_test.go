@@ -1,11 +1,14 @@
 package stakeprog
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
 	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/program/sysprog"
+	"github.com/portto/solana-go-sdk/program/sysvar"
 	"github.com/portto/solana-go-sdk/types"
 )
 
@@ -251,3 +254,135 @@ func TestSetLockup(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizeChecked(t *testing.T) {
+	got := AuthorizeChecked(AuthorizeCheckedParam{
+		Stake:    common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		Auth:     common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+		NewAuth:  common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+		AuthType: StakeAuthorizationTypeWithdrawer,
+	})
+	want := types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+			{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: true, IsWritable: false},
+		},
+		Data: []byte{10, 0, 0, 0, 1, 0, 0, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AuthorizeChecked() = %v, want %v", got, want)
+	}
+}
+
+func TestSetLockupChecked(t *testing.T) {
+	got := SetLockupChecked(SetLockupCheckedParam{
+		Stake: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		Auth:  common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+		Epoch: pointer.Uint64(1),
+	})
+	want := types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: true},
+			{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+		},
+		Data: []byte{12, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SetLockupChecked() = %v, want %v", got, want)
+	}
+}
+
+func TestMoveStake(t *testing.T) {
+	got := MoveStake(MoveStakeParam{
+		From:     common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		To:       common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+		Auth:     common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+		Lamports: 1,
+	})
+	want := types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: true},
+			{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+			{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+		},
+		Data: []byte{16, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MoveStake() = %v, want %v", got, want)
+	}
+}
+
+func TestCreateAccountWithSeedAndDelegateStake(t *testing.T) {
+	from := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	vote := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	stake := common.CreateWithSeed(from, "stake:0", common.StakeProgramID)
+	auth := Authorized{Staker: from, Withdrawer: from}
+	want := func(lamports uint64) []types.Instruction {
+		return []types.Instruction{
+			sysprog.CreateAccountWithSeed(sysprog.CreateAccountWithSeedParam{
+				From:     from,
+				New:      stake,
+				Base:     from,
+				Owner:    common.StakeProgramID,
+				Seed:     "stake:0",
+				Lamports: lamports,
+				Space:    AccountSize,
+			}),
+			Initialize(InitializeParam{Stake: stake, Auth: auth}),
+			DelegateStake(DelegateStakeParam{Stake: stake, Auth: from, Vote: vote}),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		param CreateAccountWithSeedAndDelegateStakeParam
+		want  []types.Instruction
+	}{
+		{
+			name:  "default rent",
+			param: CreateAccountWithSeedAndDelegateStakeParam{From: from, Base: from, Seed: "stake:0", Vote: vote, Auth: auth, Amount: 1000000000},
+			want:  want(1002282880),
+		},
+		{
+			name: "custom rent",
+			param: CreateAccountWithSeedAndDelegateStakeParam{
+				From:   from,
+				Base:   from,
+				Seed:   "stake:0",
+				Vote:   vote,
+				Auth:   auth,
+				Amount: 1000000000,
+				Rent:   &sysvar.Rent{LamportsPerByteYear: 1000, ExemptionThreshold: 1},
+			},
+			want: want(1000328000),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CreateAccountWithSeedAndDelegateStake(tt.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateAccountWithSeedAndDelegateStake() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	invalidParams := []CreateAccountWithSeedAndDelegateStakeParam{
+		{From: from, Base: from, Seed: "stake:0"},
+		{From: from, Base: from, Seed: "stake:0:0123456789012345678901234567", Amount: 1000000000},
+		{From: from, Base: from, Seed: "stake:0", Amount: math.MaxUint64},
+	}
+	for _, param := range invalidParams {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("CreateAccountWithSeedAndDelegateStake(%v) didn't panic", param)
+				}
+			}()
+			CreateAccountWithSeedAndDelegateStake(param)
+		}()
+	}
+}
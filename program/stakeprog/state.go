@@ -0,0 +1,327 @@
+package stakeprog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+var ErrInvalidAccountDataSize = errors.New("invalid account data size")
+
+type StakeStateType uint32
+
+const (
+	StakeStateTypeUninitialized StakeStateType = iota
+	StakeStateTypeInitialized
+	StakeStateTypeStake
+	StakeStateTypeRewardsPool
+)
+
+type Meta struct {
+	RentExemptReserve uint64
+	Authorized        Authorized
+	Lockup            Lockup
+}
+
+type Delegation struct {
+	VoterPubkey        common.PublicKey
+	Stake              uint64
+	ActivationEpoch    uint64
+	DeactivationEpoch  uint64
+	WarmupCooldownRate float64
+}
+
+type Stake struct {
+	Delegation      Delegation
+	CreditsObserved uint64
+}
+
+type StakeFlags uint8
+
+const (
+	StakeFlagsMustFullyActivateBeforeDeactivationIsPermitted StakeFlags = 1 << iota
+)
+
+// StakeState is the state of a stake account. Meta is set if the account is initialized and
+// Stake is set if the account has been delegated.
+type StakeState struct {
+	Type       StakeStateType
+	Meta       *Meta
+	Stake      *Stake
+	StakeFlags StakeFlags
+}
+
+const (
+	metaSize  = 120
+	stakeSize = 72
+)
+
+func StakeStateDeserialize(data []byte) (StakeState, error) {
+	if len(data) < 4 {
+		return StakeState{}, ErrInvalidAccountDataSize
+	}
+	state := StakeState{
+		Type: StakeStateType(binary.LittleEndian.Uint32(data[:4])),
+	}
+	data = data[4:]
+
+	switch state.Type {
+	case StakeStateTypeUninitialized, StakeStateTypeRewardsPool:
+		return state, nil
+	case StakeStateTypeInitialized:
+		if len(data) < metaSize {
+			return StakeState{}, ErrInvalidAccountDataSize
+		}
+		meta := metaFromData(data[:metaSize])
+		state.Meta = &meta
+		return state, nil
+	case StakeStateTypeStake:
+		if len(data) < metaSize+stakeSize {
+			return StakeState{}, ErrInvalidAccountDataSize
+		}
+		meta := metaFromData(data[:metaSize])
+		stake := stakeFromData(data[metaSize : metaSize+stakeSize])
+		state.Meta = &meta
+		state.Stake = &stake
+		// stake flags are appended by a later version, old accounts have zero padding there
+		if len(data) > metaSize+stakeSize {
+			state.StakeFlags = StakeFlags(data[metaSize+stakeSize])
+		}
+		return state, nil
+	}
+	return StakeState{}, fmt.Errorf("unknown stake state type %v", state.Type)
+}
+
+func metaFromData(data []byte) Meta {
+	return Meta{
+		RentExemptReserve: binary.LittleEndian.Uint64(data[:8]),
+		Authorized: Authorized{
+			Staker:     common.PublicKeyFromBytes(data[8:40]),
+			Withdrawer: common.PublicKeyFromBytes(data[40:72]),
+		},
+		Lockup: Lockup{
+			UnixTimestamp: int64(binary.LittleEndian.Uint64(data[72:80])),
+			Epoch:         binary.LittleEndian.Uint64(data[80:88]),
+			Cusodian:      common.PublicKeyFromBytes(data[88:120]),
+		},
+	}
+}
+
+func stakeFromData(data []byte) Stake {
+	return Stake{
+		Delegation: Delegation{
+			VoterPubkey:        common.PublicKeyFromBytes(data[:32]),
+			Stake:              binary.LittleEndian.Uint64(data[32:40]),
+			ActivationEpoch:    binary.LittleEndian.Uint64(data[40:48]),
+			DeactivationEpoch:  binary.LittleEndian.Uint64(data[48:56]),
+			WarmupCooldownRate: math.Float64frombits(binary.LittleEndian.Uint64(data[56:64])),
+		},
+		CreditsObserved: binary.LittleEndian.Uint64(data[64:72]),
+	}
+}
+
+type StakeHistoryEntry struct {
+	Effective    uint64
+	Activating   uint64
+	Deactivating uint64
+}
+
+type StakeHistoryEpochEntry struct {
+	Epoch uint64
+	StakeHistoryEntry
+}
+
+// StakeHistory is the data of the stake history sysvar, entries are sorted from the newest epoch
+type StakeHistory []StakeHistoryEpochEntry
+
+func StakeHistoryDeserialize(data []byte) (StakeHistory, error) {
+	if len(data) < 8 {
+		return nil, ErrInvalidAccountDataSize
+	}
+	n := binary.LittleEndian.Uint64(data[:8])
+	data = data[8:]
+	if uint64(len(data))/32 < n {
+		return nil, ErrInvalidAccountDataSize
+	}
+	history := make(StakeHistory, 0, n)
+	for i := uint64(0); i < n; i++ {
+		history = append(history, StakeHistoryEpochEntry{
+			Epoch: binary.LittleEndian.Uint64(data[:8]),
+			StakeHistoryEntry: StakeHistoryEntry{
+				Effective:    binary.LittleEndian.Uint64(data[8:16]),
+				Activating:   binary.LittleEndian.Uint64(data[16:24]),
+				Deactivating: binary.LittleEndian.Uint64(data[24:32]),
+			},
+		})
+		data = data[32:]
+	}
+	return history, nil
+}
+
+// Get returns the entry of the epoch
+func (h StakeHistory) Get(epoch uint64) (StakeHistoryEntry, bool) {
+	i := sort.Search(len(h), func(i int) bool { return h[i].Epoch <= epoch })
+	if i < len(h) && h[i].Epoch == epoch {
+		return h[i].StakeHistoryEntry, true
+	}
+	return StakeHistoryEntry{}, false
+}
+
+const (
+	DefaultWarmupCooldownRate = 0.25
+	NewWarmupCooldownRate     = 0.09
+)
+
+// WarmupCooldownRate returns the rate at the epoch. newRateActivationEpoch is the epoch which
+// the reduce_stake_warmup_cooldown feature is activated at, nil if it is not activated.
+func WarmupCooldownRate(epoch uint64, newRateActivationEpoch *uint64) float64 {
+	if newRateActivationEpoch == nil || epoch < *newRateActivationEpoch {
+		return DefaultWarmupCooldownRate
+	}
+	return NewWarmupCooldownRate
+}
+
+type StakeActivationState string
+
+const (
+	StakeActivationStateActivating   StakeActivationState = "activating"
+	StakeActivationStateActive       StakeActivationState = "active"
+	StakeActivationStateDeactivating StakeActivationState = "deactivating"
+	StakeActivationStateInactive     StakeActivationState = "inactive"
+)
+
+type StakeActivationStatus struct {
+	Effective    uint64
+	Activating   uint64
+	Deactivating uint64
+}
+
+// State returns the state the same way as getStakeActivation
+func (s StakeActivationStatus) State() StakeActivationState {
+	switch {
+	case s.Deactivating > 0:
+		return StakeActivationStateDeactivating
+	case s.Activating > 0:
+		return StakeActivationStateActivating
+	case s.Effective > 0:
+		return StakeActivationStateActive
+	}
+	return StakeActivationStateInactive
+}
+
+// StakeActivatingAndDeactivating calculates the effective, activating and deactivating stake at
+// the target epoch the same way as the stake program does.
+func (d Delegation) StakeActivatingAndDeactivating(targetEpoch uint64, history StakeHistory, newRateActivationEpoch *uint64) StakeActivationStatus {
+	effectiveStake, activatingStake := d.stakeAndActivating(targetEpoch, history, newRateActivationEpoch)
+
+	switch {
+	case targetEpoch < d.DeactivationEpoch:
+		return StakeActivationStatus{Effective: effectiveStake, Activating: activatingStake}
+	case targetEpoch == d.DeactivationEpoch:
+		// can only deactivate what's activated
+		return StakeActivationStatus{Effective: effectiveStake, Deactivating: effectiveStake}
+	}
+
+	prevClusterStake, ok := history.Get(d.DeactivationEpoch)
+	if !ok {
+		// no history or dropped out of history, so assume fully deactivated
+		return StakeActivationStatus{}
+	}
+	prevEpoch := d.DeactivationEpoch
+	currentEffectiveStake := effectiveStake
+	for {
+		currentEpoch := prevEpoch + 1
+		if prevClusterStake.Deactivating == 0 {
+			break
+		}
+
+		weight := float64(currentEffectiveStake) / float64(prevClusterStake.Deactivating)
+		rate := WarmupCooldownRate(currentEpoch, newRateActivationEpoch)
+		newlyNotEffectiveClusterStake := float64(prevClusterStake.Effective) * rate
+		newlyNotEffectiveStake := max1(weight * newlyNotEffectiveClusterStake)
+
+		if newlyNotEffectiveStake >= currentEffectiveStake {
+			currentEffectiveStake = 0
+			break
+		}
+		currentEffectiveStake -= newlyNotEffectiveStake
+		if currentEpoch >= targetEpoch {
+			break
+		}
+		currentClusterStake, ok := history.Get(currentEpoch)
+		if !ok {
+			break
+		}
+		prevEpoch = currentEpoch
+		prevClusterStake = currentClusterStake
+	}
+	return StakeActivationStatus{Effective: currentEffectiveStake, Deactivating: currentEffectiveStake}
+}
+
+func (d Delegation) stakeAndActivating(targetEpoch uint64, history StakeHistory, newRateActivationEpoch *uint64) (uint64, uint64) {
+	delegatedStake := d.Stake
+
+	switch {
+	case d.ActivationEpoch == math.MaxUint64:
+		// bootstrap stake is fully effective
+		return delegatedStake, 0
+	case d.ActivationEpoch == d.DeactivationEpoch:
+		// activated but instantly deactivated, no stake at all regardless of target epoch
+		return 0, 0
+	case targetEpoch == d.ActivationEpoch:
+		return 0, delegatedStake
+	case targetEpoch < d.ActivationEpoch:
+		return 0, 0
+	}
+
+	prevClusterStake, ok := history.Get(d.ActivationEpoch)
+	if !ok {
+		// no history or dropped out of history, so assume fully effective
+		return delegatedStake, 0
+	}
+	prevEpoch := d.ActivationEpoch
+	var currentEffectiveStake uint64
+	for {
+		currentEpoch := prevEpoch + 1
+		if prevClusterStake.Activating == 0 {
+			break
+		}
+
+		remainingActivatingStake := delegatedStake - currentEffectiveStake
+		weight := float64(remainingActivatingStake) / float64(prevClusterStake.Activating)
+		rate := WarmupCooldownRate(currentEpoch, newRateActivationEpoch)
+		newlyEffectiveClusterStake := float64(prevClusterStake.Effective) * rate
+		newlyEffectiveStake := max1(weight * newlyEffectiveClusterStake)
+
+		currentEffectiveStake += newlyEffectiveStake
+		if currentEffectiveStake >= delegatedStake {
+			currentEffectiveStake = delegatedStake
+			break
+		}
+		if currentEpoch >= targetEpoch || currentEpoch >= d.DeactivationEpoch {
+			break
+		}
+		currentClusterStake, ok := history.Get(currentEpoch)
+		if !ok {
+			break
+		}
+		prevEpoch = currentEpoch
+		prevClusterStake = currentClusterStake
+	}
+	return currentEffectiveStake, delegatedStake - currentEffectiveStake
+}
+
+// max1 converts f to u64 the way rust `as` does and returns at least 1
+func max1(f float64) uint64 {
+	if f < 1 || math.IsNaN(f) {
+		return 1
+	}
+	if f >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(f)
+}
@@ -0,0 +1,234 @@
+package stakeprog
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStakeStateDeserialize(t *testing.T) {
+	staker := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	withdrawer := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	vote := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+
+	meta := make([]byte, 120)
+	binary.LittleEndian.PutUint64(meta[:8], 2282880)
+	copy(meta[8:40], staker.Bytes())
+	copy(meta[40:72], withdrawer.Bytes())
+	binary.LittleEndian.PutUint64(meta[80:88], 5)
+
+	stake := make([]byte, 72)
+	copy(stake[:32], vote.Bytes())
+	binary.LittleEndian.PutUint64(stake[32:40], 1000000000)
+	binary.LittleEndian.PutUint64(stake[40:48], 100)
+	binary.LittleEndian.PutUint64(stake[48:56], math.MaxUint64)
+	binary.LittleEndian.PutUint64(stake[56:64], math.Float64bits(0.25))
+	binary.LittleEndian.PutUint64(stake[64:72], 42)
+
+	wantMeta := &Meta{
+		RentExemptReserve: 2282880,
+		Authorized:        Authorized{Staker: staker, Withdrawer: withdrawer},
+		Lockup:            Lockup{Epoch: 5},
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    StakeState
+		wantErr bool
+	}{
+		{
+			name: "uninitialized",
+			data: make([]byte, AccountSize),
+			want: StakeState{Type: StakeStateTypeUninitialized},
+		},
+		{
+			name: "initialized",
+			data: append(append([]byte{1, 0, 0, 0}, meta...), make([]byte, 76)...),
+			want: StakeState{Type: StakeStateTypeInitialized, Meta: wantMeta},
+		},
+		{
+			name: "stake",
+			data: append(append(append([]byte{2, 0, 0, 0}, meta...), stake...), 1, 0, 0, 0),
+			want: StakeState{
+				Type: StakeStateTypeStake,
+				Meta: wantMeta,
+				Stake: &Stake{
+					Delegation: Delegation{
+						VoterPubkey:        vote,
+						Stake:              1000000000,
+						ActivationEpoch:    100,
+						DeactivationEpoch:  math.MaxUint64,
+						WarmupCooldownRate: 0.25,
+					},
+					CreditsObserved: 42,
+				},
+				StakeFlags: StakeFlagsMustFullyActivateBeforeDeactivationIsPermitted,
+			},
+		},
+		{
+			name: "rewards pool",
+			data: []byte{3, 0, 0, 0},
+			want: StakeState{Type: StakeStateTypeRewardsPool},
+		},
+		{
+			name:    "data size is not enough",
+			data:    append([]byte{2, 0, 0, 0}, meta...),
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			data:    []byte{4, 0, 0, 0},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StakeStateDeserialize(tt.data)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStakeHistoryDeserialize(t *testing.T) {
+	data := make([]byte, 8+2*32)
+	binary.LittleEndian.PutUint64(data[:8], 2)
+	binary.LittleEndian.PutUint64(data[8:16], 11)
+	binary.LittleEndian.PutUint64(data[16:24], 100)
+	binary.LittleEndian.PutUint64(data[40:48], 10)
+	binary.LittleEndian.PutUint64(data[56:64], 5)
+	binary.LittleEndian.PutUint64(data[64:72], 6)
+
+	got, err := StakeHistoryDeserialize(data)
+	assert.NoError(t, err)
+	assert.Equal(t, StakeHistory{
+		{Epoch: 11, StakeHistoryEntry: StakeHistoryEntry{Effective: 100}},
+		{Epoch: 10, StakeHistoryEntry: StakeHistoryEntry{Activating: 5, Deactivating: 6}},
+	}, got)
+
+	entry, ok := got.Get(10)
+	assert.True(t, ok)
+	assert.Equal(t, StakeHistoryEntry{Activating: 5, Deactivating: 6}, entry)
+	_, ok = got.Get(12)
+	assert.False(t, ok)
+	_, ok = got.Get(9)
+	assert.False(t, ok)
+
+	_, err = StakeHistoryDeserialize(data[:40])
+	assert.Equal(t, ErrInvalidAccountDataSize, err)
+}
+
+func TestDelegation_StakeActivatingAndDeactivating(t *testing.T) {
+	activating := Delegation{Stake: 1000, ActivationEpoch: 10, DeactivationEpoch: math.MaxUint64}
+	slowHistory := StakeHistory{
+		{Epoch: 11, StakeHistoryEntry: StakeHistoryEntry{Effective: 1250, Activating: 750}},
+		{Epoch: 10, StakeHistoryEntry: StakeHistoryEntry{Effective: 1000, Activating: 1000}},
+	}
+	deactivating := Delegation{Stake: 1000, ActivationEpoch: 0, DeactivationEpoch: 20}
+
+	tests := []struct {
+		name                   string
+		delegation             Delegation
+		targetEpoch            uint64
+		history                StakeHistory
+		newRateActivationEpoch *uint64
+		want                   StakeActivationStatus
+		wantState              StakeActivationState
+	}{
+		{
+			name:        "before activation",
+			delegation:  activating,
+			targetEpoch: 9,
+			want:        StakeActivationStatus{},
+			wantState:   StakeActivationStateInactive,
+		},
+		{
+			name:        "activation epoch",
+			delegation:  activating,
+			targetEpoch: 10,
+			want:        StakeActivationStatus{Activating: 1000},
+			wantState:   StakeActivationStateActivating,
+		},
+		{
+			name:        "fully activated in one epoch",
+			delegation:  activating,
+			targetEpoch: 11,
+			history:     StakeHistory{{Epoch: 10, StakeHistoryEntry: StakeHistoryEntry{Effective: 10000, Activating: 1000}}},
+			want:        StakeActivationStatus{Effective: 1000},
+			wantState:   StakeActivationStateActive,
+		},
+		{
+			name:        "warmup",
+			delegation:  activating,
+			targetEpoch: 11,
+			history:     slowHistory,
+			want:        StakeActivationStatus{Effective: 250, Activating: 750},
+			wantState:   StakeActivationStateActivating,
+		},
+		{
+			name:        "warmup in two epochs",
+			delegation:  activating,
+			targetEpoch: 12,
+			history:     slowHistory,
+			want:        StakeActivationStatus{Effective: 562, Activating: 438},
+			wantState:   StakeActivationStateActivating,
+		},
+		{
+			name:                   "warmup with new rate",
+			delegation:             activating,
+			targetEpoch:            11,
+			history:                slowHistory,
+			newRateActivationEpoch: pointer.Uint64(0),
+			want:                   StakeActivationStatus{Effective: 90, Activating: 910},
+			wantState:              StakeActivationStateActivating,
+		},
+		{
+			name:        "no history",
+			delegation:  activating,
+			targetEpoch: 11,
+			want:        StakeActivationStatus{Effective: 1000},
+			wantState:   StakeActivationStateActive,
+		},
+		{
+			name:        "deactivation epoch",
+			delegation:  deactivating,
+			targetEpoch: 20,
+			want:        StakeActivationStatus{Effective: 1000, Deactivating: 1000},
+			wantState:   StakeActivationStateDeactivating,
+		},
+		{
+			name:        "cooldown",
+			delegation:  deactivating,
+			targetEpoch: 21,
+			history:     StakeHistory{{Epoch: 20, StakeHistoryEntry: StakeHistoryEntry{Effective: 2000, Deactivating: 1000}}},
+			want:        StakeActivationStatus{Effective: 500, Deactivating: 500},
+			wantState:   StakeActivationStateDeactivating,
+		},
+		{
+			name:        "deactivated",
+			delegation:  deactivating,
+			targetEpoch: 21,
+			want:        StakeActivationStatus{},
+			wantState:   StakeActivationStateInactive,
+		},
+		{
+			name:        "bootstrap",
+			delegation:  Delegation{Stake: 1000, ActivationEpoch: math.MaxUint64, DeactivationEpoch: math.MaxUint64},
+			targetEpoch: 0,
+			want:        StakeActivationStatus{Effective: 1000},
+			wantState:   StakeActivationStateActive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.delegation.StakeActivatingAndDeactivating(tt.targetEpoch, tt.history, tt.newRateActivationEpoch)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantState, got.State())
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package sysprog
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DecodeInstruction decodes a system program instruction to its param, e.g. TransferParam for a transfer instruction
+func DecodeInstruction(instruction types.Instruction) (interface{}, error) {
+	if instruction.ProgramID != common.SystemProgramID {
+		return nil, types.ErrProgramIDMismatch
+	}
+	if len(instruction.Data) < 4 {
+		return nil, fmt.Errorf("%w, data size is not enough for instruction type", types.ErrInvalidInstructionData)
+	}
+	accounts := instruction.Accounts
+	data := instruction.Data[4:]
+
+	switch Instruction(binary.LittleEndian.Uint32(instruction.Data[:4])) {
+	case InstructionCreateAccount:
+		if err := check(accounts, 2, data, 48); err != nil {
+			return nil, err
+		}
+		return CreateAccountParam{
+			From:     accounts[0].PubKey,
+			New:      accounts[1].PubKey,
+			Lamports: binary.LittleEndian.Uint64(data[:8]),
+			Space:    binary.LittleEndian.Uint64(data[8:16]),
+			Owner:    common.PublicKeyFromBytes(data[16:48]),
+		}, nil
+
+	case InstructionAssign:
+		if err := check(accounts, 1, data, 32); err != nil {
+			return nil, err
+		}
+		return AssignParam{
+			From:  accounts[0].PubKey,
+			Owner: common.PublicKeyFromBytes(data[:32]),
+		}, nil
+
+	case InstructionTransfer:
+		if err := check(accounts, 2, data, 8); err != nil {
+			return nil, err
+		}
+		return TransferParam{
+			From:   accounts[0].PubKey,
+			To:     accounts[1].PubKey,
+			Amount: binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+
+	case InstructionCreateAccountWithSeed:
+		if err := check(accounts, 2, data, 32); err != nil {
+			return nil, err
+		}
+		seed, rest, err := decodeString(data[32:])
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 48 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return CreateAccountWithSeedParam{
+			From:     accounts[0].PubKey,
+			New:      accounts[1].PubKey,
+			Base:     common.PublicKeyFromBytes(data[:32]),
+			Owner:    common.PublicKeyFromBytes(rest[16:48]),
+			Seed:     seed,
+			Lamports: binary.LittleEndian.Uint64(rest[:8]),
+			Space:    binary.LittleEndian.Uint64(rest[8:16]),
+		}, nil
+
+	case InstructionAdvanceNonceAccount:
+		if err := check(accounts, 3, data, 0); err != nil {
+			return nil, err
+		}
+		return AdvanceNonceAccountParam{
+			Nonce: accounts[0].PubKey,
+			Auth:  accounts[2].PubKey,
+		}, nil
+
+	case InstructionWithdrawNonceAccount:
+		if err := check(accounts, 5, data, 8); err != nil {
+			return nil, err
+		}
+		return WithdrawNonceAccountParam{
+			Nonce:  accounts[0].PubKey,
+			Auth:   accounts[4].PubKey,
+			To:     accounts[1].PubKey,
+			Amount: binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+
+	case InstructionInitializeNonceAccount:
+		if err := check(accounts, 1, data, 32); err != nil {
+			return nil, err
+		}
+		return InitializeNonceAccountParam{
+			Nonce: accounts[0].PubKey,
+			Auth:  common.PublicKeyFromBytes(data[:32]),
+		}, nil
+
+	case InstructionAuthorizeNonceAccount:
+		if err := check(accounts, 2, data, 32); err != nil {
+			return nil, err
+		}
+		return AuthorizeNonceAccountParam{
+			Nonce:   accounts[0].PubKey,
+			Auth:    accounts[1].PubKey,
+			NewAuth: common.PublicKeyFromBytes(data[:32]),
+		}, nil
+
+	case InstructionAllocate:
+		if err := check(accounts, 1, data, 8); err != nil {
+			return nil, err
+		}
+		return AllocateParam{
+			Account: accounts[0].PubKey,
+			Space:   binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+
+	case InstructionAllocateWithSeed:
+		if err := check(accounts, 2, data, 32); err != nil {
+			return nil, err
+		}
+		seed, rest, err := decodeString(data[32:])
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 40 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return AllocateWithSeedParam{
+			Account: accounts[0].PubKey,
+			Base:    common.PublicKeyFromBytes(data[:32]),
+			Owner:   common.PublicKeyFromBytes(rest[8:40]),
+			Seed:    seed,
+			Space:   binary.LittleEndian.Uint64(rest[:8]),
+		}, nil
+
+	case InstructionAssignWithSeed:
+		if err := check(accounts, 2, data, 32); err != nil {
+			return nil, err
+		}
+		seed, rest, err := decodeString(data[32:])
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 32 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return AssignWithSeedParam{
+			Account: accounts[0].PubKey,
+			Owner:   common.PublicKeyFromBytes(rest[:32]),
+			Base:    common.PublicKeyFromBytes(data[:32]),
+			Seed:    seed,
+		}, nil
+
+	case InstructionTransferWithSeed:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		seed, rest, err := decodeString(data[8:])
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 32 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return TransferWithSeedParam{
+			From:   accounts[0].PubKey,
+			To:     accounts[2].PubKey,
+			Base:   accounts[1].PubKey,
+			Owner:  common.PublicKeyFromBytes(rest[:32]),
+			Seed:   seed,
+			Amount: binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+
+	case InstructionUpgradeNonceAccount:
+		if err := check(accounts, 1, data, 0); err != nil {
+			return nil, err
+		}
+		return UpgradeNonceAccountParam{
+			NonceAccountPubkey: accounts[0].PubKey,
+		}, nil
+	}
+
+	return nil, types.ErrUnknownInstruction
+}
+
+func check(accounts []types.AccountMeta, accountsLen int, data []byte, dataLen int) error {
+	if len(accounts) < accountsLen {
+		return fmt.Errorf("%w, expected %v, got %v", types.ErrNotEnoughAccounts, accountsLen, len(accounts))
+	}
+	if len(data) < dataLen {
+		return fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+	}
+	return nil
+}
+
+// decodeString decodes a bincode string and returns the remaining data
+func decodeString(data []byte) (string, []byte, error) {
+	if len(data) < 8 {
+		return "", nil, fmt.Errorf("%w, data size is not enough for string length", types.ErrInvalidInstructionData)
+	}
+	l := binary.LittleEndian.Uint64(data[:8])
+	if uint64(len(data)-8) < l {
+		return "", nil, fmt.Errorf("%w, data size is not enough for string", types.ErrInvalidInstructionData)
+	}
+	return string(data[8 : 8+l]), data[8+l:], nil
+}
@@ -0,0 +1,121 @@
+package sysprog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstruction(t *testing.T) {
+	from := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	base := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+		err         error
+	}{
+		{
+			name:        "create account",
+			instruction: CreateAccount(CreateAccountParam{From: from, New: to, Owner: common.StakeProgramID, Lamports: 1, Space: 200}),
+			want:        CreateAccountParam{From: from, New: to, Owner: common.StakeProgramID, Lamports: 1, Space: 200},
+		},
+		{
+			name:        "assign",
+			instruction: Assign(AssignParam{From: from, Owner: common.StakeProgramID}),
+			want:        AssignParam{From: from, Owner: common.StakeProgramID},
+		},
+		{
+			name:        "transfer",
+			instruction: Transfer(TransferParam{From: from, To: to, Amount: 1000000000}),
+			want:        TransferParam{From: from, To: to, Amount: 1000000000},
+		},
+		{
+			name:        "create account with seed",
+			instruction: CreateAccountWithSeed(CreateAccountWithSeedParam{From: from, New: to, Base: base, Owner: common.StakeProgramID, Seed: "0", Lamports: 1, Space: 200}),
+			want:        CreateAccountWithSeedParam{From: from, New: to, Base: base, Owner: common.StakeProgramID, Seed: "0", Lamports: 1, Space: 200},
+		},
+		{
+			name:        "advance nonce account",
+			instruction: AdvanceNonceAccount(AdvanceNonceAccountParam{Nonce: to, Auth: from}),
+			want:        AdvanceNonceAccountParam{Nonce: to, Auth: from},
+		},
+		{
+			name:        "withdraw nonce account",
+			instruction: WithdrawNonceAccount(WithdrawNonceAccountParam{Nonce: to, Auth: from, To: base, Amount: 10}),
+			want:        WithdrawNonceAccountParam{Nonce: to, Auth: from, To: base, Amount: 10},
+		},
+		{
+			name:        "initialize nonce account",
+			instruction: InitializeNonceAccount(InitializeNonceAccountParam{Nonce: to, Auth: from}),
+			want:        InitializeNonceAccountParam{Nonce: to, Auth: from},
+		},
+		{
+			name:        "authorize nonce account",
+			instruction: AuthorizeNonceAccount(AuthorizeNonceAccountParam{Nonce: to, Auth: from, NewAuth: base}),
+			want:        AuthorizeNonceAccountParam{Nonce: to, Auth: from, NewAuth: base},
+		},
+		{
+			name:        "allocate",
+			instruction: Allocate(AllocateParam{Account: from, Space: 100}),
+			want:        AllocateParam{Account: from, Space: 100},
+		},
+		{
+			name:        "allocate with seed",
+			instruction: AllocateWithSeed(AllocateWithSeedParam{Account: to, Base: base, Owner: common.StakeProgramID, Seed: "seed", Space: 100}),
+			want:        AllocateWithSeedParam{Account: to, Base: base, Owner: common.StakeProgramID, Seed: "seed", Space: 100},
+		},
+		{
+			name:        "assign with seed",
+			instruction: AssignWithSeed(AssignWithSeedParam{Account: to, Owner: common.StakeProgramID, Base: base, Seed: "seed"}),
+			want:        AssignWithSeedParam{Account: to, Owner: common.StakeProgramID, Base: base, Seed: "seed"},
+		},
+		{
+			name:        "transfer with seed",
+			instruction: TransferWithSeed(TransferWithSeedParam{From: from, To: to, Base: base, Owner: common.StakeProgramID, Seed: "seed", Amount: 1}),
+			want:        TransferWithSeedParam{From: from, To: to, Base: base, Owner: common.StakeProgramID, Seed: "seed", Amount: 1},
+		},
+		{
+			name:        "upgrade nonce account",
+			instruction: UpgradeNonceAccount(UpgradeNonceAccountParam{NonceAccountPubkey: to}),
+			want:        UpgradeNonceAccountParam{NonceAccountPubkey: to},
+		},
+		{
+			name:        "program id mismatch",
+			instruction: types.Instruction{ProgramID: common.TokenProgramID, Data: []byte{2, 0, 0, 0}},
+			err:         types.ErrProgramIDMismatch,
+		},
+		{
+			name:        "unknown instruction",
+			instruction: types.Instruction{ProgramID: common.SystemProgramID, Data: []byte{255, 0, 0, 0}},
+			err:         types.ErrUnknownInstruction,
+		},
+		{
+			name:        "invalid data",
+			instruction: types.Instruction{ProgramID: common.SystemProgramID, Accounts: make([]types.AccountMeta, 2), Data: []byte{2, 0, 0, 0, 1}},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "invalid seed",
+			instruction: types.Instruction{ProgramID: common.SystemProgramID, Accounts: make([]types.AccountMeta, 2), Data: append([]byte{9, 0, 0, 0}, append(make([]byte, 32), 255, 0, 0, 0, 0, 0, 0, 0)...)},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "not enough accounts",
+			instruction: types.Instruction{ProgramID: common.SystemProgramID, Accounts: make([]types.AccountMeta, 1), Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}},
+			err:         types.ErrNotEnoughAccounts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.True(t, errors.Is(err, tt.err), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
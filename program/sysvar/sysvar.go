@@ -0,0 +1,341 @@
+package sysvar
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/mr-tron/base58"
+)
+
+var ErrInvalidAccountDataSize = errors.New("invalid account data size")
+
+type Clock struct {
+	Slot                uint64
+	EpochStartTimestamp int64
+	Epoch               uint64
+	LeaderScheduleEpoch uint64
+	UnixTimestamp       int64
+}
+
+func ClockDeserialize(data []byte) (Clock, error) {
+	if len(data) < 40 {
+		return Clock{}, ErrInvalidAccountDataSize
+	}
+	return Clock{
+		Slot:                binary.LittleEndian.Uint64(data[:8]),
+		EpochStartTimestamp: int64(binary.LittleEndian.Uint64(data[8:16])),
+		Epoch:               binary.LittleEndian.Uint64(data[16:24]),
+		LeaderScheduleEpoch: binary.LittleEndian.Uint64(data[24:32]),
+		UnixTimestamp:       int64(binary.LittleEndian.Uint64(data[32:40])),
+	}, nil
+}
+
+// AccountStorageOverhead is the bytes added to the data length of an account for rent
+const AccountStorageOverhead uint64 = 128
+
+type Rent struct {
+	LamportsPerByteYear uint64
+	ExemptionThreshold  float64
+	BurnPercent         uint8
+}
+
+// DefaultRent is the rent of the clusters
+var DefaultRent = Rent{
+	LamportsPerByteYear: 3480,
+	ExemptionThreshold:  2.0,
+	BurnPercent:         50,
+}
+
+func RentDeserialize(data []byte) (Rent, error) {
+	if len(data) < 17 {
+		return Rent{}, ErrInvalidAccountDataSize
+	}
+	return Rent{
+		LamportsPerByteYear: binary.LittleEndian.Uint64(data[:8]),
+		ExemptionThreshold:  math.Float64frombits(binary.LittleEndian.Uint64(data[8:16])),
+		BurnPercent:         data[16],
+	}, nil
+}
+
+// MinimumBalance returns the minimum balance for an account with the data length to be rent exempt.
+// it is the same as getMinimumBalanceForRentExemption.
+func (r Rent) MinimumBalance(dataLen uint64) uint64 {
+	return uint64(float64((AccountStorageOverhead+dataLen)*r.LamportsPerByteYear) * r.ExemptionThreshold)
+}
+
+// IsExempt reports whether the balance is enough for an account with the data length to be rent exempt
+func (r Rent) IsExempt(balance, dataLen uint64) bool {
+	return balance >= r.MinimumBalance(dataLen)
+}
+
+// MinimumSlotsPerEpoch is the slots of the first epoch when warmup is enabled
+const MinimumSlotsPerEpoch uint64 = 32
+
+type EpochSchedule struct {
+	SlotsPerEpoch            uint64
+	LeaderScheduleSlotOffset uint64
+	Warmup                   bool
+	FirstNormalEpoch         uint64
+	FirstNormalSlot          uint64
+}
+
+func EpochScheduleDeserialize(data []byte) (EpochSchedule, error) {
+	if len(data) < 33 {
+		return EpochSchedule{}, ErrInvalidAccountDataSize
+	}
+	return EpochSchedule{
+		SlotsPerEpoch:            binary.LittleEndian.Uint64(data[:8]),
+		LeaderScheduleSlotOffset: binary.LittleEndian.Uint64(data[8:16]),
+		Warmup:                   data[16] != 0,
+		FirstNormalEpoch:         binary.LittleEndian.Uint64(data[17:25]),
+		FirstNormalSlot:          binary.LittleEndian.Uint64(data[25:33]),
+	}, nil
+}
+
+// GetSlotsInEpoch returns the number of slots in the epoch
+func (s EpochSchedule) GetSlotsInEpoch(epoch uint64) uint64 {
+	if epoch < s.FirstNormalEpoch {
+		return 1 << (epoch + uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch)))
+	}
+	return s.SlotsPerEpoch
+}
+
+// GetEpochAndSlotIndex returns the epoch of the slot and the index of the slot in the epoch
+func (s EpochSchedule) GetEpochAndSlotIndex(slot uint64) (uint64, uint64) {
+	if slot < s.FirstNormalSlot {
+		// epochs before the first normal one are doubled from MinimumSlotsPerEpoch
+		epoch := uint64(bits.Len64(slot+MinimumSlotsPerEpoch)) - uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch)) - 1
+		epochLen := uint64(1) << (epoch + uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch)))
+		return epoch, slot - (epochLen - MinimumSlotsPerEpoch)
+	}
+	normalSlotIndex := slot - s.FirstNormalSlot
+	return s.FirstNormalEpoch + normalSlotIndex/s.SlotsPerEpoch, normalSlotIndex % s.SlotsPerEpoch
+}
+
+// GetEpoch returns the epoch of the slot
+func (s EpochSchedule) GetEpoch(slot uint64) uint64 {
+	epoch, _ := s.GetEpochAndSlotIndex(slot)
+	return epoch
+}
+
+// GetFirstSlotInEpoch returns the first slot of the epoch
+func (s EpochSchedule) GetFirstSlotInEpoch(epoch uint64) uint64 {
+	if epoch <= s.FirstNormalEpoch {
+		return ((uint64(1) << epoch) - 1) * MinimumSlotsPerEpoch
+	}
+	return (epoch-s.FirstNormalEpoch)*s.SlotsPerEpoch + s.FirstNormalSlot
+}
+
+// GetLastSlotInEpoch returns the last slot of the epoch
+func (s EpochSchedule) GetLastSlotInEpoch(epoch uint64) uint64 {
+	return s.GetFirstSlotInEpoch(epoch) + s.GetSlotsInEpoch(epoch) - 1
+}
+
+// Fees is deprecated on the clusters, use getFeeForMessage instead
+type Fees struct {
+	LamportsPerSignature uint64
+}
+
+func FeesDeserialize(data []byte) (Fees, error) {
+	if len(data) < 8 {
+		return Fees{}, ErrInvalidAccountDataSize
+	}
+	return Fees{
+		LamportsPerSignature: binary.LittleEndian.Uint64(data[:8]),
+	}, nil
+}
+
+type RecentBlockhashesEntry struct {
+	Blockhash            string
+	LamportsPerSignature uint64
+}
+
+// RecentBlockhashes is deprecated on the clusters, entries are sorted from the newest one
+type RecentBlockhashes []RecentBlockhashesEntry
+
+func RecentBlockhashesDeserialize(data []byte) (RecentBlockhashes, error) {
+	n, data, err := length(data, 40)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(RecentBlockhashes, 0, n)
+	for i := uint64(0); i < n; i++ {
+		entries = append(entries, RecentBlockhashesEntry{
+			Blockhash:            base58.Encode(data[:32]),
+			LamportsPerSignature: binary.LittleEndian.Uint64(data[32:40]),
+		})
+		data = data[40:]
+	}
+	return entries, nil
+}
+
+type SlotHash struct {
+	Slot uint64
+	Hash string
+}
+
+// SlotHashes are the bank hashes of the recent slots, sorted from the newest slot
+type SlotHashes []SlotHash
+
+func SlotHashesDeserialize(data []byte) (SlotHashes, error) {
+	n, data, err := length(data, 40)
+	if err != nil {
+		return nil, err
+	}
+	slotHashes := make(SlotHashes, 0, n)
+	for i := uint64(0); i < n; i++ {
+		slotHashes = append(slotHashes, SlotHash{
+			Slot: binary.LittleEndian.Uint64(data[:8]),
+			Hash: base58.Encode(data[8:40]),
+		})
+		data = data[40:]
+	}
+	return slotHashes, nil
+}
+
+// Get returns the hash of the slot
+func (s SlotHashes) Get(slot uint64) (string, bool) {
+	for _, slotHash := range s {
+		if slotHash.Slot == slot {
+			return slotHash.Hash, true
+		}
+	}
+	return "", false
+}
+
+// SlotHistoryMaxEntries is the number of slots tracked by the slot history
+const SlotHistoryMaxEntries uint64 = 1024 * 1024
+
+type SlotHistoryCheck int
+
+const (
+	SlotHistoryCheckFuture SlotHistoryCheck = iota
+	SlotHistoryCheckTooOld
+	SlotHistoryCheckFound
+	SlotHistoryCheckNotFound
+)
+
+// SlotHistory is a bitvector of the recent slots, a bit is set if the slot is in the ledger
+type SlotHistory struct {
+	Bits     []uint64
+	BitsLen  uint64
+	NextSlot uint64
+}
+
+func SlotHistoryDeserialize(data []byte) (SlotHistory, error) {
+	if len(data) < 1 {
+		return SlotHistory{}, ErrInvalidAccountDataSize
+	}
+	var history SlotHistory
+	hasBits := data[0]
+	data = data[1:]
+	switch hasBits {
+	case 0:
+	case 1:
+		n, rest, err := length(data, 8)
+		if err != nil {
+			return SlotHistory{}, err
+		}
+		history.Bits = make([]uint64, 0, n)
+		for i := uint64(0); i < n; i++ {
+			history.Bits = append(history.Bits, binary.LittleEndian.Uint64(rest[:8]))
+			rest = rest[8:]
+		}
+		data = rest
+	default:
+		return SlotHistory{}, errors.New("invalid bits option")
+	}
+	if len(data) < 16 {
+		return SlotHistory{}, ErrInvalidAccountDataSize
+	}
+	history.BitsLen = binary.LittleEndian.Uint64(data[:8])
+	history.NextSlot = binary.LittleEndian.Uint64(data[8:16])
+	return history, nil
+}
+
+// Newest returns the newest slot in the history
+func (h SlotHistory) Newest() uint64 {
+	return h.NextSlot - 1
+}
+
+// Oldest returns the oldest slot the history can tell
+func (h SlotHistory) Oldest() uint64 {
+	if h.NextSlot < SlotHistoryMaxEntries {
+		return 0
+	}
+	return h.NextSlot - SlotHistoryMaxEntries
+}
+
+// Check reports whether the slot is in the ledger
+func (h SlotHistory) Check(slot uint64) SlotHistoryCheck {
+	switch {
+	case slot > h.Newest():
+		return SlotHistoryCheckFuture
+	case slot < h.Oldest():
+		return SlotHistoryCheckTooOld
+	}
+	i := slot % SlotHistoryMaxEntries
+	if i < h.BitsLen && i/64 < uint64(len(h.Bits)) && h.Bits[i/64]&(1<<(i%64)) != 0 {
+		return SlotHistoryCheckFound
+	}
+	return SlotHistoryCheckNotFound
+}
+
+// EpochRewards tracks the partitioned distribution of the epoch rewards
+type EpochRewards struct {
+	DistributionStartingBlockHeight uint64
+	NumPartitions                   uint64
+	ParentBlockhash                 string
+	TotalPoints                     *big.Int
+	TotalRewards                    uint64
+	DistributedRewards              uint64
+	Active                          bool
+}
+
+func EpochRewardsDeserialize(data []byte) (EpochRewards, error) {
+	if len(data) < 81 {
+		return EpochRewards{}, ErrInvalidAccountDataSize
+	}
+	// total points is a little endian u128
+	totalPoints := new(big.Int).SetUint64(binary.LittleEndian.Uint64(data[56:64]))
+	totalPoints.Lsh(totalPoints, 64)
+	totalPoints.Or(totalPoints, new(big.Int).SetUint64(binary.LittleEndian.Uint64(data[48:56])))
+	return EpochRewards{
+		DistributionStartingBlockHeight: binary.LittleEndian.Uint64(data[:8]),
+		NumPartitions:                   binary.LittleEndian.Uint64(data[8:16]),
+		ParentBlockhash:                 base58.Encode(data[16:48]),
+		TotalPoints:                     totalPoints,
+		TotalRewards:                    binary.LittleEndian.Uint64(data[64:72]),
+		DistributedRewards:              binary.LittleEndian.Uint64(data[72:80]),
+		Active:                          data[80] != 0,
+	}, nil
+}
+
+type LastRestartSlot struct {
+	LastRestartSlot uint64
+}
+
+func LastRestartSlotDeserialize(data []byte) (LastRestartSlot, error) {
+	if len(data) < 8 {
+		return LastRestartSlot{}, ErrInvalidAccountDataSize
+	}
+	return LastRestartSlot{
+		LastRestartSlot: binary.LittleEndian.Uint64(data[:8]),
+	}, nil
+}
+
+// length reads a u64 length prefix and makes sure there is enough data for the items
+func length(data []byte, itemSize int) (uint64, []byte, error) {
+	if len(data) < 8 {
+		return 0, nil, ErrInvalidAccountDataSize
+	}
+	n := binary.LittleEndian.Uint64(data[:8])
+	data = data[8:]
+	if uint64(len(data)/itemSize) < n {
+		return 0, nil, ErrInvalidAccountDataSize
+	}
+	return n, data, nil
+}
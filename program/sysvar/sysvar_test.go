@@ -0,0 +1,174 @@
+package sysvar
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func u64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return b
+}
+
+func concat(bs ...[]byte) []byte {
+	var data []byte
+	for _, b := range bs {
+		data = append(data, b...)
+	}
+	return data
+}
+
+func TestClockDeserialize(t *testing.T) {
+	got, err := ClockDeserialize(concat(u64(100), u64(1700000000), u64(3), u64(4), u64(1700000400)))
+	assert.NoError(t, err)
+	assert.Equal(t, Clock{Slot: 100, EpochStartTimestamp: 1700000000, Epoch: 3, LeaderScheduleEpoch: 4, UnixTimestamp: 1700000400}, got)
+
+	_, err = ClockDeserialize(make([]byte, 39))
+	assert.Equal(t, ErrInvalidAccountDataSize, err)
+}
+
+func TestRent(t *testing.T) {
+	rent, err := RentDeserialize(concat(u64(3480), u64(math.Float64bits(2)), []byte{50}))
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultRent, rent)
+
+	tests := []struct {
+		dataLen uint64
+		want    uint64
+	}{
+		{dataLen: 0, want: 890880},
+		{dataLen: 165, want: 2039280},
+		{dataLen: 200, want: 2282880},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, rent.MinimumBalance(tt.dataLen))
+	}
+	assert.True(t, rent.IsExempt(2039280, 165))
+	assert.False(t, rent.IsExempt(2039279, 165))
+}
+
+func TestEpochSchedule(t *testing.T) {
+	schedule, err := EpochScheduleDeserialize(concat(u64(432000), u64(432000), []byte{1}, u64(14), u64(524256)))
+	assert.NoError(t, err)
+	assert.Equal(t, EpochSchedule{SlotsPerEpoch: 432000, LeaderScheduleSlotOffset: 432000, Warmup: true, FirstNormalEpoch: 14, FirstNormalSlot: 524256}, schedule)
+
+	tests := []struct {
+		slot      uint64
+		wantEpoch uint64
+		wantIndex uint64
+	}{
+		{slot: 0, wantEpoch: 0, wantIndex: 0},
+		{slot: 31, wantEpoch: 0, wantIndex: 31},
+		{slot: 32, wantEpoch: 1, wantIndex: 0},
+		{slot: 95, wantEpoch: 1, wantIndex: 63},
+		{slot: 96, wantEpoch: 2, wantIndex: 0},
+		{slot: 524255, wantEpoch: 13, wantIndex: 262143},
+		{slot: 524256, wantEpoch: 14, wantIndex: 0},
+		{slot: 524256 + 432000*2 + 5, wantEpoch: 16, wantIndex: 5},
+	}
+	for _, tt := range tests {
+		epoch, index := schedule.GetEpochAndSlotIndex(tt.slot)
+		assert.Equal(t, tt.wantEpoch, epoch, tt.slot)
+		assert.Equal(t, tt.wantIndex, index, tt.slot)
+		assert.Equal(t, tt.slot-tt.wantIndex, schedule.GetFirstSlotInEpoch(tt.wantEpoch), tt.slot)
+	}
+	assert.Equal(t, uint64(64), schedule.GetSlotsInEpoch(1))
+	assert.Equal(t, uint64(432000), schedule.GetSlotsInEpoch(14))
+	assert.Equal(t, uint64(524255), schedule.GetLastSlotInEpoch(13))
+
+	noWarmup := EpochSchedule{SlotsPerEpoch: 100, LeaderScheduleSlotOffset: 100}
+	epoch, index := noWarmup.GetEpochAndSlotIndex(250)
+	assert.Equal(t, uint64(2), epoch)
+	assert.Equal(t, uint64(50), index)
+	assert.Equal(t, uint64(200), noWarmup.GetFirstSlotInEpoch(2))
+}
+
+func TestSlotHashesDeserialize(t *testing.T) {
+	hash := make([]byte, 32)
+	hash[31] = 1
+	got, err := SlotHashesDeserialize(concat(u64(2), u64(11), hash, u64(10), make([]byte, 32)))
+	assert.NoError(t, err)
+	assert.Equal(t, SlotHashes{
+		{Slot: 11, Hash: "11111111111111111111111111111112"},
+		{Slot: 10, Hash: "11111111111111111111111111111111"},
+	}, got)
+
+	h, ok := got.Get(10)
+	assert.True(t, ok)
+	assert.Equal(t, "11111111111111111111111111111111", h)
+	_, ok = got.Get(12)
+	assert.False(t, ok)
+
+	_, err = SlotHashesDeserialize(concat(u64(2), u64(11), hash))
+	assert.Equal(t, ErrInvalidAccountDataSize, err)
+}
+
+func TestRecentBlockhashesDeserialize(t *testing.T) {
+	got, err := RecentBlockhashesDeserialize(concat(u64(1), make([]byte, 32), u64(5000)))
+	assert.NoError(t, err)
+	assert.Equal(t, RecentBlockhashes{{Blockhash: "11111111111111111111111111111111", LamportsPerSignature: 5000}}, got)
+}
+
+func TestSlotHistory(t *testing.T) {
+	bits := make([]uint64, SlotHistoryMaxEntries/64)
+	// slot 1 and 65 are in the ledger
+	bits[0] = 1 << 1
+	bits[1] = 1 << 1
+	data := []byte{1}
+	data = append(data, u64(uint64(len(bits)))...)
+	for _, b := range bits {
+		data = append(data, u64(b)...)
+	}
+	data = append(data, concat(u64(SlotHistoryMaxEntries), u64(SlotHistoryMaxEntries+2))...)
+
+	history, err := SlotHistoryDeserialize(data)
+	assert.NoError(t, err)
+	assert.Equal(t, SlotHistoryMaxEntries+2, history.NextSlot)
+	assert.Equal(t, uint64(2), history.Oldest())
+	assert.Equal(t, SlotHistoryMaxEntries+1, history.Newest())
+
+	tests := []struct {
+		slot uint64
+		want SlotHistoryCheck
+	}{
+		{slot: 1, want: SlotHistoryCheckTooOld},
+		{slot: 65, want: SlotHistoryCheckFound},
+		{slot: 66, want: SlotHistoryCheckNotFound},
+		{slot: SlotHistoryMaxEntries + 1, want: SlotHistoryCheckFound},
+		{slot: SlotHistoryMaxEntries + 2, want: SlotHistoryCheckFuture},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, history.Check(tt.slot), tt.slot)
+	}
+
+	_, err = SlotHistoryDeserialize(data[:len(data)-1])
+	assert.Equal(t, ErrInvalidAccountDataSize, err)
+}
+
+func TestEpochRewardsDeserialize(t *testing.T) {
+	data := concat(u64(1000), u64(4), make([]byte, 32), u64(5), u64(1), u64(300), u64(100), []byte{1})
+	got, err := EpochRewardsDeserialize(data)
+	assert.NoError(t, err)
+	totalPoints, _ := new(big.Int).SetString("18446744073709551621", 10)
+	assert.Equal(t, 0, totalPoints.Cmp(got.TotalPoints))
+	got.TotalPoints = nil
+	assert.Equal(t, EpochRewards{
+		DistributionStartingBlockHeight: 1000,
+		NumPartitions:                   4,
+		ParentBlockhash:                 "11111111111111111111111111111111",
+		TotalRewards:                    300,
+		DistributedRewards:              100,
+		Active:                          true,
+	}, got)
+}
+
+func TestLastRestartSlotDeserialize(t *testing.T) {
+	got, err := LastRestartSlotDeserialize(u64(42))
+	assert.NoError(t, err)
+	assert.Equal(t, LastRestartSlot{LastRestartSlot: 42}, got)
+}
@@ -0,0 +1,350 @@
+package tokenprog
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DecodeInstruction decodes a token program or token-2022 program instruction to its param, e.g. TransferCheckedParam for a transfer checked instruction.
+// signers of a multisig authority are decoded into Signers.
+func DecodeInstruction(instruction types.Instruction) (interface{}, error) {
+	if instruction.ProgramID != common.TokenProgramID && instruction.ProgramID != common.Token2022ProgramID {
+		return nil, types.ErrProgramIDMismatch
+	}
+	// keep ProgramID of params empty for the token program, the same as what builders expect
+	var pid common.PublicKey
+	if instruction.ProgramID == common.Token2022ProgramID {
+		pid = common.Token2022ProgramID
+	}
+	if len(instruction.Data) < 1 {
+		return nil, fmt.Errorf("%w, data size is not enough for instruction type", types.ErrInvalidInstructionData)
+	}
+	accounts := instruction.Accounts
+	data := instruction.Data[1:]
+
+	switch Instruction(instruction.Data[0]) {
+	case InstructionInitializeMint:
+		if err := check(accounts, 1, data, 33); err != nil {
+			return nil, err
+		}
+		freezeAuth, err := decodeOptionalPublicKey(data[33:])
+		if err != nil {
+			return nil, err
+		}
+		return InitializeMintParam{
+			Decimals:   data[0],
+			Mint:       accounts[0].PubKey,
+			MintAuth:   common.PublicKeyFromBytes(data[1:33]),
+			FreezeAuth: freezeAuth,
+			ProgramID:  pid,
+		}, nil
+
+	case InstructionInitializeAccount:
+		if err := check(accounts, 3, data, 0); err != nil {
+			return nil, err
+		}
+		return InitializeAccountParam{
+			Account:   accounts[0].PubKey,
+			Mint:      accounts[1].PubKey,
+			Owner:     accounts[2].PubKey,
+			ProgramID: pid,
+		}, nil
+
+	case InstructionInitializeMultisig:
+		if err := check(accounts, 2, data, 1); err != nil {
+			return nil, err
+		}
+		return InitializeMultisigParam{
+			Account:     accounts[0].PubKey,
+			Signers:     pubkeysFrom(accounts, 2),
+			MinRequired: data[0],
+			ProgramID:   pid,
+		}, nil
+
+	case InstructionTransfer:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		return TransferParam{
+			From:      accounts[0].PubKey,
+			To:        accounts[1].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			Amount:    binary.LittleEndian.Uint64(data[:8]),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionApprove:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		return ApproveParam{
+			From:      accounts[0].PubKey,
+			To:        accounts[1].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			Amount:    binary.LittleEndian.Uint64(data[:8]),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionRevoke:
+		if err := check(accounts, 2, data, 0); err != nil {
+			return nil, err
+		}
+		return RevokeParam{
+			From:      accounts[0].PubKey,
+			Auth:      accounts[1].PubKey,
+			Signers:   pubkeysFrom(accounts, 2),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionSetAuthority:
+		if err := check(accounts, 2, data, 1); err != nil {
+			return nil, err
+		}
+		newAuth, err := decodeOptionalPublicKey(data[1:])
+		if err != nil {
+			return nil, err
+		}
+		return SetAuthorityParam{
+			Account:   accounts[0].PubKey,
+			NewAuth:   newAuth,
+			AuthType:  AuthorityType(data[0]),
+			Auth:      accounts[1].PubKey,
+			Signers:   pubkeysFrom(accounts, 2),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionMintTo:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		return MintToParam{
+			Mint:      accounts[0].PubKey,
+			To:        accounts[1].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			Amount:    binary.LittleEndian.Uint64(data[:8]),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionBurn:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		return BurnParam{
+			Account:   accounts[0].PubKey,
+			Mint:      accounts[1].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			Amount:    binary.LittleEndian.Uint64(data[:8]),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionCloseAccount:
+		if err := check(accounts, 3, data, 0); err != nil {
+			return nil, err
+		}
+		return CloseAccountParam{
+			Account:   accounts[0].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			To:        accounts[1].PubKey,
+			ProgramID: pid,
+		}, nil
+
+	case InstructionFreezeAccount:
+		if err := check(accounts, 3, data, 0); err != nil {
+			return nil, err
+		}
+		return FreezeAccountParam{
+			Account:   accounts[0].PubKey,
+			Mint:      accounts[1].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionThawAccount:
+		if err := check(accounts, 3, data, 0); err != nil {
+			return nil, err
+		}
+		return ThawAccountParam{
+			Account:   accounts[0].PubKey,
+			Mint:      accounts[1].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionTransferChecked:
+		if err := check(accounts, 4, data, 9); err != nil {
+			return nil, err
+		}
+		return TransferCheckedParam{
+			From:      accounts[0].PubKey,
+			To:        accounts[2].PubKey,
+			Mint:      accounts[1].PubKey,
+			Auth:      accounts[3].PubKey,
+			Signers:   pubkeysFrom(accounts, 4),
+			Amount:    binary.LittleEndian.Uint64(data[:8]),
+			Decimals:  data[8],
+			ProgramID: pid,
+		}, nil
+
+	case InstructionApproveChecked:
+		if err := check(accounts, 4, data, 9); err != nil {
+			return nil, err
+		}
+		return ApproveCheckedParam{
+			From:      accounts[0].PubKey,
+			Mint:      accounts[1].PubKey,
+			To:        accounts[2].PubKey,
+			Auth:      accounts[3].PubKey,
+			Signers:   pubkeysFrom(accounts, 4),
+			Amount:    binary.LittleEndian.Uint64(data[:8]),
+			Decimals:  data[8],
+			ProgramID: pid,
+		}, nil
+
+	case InstructionMintToChecked:
+		if err := check(accounts, 3, data, 9); err != nil {
+			return nil, err
+		}
+		return MintToCheckedParam{
+			Mint:      accounts[0].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			To:        accounts[1].PubKey,
+			Amount:    binary.LittleEndian.Uint64(data[:8]),
+			Decimals:  data[8],
+			ProgramID: pid,
+		}, nil
+
+	case InstructionBurnChecked:
+		if err := check(accounts, 3, data, 9); err != nil {
+			return nil, err
+		}
+		return BurnCheckedParam{
+			Account:   accounts[0].PubKey,
+			Auth:      accounts[2].PubKey,
+			Signers:   pubkeysFrom(accounts, 3),
+			Mint:      accounts[1].PubKey,
+			Amount:    binary.LittleEndian.Uint64(data[:8]),
+			Decimals:  data[8],
+			ProgramID: pid,
+		}, nil
+
+	case InstructionInitializeAccount2:
+		if err := check(accounts, 2, data, 32); err != nil {
+			return nil, err
+		}
+		return InitializeAccount2Param{
+			Account:   accounts[0].PubKey,
+			Mint:      accounts[1].PubKey,
+			Owner:     common.PublicKeyFromBytes(data[:32]),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionSyncNative:
+		if err := check(accounts, 1, data, 0); err != nil {
+			return nil, err
+		}
+		return SyncNativeParam{
+			Account:   accounts[0].PubKey,
+			ProgramID: pid,
+		}, nil
+
+	case InstructionInitializeAccount3:
+		if err := check(accounts, 2, data, 32); err != nil {
+			return nil, err
+		}
+		return InitializeAccount3Param{
+			Account:   accounts[0].PubKey,
+			Mint:      accounts[1].PubKey,
+			Owner:     common.PublicKeyFromBytes(data[:32]),
+			ProgramID: pid,
+		}, nil
+
+	case InstructionInitializeMultisig2:
+		if err := check(accounts, 1, data, 1); err != nil {
+			return nil, err
+		}
+		return InitializeMultisig2Param{
+			Account:     accounts[0].PubKey,
+			Signers:     pubkeysFrom(accounts, 1),
+			MinRequired: data[0],
+			ProgramID:   pid,
+		}, nil
+
+	case InstructionInitializeMint2:
+		if err := check(accounts, 1, data, 33); err != nil {
+			return nil, err
+		}
+		freezeAuth, err := decodeOptionalPublicKey(data[33:])
+		if err != nil {
+			return nil, err
+		}
+		return InitializeMint2Param{
+			Decimals:   data[0],
+			Mint:       accounts[0].PubKey,
+			MintAuth:   common.PublicKeyFromBytes(data[1:33]),
+			FreezeAuth: freezeAuth,
+			ProgramID:  pid,
+		}, nil
+	}
+
+	if instruction.ProgramID == common.Token2022ProgramID {
+		return decodeExtensionInstruction(instruction)
+	}
+	return nil, types.ErrUnknownInstruction
+}
+
+func check(accounts []types.AccountMeta, accountsLen int, data []byte, dataLen int) error {
+	if len(accounts) < accountsLen {
+		return fmt.Errorf("%w, expected %v, got %v", types.ErrNotEnoughAccounts, accountsLen, len(accounts))
+	}
+	if len(data) < dataLen {
+		return fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+	}
+	return nil
+}
+
+// pubkeysFrom returns pubkeys of accounts[i:], e.g. signers of a multisig authority
+func pubkeysFrom(accounts []types.AccountMeta, i int) []common.PublicKey {
+	if i >= len(accounts) {
+		return nil
+	}
+	pubkeys := make([]common.PublicKey, 0, len(accounts)-i)
+	for _, account := range accounts[i:] {
+		pubkeys = append(pubkeys, account.PubKey)
+	}
+	return pubkeys
+}
+
+// decodeOptionalPublicKey decodes a COption<Pubkey>, the pubkey may be omitted for none
+func decodeOptionalPublicKey(data []byte) (*common.PublicKey, error) {
+	pubkey, _, err := splitOptionalPublicKey(data)
+	return pubkey, err
+}
+
+// splitOptionalPublicKey decodes a COption<Pubkey> and returns the remaining data
+func splitOptionalPublicKey(data []byte) (*common.PublicKey, []byte, error) {
+	if len(data) < 1 {
+		return nil, nil, fmt.Errorf("%w, data size is not enough for option", types.ErrInvalidInstructionData)
+	}
+	switch data[0] {
+	case 0:
+		return nil, data[1:], nil
+	case 1:
+		if len(data) < 33 {
+			return nil, nil, fmt.Errorf("%w, data size is not enough for pubkey", types.ErrInvalidInstructionData)
+		}
+		pubkey := common.PublicKeyFromBytes(data[1:33])
+		return &pubkey, data[33:], nil
+	}
+	return nil, nil, fmt.Errorf("%w, invalid option", types.ErrInvalidInstructionData)
+}
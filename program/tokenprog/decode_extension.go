@@ -0,0 +1,307 @@
+package tokenprog
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// decodeExtensionInstruction decodes token-2022 only instructions which have a builder in this package
+func decodeExtensionInstruction(instruction types.Instruction) (interface{}, error) {
+	accounts := instruction.Accounts
+	data := instruction.Data[1:]
+
+	switch Instruction(instruction.Data[0]) {
+	case InstructionInitializeMintCloseAuthority:
+		if err := check(accounts, 1, data, 0); err != nil {
+			return nil, err
+		}
+		closeAuth, err := decodeOptionalPublicKey(data)
+		if err != nil {
+			return nil, err
+		}
+		return InitializeMintCloseAuthorityParam{
+			Mint:      accounts[0].PubKey,
+			CloseAuth: closeAuth,
+		}, nil
+
+	case InstructionTransferFeeExtension:
+		return decodeTransferFeeInstruction(accounts, data)
+
+	case InstructionConfidentialTransferExtension:
+		if err := check(accounts, 1, data, 66); err != nil {
+			return nil, err
+		}
+		if ConfidentialTransferInstruction(data[0]) != ConfidentialTransferInstructionInitializeMint {
+			return nil, types.ErrUnknownInstruction
+		}
+		var auditorElGamalPubkey *[32]byte
+		if (common.PublicKeyFromBytes(data[34:66]) != common.PublicKey{}) {
+			var pubkey [32]byte
+			copy(pubkey[:], data[34:66])
+			auditorElGamalPubkey = &pubkey
+		}
+		return InitializeConfidentialTransferMintParam{
+			Mint:                   accounts[0].PubKey,
+			Auth:                   optionalNonZeroPublicKeyFromData(data[1:33]),
+			AutoApproveNewAccounts: data[33] == 1,
+			AuditorElGamalPubkey:   auditorElGamalPubkey,
+		}, nil
+
+	case InstructionDefaultAccountStateExtension:
+		if err := check(accounts, 1, data, 2); err != nil {
+			return nil, err
+		}
+		switch DefaultAccountStateInstruction(data[0]) {
+		case DefaultAccountStateInstructionInitialize:
+			return InitializeDefaultAccountStateParam{
+				Mint:  accounts[0].PubKey,
+				State: TokenAccountState(data[1]),
+			}, nil
+		case DefaultAccountStateInstructionUpdate:
+			if err := check(accounts, 2, data, 2); err != nil {
+				return nil, err
+			}
+			return UpdateDefaultAccountStateParam{
+				Mint:       accounts[0].PubKey,
+				FreezeAuth: accounts[1].PubKey,
+				Signers:    pubkeysFrom(accounts, 2),
+				State:      TokenAccountState(data[1]),
+			}, nil
+		}
+
+	case InstructionMemoTransferExtension:
+		if err := check(accounts, 2, data, 1); err != nil {
+			return nil, err
+		}
+		switch MemoTransferInstruction(data[0]) {
+		case MemoTransferInstructionEnable:
+			return EnableRequiredMemoTransfersParam{
+				Account: accounts[0].PubKey,
+				Owner:   accounts[1].PubKey,
+				Signers: pubkeysFrom(accounts, 2),
+			}, nil
+		case MemoTransferInstructionDisable:
+			return DisableRequiredMemoTransfersParam{
+				Account: accounts[0].PubKey,
+				Owner:   accounts[1].PubKey,
+				Signers: pubkeysFrom(accounts, 2),
+			}, nil
+		}
+
+	case InstructionInitializeNonTransferableMint:
+		if err := check(accounts, 1, data, 0); err != nil {
+			return nil, err
+		}
+		return InitializeNonTransferableMintParam{
+			Mint: accounts[0].PubKey,
+		}, nil
+
+	case InstructionInterestBearingMintExtension:
+		if err := check(accounts, 1, data, 1); err != nil {
+			return nil, err
+		}
+		switch InterestBearingMintInstruction(data[0]) {
+		case InterestBearingMintInstructionInitialize:
+			if err := check(accounts, 1, data, 35); err != nil {
+				return nil, err
+			}
+			return InitializeInterestBearingMintParam{
+				Mint:     accounts[0].PubKey,
+				RateAuth: optionalNonZeroPublicKeyFromData(data[1:33]),
+				Rate:     int16(binary.LittleEndian.Uint16(data[33:35])),
+			}, nil
+		case InterestBearingMintInstructionUpdateRate:
+			if err := check(accounts, 2, data, 3); err != nil {
+				return nil, err
+			}
+			return UpdateInterestBearingMintRateParam{
+				Mint:     accounts[0].PubKey,
+				RateAuth: accounts[1].PubKey,
+				Signers:  pubkeysFrom(accounts, 2),
+				Rate:     int16(binary.LittleEndian.Uint16(data[1:3])),
+			}, nil
+		}
+
+	case InstructionCpiGuardExtension:
+		if err := check(accounts, 2, data, 1); err != nil {
+			return nil, err
+		}
+		switch CpiGuardInstruction(data[0]) {
+		case CpiGuardInstructionEnable:
+			return EnableCpiGuardParam{
+				Account: accounts[0].PubKey,
+				Owner:   accounts[1].PubKey,
+				Signers: pubkeysFrom(accounts, 2),
+			}, nil
+		case CpiGuardInstructionDisable:
+			return DisableCpiGuardParam{
+				Account: accounts[0].PubKey,
+				Owner:   accounts[1].PubKey,
+				Signers: pubkeysFrom(accounts, 2),
+			}, nil
+		}
+
+	case InstructionInitializePermanentDelegate:
+		if err := check(accounts, 1, data, 32); err != nil {
+			return nil, err
+		}
+		return InitializePermanentDelegateParam{
+			Mint:     accounts[0].PubKey,
+			Delegate: common.PublicKeyFromBytes(data[:32]),
+		}, nil
+
+	case InstructionTransferHookExtension:
+		if err := check(accounts, 1, data, 1); err != nil {
+			return nil, err
+		}
+		switch TransferHookInstruction(data[0]) {
+		case TransferHookInstructionInitialize:
+			if err := check(accounts, 1, data, 65); err != nil {
+				return nil, err
+			}
+			return InitializeTransferHookParam{
+				Mint:                  accounts[0].PubKey,
+				Auth:                  optionalNonZeroPublicKeyFromData(data[1:33]),
+				TransferHookProgramID: optionalNonZeroPublicKeyFromData(data[33:65]),
+			}, nil
+		case TransferHookInstructionUpdate:
+			if err := check(accounts, 2, data, 33); err != nil {
+				return nil, err
+			}
+			return UpdateTransferHookParam{
+				Mint:                  accounts[0].PubKey,
+				Auth:                  accounts[1].PubKey,
+				Signers:               pubkeysFrom(accounts, 2),
+				TransferHookProgramID: optionalNonZeroPublicKeyFromData(data[1:33]),
+			}, nil
+		}
+
+	case InstructionMetadataPointerExtension:
+		if err := check(accounts, 1, data, 1); err != nil {
+			return nil, err
+		}
+		switch MetadataPointerInstruction(data[0]) {
+		case MetadataPointerInstructionInitialize:
+			if err := check(accounts, 1, data, 65); err != nil {
+				return nil, err
+			}
+			return InitializeMetadataPointerParam{
+				Mint:            accounts[0].PubKey,
+				Auth:            optionalNonZeroPublicKeyFromData(data[1:33]),
+				MetadataAddress: optionalNonZeroPublicKeyFromData(data[33:65]),
+			}, nil
+		case MetadataPointerInstructionUpdate:
+			if err := check(accounts, 2, data, 33); err != nil {
+				return nil, err
+			}
+			return UpdateMetadataPointerParam{
+				Mint:            accounts[0].PubKey,
+				Auth:            accounts[1].PubKey,
+				Signers:         pubkeysFrom(accounts, 2),
+				MetadataAddress: optionalNonZeroPublicKeyFromData(data[1:33]),
+			}, nil
+		}
+	}
+
+	return nil, types.ErrUnknownInstruction
+}
+
+func decodeTransferFeeInstruction(accounts []types.AccountMeta, data []byte) (interface{}, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("%w, data size is not enough for transfer fee instruction type", types.ErrInvalidInstructionData)
+	}
+
+	switch TransferFeeInstruction(data[0]) {
+	case TransferFeeInstructionInitializeTransferFeeConfig:
+		if err := check(accounts, 1, data, 0); err != nil {
+			return nil, err
+		}
+		transferFeeConfigAuth, rest, err := splitOptionalPublicKey(data[1:])
+		if err != nil {
+			return nil, err
+		}
+		withdrawWithheldAuth, rest, err := splitOptionalPublicKey(rest)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 10 {
+			return nil, fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+		}
+		return InitializeTransferFeeConfigParam{
+			Mint:                   accounts[0].PubKey,
+			TransferFeeConfigAuth:  transferFeeConfigAuth,
+			WithdrawWithheldAuth:   withdrawWithheldAuth,
+			TransferFeeBasisPoints: binary.LittleEndian.Uint16(rest[:2]),
+			MaximumFee:             binary.LittleEndian.Uint64(rest[2:10]),
+		}, nil
+
+	case TransferFeeInstructionTransferCheckedWithFee:
+		if err := check(accounts, 4, data, 18); err != nil {
+			return nil, err
+		}
+		return TransferCheckedWithFeeParam{
+			From:     accounts[0].PubKey,
+			To:       accounts[2].PubKey,
+			Mint:     accounts[1].PubKey,
+			Auth:     accounts[3].PubKey,
+			Signers:  pubkeysFrom(accounts, 4),
+			Amount:   binary.LittleEndian.Uint64(data[1:9]),
+			Decimals: data[9],
+			Fee:      binary.LittleEndian.Uint64(data[10:18]),
+		}, nil
+
+	case TransferFeeInstructionWithdrawWithheldTokensFromMint:
+		if err := check(accounts, 3, data, 1); err != nil {
+			return nil, err
+		}
+		return WithdrawWithheldTokensFromMintParam{
+			Mint:    accounts[0].PubKey,
+			To:      accounts[1].PubKey,
+			Auth:    accounts[2].PubKey,
+			Signers: pubkeysFrom(accounts, 3),
+		}, nil
+
+	case TransferFeeInstructionWithdrawWithheldTokensFromAccounts:
+		if err := check(accounts, 3, data, 2); err != nil {
+			return nil, err
+		}
+		numTokenAccounts := int(data[1])
+		if err := check(accounts, 3+numTokenAccounts, data, 2); err != nil {
+			return nil, err
+		}
+		sourcesStart := len(accounts) - numTokenAccounts
+		return WithdrawWithheldTokensFromAccountsParam{
+			Mint:    accounts[0].PubKey,
+			To:      accounts[1].PubKey,
+			Auth:    accounts[2].PubKey,
+			Signers: pubkeysFrom(accounts[:sourcesStart], 3),
+			Sources: pubkeysFrom(accounts, sourcesStart),
+		}, nil
+
+	case TransferFeeInstructionHarvestWithheldTokensToMint:
+		if err := check(accounts, 1, data, 1); err != nil {
+			return nil, err
+		}
+		return HarvestWithheldTokensToMintParam{
+			Mint:    accounts[0].PubKey,
+			Sources: pubkeysFrom(accounts, 1),
+		}, nil
+
+	case TransferFeeInstructionSetTransferFee:
+		if err := check(accounts, 2, data, 11); err != nil {
+			return nil, err
+		}
+		return SetTransferFeeParam{
+			Mint:                   accounts[0].PubKey,
+			Auth:                   accounts[1].PubKey,
+			Signers:                pubkeysFrom(accounts, 2),
+			TransferFeeBasisPoints: binary.LittleEndian.Uint16(data[1:3]),
+			MaximumFee:             binary.LittleEndian.Uint64(data[3:11]),
+		}, nil
+	}
+
+	return nil, types.ErrUnknownInstruction
+}
@@ -0,0 +1,183 @@
+package tokenprog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstruction(t *testing.T) {
+	account := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	mint := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	auth := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+	to := common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde")
+	signer1 := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	signer2 := common.PublicKeyFromString("2xNweLHLqrbx4zo1waDvgWJHgsUpPj8Y8icbAFeR4a8i")
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+		err         error
+	}{
+		{
+			name:        "initialize mint",
+			instruction: InitializeMint(InitializeMintParam{Decimals: 9, Mint: mint, MintAuth: auth}),
+			want:        InitializeMintParam{Decimals: 9, Mint: mint, MintAuth: auth},
+		},
+		{
+			name:        "initialize mint with freeze authority",
+			instruction: InitializeMint(InitializeMintParam{Decimals: 9, Mint: mint, MintAuth: auth, FreezeAuth: &to}),
+			want:        InitializeMintParam{Decimals: 9, Mint: mint, MintAuth: auth, FreezeAuth: &to},
+		},
+		{
+			name:        "initialize account",
+			instruction: InitializeAccount(InitializeAccountParam{Account: account, Mint: mint, Owner: auth}),
+			want:        InitializeAccountParam{Account: account, Mint: mint, Owner: auth},
+		},
+		{
+			name:        "initialize multisig",
+			instruction: InitializeMultisig(InitializeMultisigParam{Account: account, Signers: []common.PublicKey{signer1, signer2}, MinRequired: 1}),
+			want:        InitializeMultisigParam{Account: account, Signers: []common.PublicKey{signer1, signer2}, MinRequired: 1},
+		},
+		{
+			name:        "transfer",
+			instruction: Transfer(TransferParam{From: account, To: to, Auth: auth, Amount: 1}),
+			want:        TransferParam{From: account, To: to, Auth: auth, Amount: 1},
+		},
+		{
+			name:        "transfer by multisig",
+			instruction: Transfer(TransferParam{From: account, To: to, Auth: auth, Signers: []common.PublicKey{signer1, signer2}, Amount: 1}),
+			want:        TransferParam{From: account, To: to, Auth: auth, Signers: []common.PublicKey{signer1, signer2}, Amount: 1},
+		},
+		{
+			name:        "approve",
+			instruction: Approve(ApproveParam{From: account, To: to, Auth: auth, Amount: 1}),
+			want:        ApproveParam{From: account, To: to, Auth: auth, Amount: 1},
+		},
+		{
+			name:        "revoke",
+			instruction: Revoke(RevokeParam{From: account, Auth: auth}),
+			want:        RevokeParam{From: account, Auth: auth},
+		},
+		{
+			name:        "set authority",
+			instruction: SetAuthority(SetAuthorityParam{Account: account, NewAuth: &to, AuthType: AuthorityTypeCloseAccount, Auth: auth}),
+			want:        SetAuthorityParam{Account: account, NewAuth: &to, AuthType: AuthorityTypeCloseAccount, Auth: auth},
+		},
+		{
+			name:        "set authority to none",
+			instruction: SetAuthority(SetAuthorityParam{Account: mint, AuthType: AuthorityTypeMintTokens, Auth: auth}),
+			want:        SetAuthorityParam{Account: mint, AuthType: AuthorityTypeMintTokens, Auth: auth},
+		},
+		{
+			name:        "mint to",
+			instruction: MintTo(MintToParam{Mint: mint, To: to, Auth: auth, Amount: 1}),
+			want:        MintToParam{Mint: mint, To: to, Auth: auth, Amount: 1},
+		},
+		{
+			name:        "burn",
+			instruction: Burn(BurnParam{Account: account, Mint: mint, Auth: auth, Amount: 1}),
+			want:        BurnParam{Account: account, Mint: mint, Auth: auth, Amount: 1},
+		},
+		{
+			name:        "close account",
+			instruction: CloseAccount(CloseAccountParam{Account: account, Auth: auth, To: to}),
+			want:        CloseAccountParam{Account: account, Auth: auth, To: to},
+		},
+		{
+			name:        "freeze account",
+			instruction: FreezeAccount(FreezeAccountParam{Account: account, Mint: mint, Auth: auth}),
+			want:        FreezeAccountParam{Account: account, Mint: mint, Auth: auth},
+		},
+		{
+			name:        "thaw account",
+			instruction: ThawAccount(ThawAccountParam{Account: account, Mint: mint, Auth: auth}),
+			want:        ThawAccountParam{Account: account, Mint: mint, Auth: auth},
+		},
+		{
+			name:        "transfer checked",
+			instruction: TransferChecked(TransferCheckedParam{From: account, To: to, Mint: mint, Auth: auth, Amount: 1, Decimals: 9}),
+			want:        TransferCheckedParam{From: account, To: to, Mint: mint, Auth: auth, Amount: 1, Decimals: 9},
+		},
+		{
+			name:        "approve checked",
+			instruction: ApproveChecked(ApproveCheckedParam{From: account, Mint: mint, To: to, Auth: auth, Amount: 1, Decimals: 9}),
+			want:        ApproveCheckedParam{From: account, Mint: mint, To: to, Auth: auth, Amount: 1, Decimals: 9},
+		},
+		{
+			name:        "mint to checked",
+			instruction: MintToChecked(MintToCheckedParam{Mint: mint, Auth: auth, To: to, Amount: 1, Decimals: 9}),
+			want:        MintToCheckedParam{Mint: mint, Auth: auth, To: to, Amount: 1, Decimals: 9},
+		},
+		{
+			name:        "burn checked",
+			instruction: BurnChecked(BurnCheckedParam{Account: account, Auth: auth, Mint: mint, Amount: 1, Decimals: 9}),
+			want:        BurnCheckedParam{Account: account, Auth: auth, Mint: mint, Amount: 1, Decimals: 9},
+		},
+		{
+			name:        "initialize account2",
+			instruction: InitializeAccount2(InitializeAccount2Param{Account: account, Mint: mint, Owner: auth}),
+			want:        InitializeAccount2Param{Account: account, Mint: mint, Owner: auth},
+		},
+		{
+			name:        "sync native",
+			instruction: SyncNative(SyncNativeParam{Account: account}),
+			want:        SyncNativeParam{Account: account},
+		},
+		{
+			name:        "initialize account3",
+			instruction: InitializeAccount3(InitializeAccount3Param{Account: account, Mint: mint, Owner: auth}),
+			want:        InitializeAccount3Param{Account: account, Mint: mint, Owner: auth},
+		},
+		{
+			name:        "initialize multisig2",
+			instruction: InitializeMultisig2(InitializeMultisig2Param{Account: account, Signers: []common.PublicKey{signer1}, MinRequired: 1}),
+			want:        InitializeMultisig2Param{Account: account, Signers: []common.PublicKey{signer1}, MinRequired: 1},
+		},
+		{
+			name:        "initialize mint2",
+			instruction: InitializeMint2(InitializeMint2Param{Decimals: 6, Mint: mint, MintAuth: auth, FreezeAuth: &to}),
+			want:        InitializeMint2Param{Decimals: 6, Mint: mint, MintAuth: auth, FreezeAuth: &to},
+		},
+		{
+			name: "set authority without padding",
+			instruction: types.Instruction{
+				ProgramID: common.TokenProgramID,
+				Accounts:  []types.AccountMeta{{PubKey: mint}, {PubKey: auth, IsSigner: true}},
+				Data:      []byte{6, 0, 0},
+			},
+			want: SetAuthorityParam{Account: mint, AuthType: AuthorityTypeMintTokens, Auth: auth},
+		},
+		{
+			name:        "program id mismatch",
+			instruction: types.Instruction{ProgramID: common.SystemProgramID, Data: []byte{3}},
+			err:         types.ErrProgramIDMismatch,
+		},
+		{
+			name:        "unknown instruction",
+			instruction: types.Instruction{ProgramID: common.TokenProgramID, Data: []byte{255}},
+			err:         types.ErrUnknownInstruction,
+		},
+		{
+			name:        "invalid data",
+			instruction: types.Instruction{ProgramID: common.TokenProgramID, Accounts: make([]types.AccountMeta, 4), Data: []byte{12, 1, 0, 0, 0, 0, 0, 0, 0}},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "not enough accounts",
+			instruction: types.Instruction{ProgramID: common.TokenProgramID, Accounts: make([]types.AccountMeta, 2), Data: []byte{3, 1, 0, 0, 0, 0, 0, 0, 0}},
+			err:         types.ErrNotEnoughAccounts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.True(t, errors.Is(err, tt.err), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,8 +1,84 @@
 package tokenprog
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/types"
+)
 
 var (
 	ErrInvalidAccountOwner    = errors.New("invalid account owner")
 	ErrInvalidAccountDataSize = errors.New("invalid account data size")
+	ErrInvalidAccountType     = errors.New("invalid account type")
+)
+
+// Error is a custom program error of the token program
+type Error uint32
+
+const (
+	ErrorNotRentExempt Error = iota
+	ErrorInsufficientFunds
+	ErrorInvalidMint
+	ErrorMintMismatch
+	ErrorOwnerMismatch
+	ErrorFixedSupply
+	ErrorAlreadyInUse
+	ErrorInvalidNumberOfProvidedSigners
+	ErrorInvalidNumberOfRequiredSigners
+	ErrorUninitializedState
+	ErrorNativeNotSupported
+	ErrorNonNativeHasBalance
+	ErrorInvalidInstruction
+	ErrorInvalidState
+	ErrorOverflow
+	ErrorAuthorityTypeNotSupported
+	ErrorMintCannotFreeze
+	ErrorAccountFrozen
+	ErrorMintDecimalsMismatch
+	ErrorNonNativeNotSupported
 )
+
+var errorMessages = map[Error]string{
+	ErrorNotRentExempt:                  "Lamport balance below rent-exempt threshold",
+	ErrorInsufficientFunds:              "Insufficient funds",
+	ErrorInvalidMint:                    "Invalid Mint",
+	ErrorMintMismatch:                   "Account not associated with this Mint",
+	ErrorOwnerMismatch:                  "Owner does not match",
+	ErrorFixedSupply:                    "Fixed supply",
+	ErrorAlreadyInUse:                   "Already in use",
+	ErrorInvalidNumberOfProvidedSigners: "Invalid number of provided signers",
+	ErrorInvalidNumberOfRequiredSigners: "Invalid number of required signers",
+	ErrorUninitializedState:             "State is uninitialized",
+	ErrorNativeNotSupported:             "Instruction does not support native tokens",
+	ErrorNonNativeHasBalance:            "Non-native account can only be closed if its balance is zero",
+	ErrorInvalidInstruction:             "Invalid instruction",
+	ErrorInvalidState:                   "State is invalid for requested operation",
+	ErrorOverflow:                       "Operation overflowed",
+	ErrorAuthorityTypeNotSupported:      "Account does not support specified authority type",
+	ErrorMintCannotFreeze:               "This token mint cannot freeze accounts",
+	ErrorAccountFrozen:                  "Account is frozen",
+	ErrorMintDecimalsMismatch:           "The provided decimals value different from the Mint decimals",
+	ErrorNonNativeNotSupported:          "Instruction does not support non-native tokens",
+}
+
+func (e Error) Error() string {
+	if message, ok := errorMessages[e]; ok {
+		return message
+	}
+	return fmt.Sprintf("unknown token program error: %#x", uint32(e))
+}
+
+// ParseError finds the custom program error in err and converts it to an Error.
+// err is usually a *types.TransactionError or an error which wraps it, the caller should make sure
+// the failed instruction belongs to the token program since custom error codes are program specific.
+func ParseError(err error) (Error, bool) {
+	var customErr types.CustomError
+	if !errors.As(err, &customErr) {
+		return 0, false
+	}
+	if _, ok := errorMessages[Error(customErr)]; !ok {
+		return 0, false
+	}
+	return Error(customErr), true
+}
@@ -0,0 +1,69 @@
+package tokenprog
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	type args struct {
+		err error
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   Error
+		wantOk bool
+	}{
+		{
+			name: "instruction error",
+			args: args{
+				err: types.ParseTransactionError(map[string]interface{}{
+					"InstructionError": []interface{}{float64(1), map[string]interface{}{"Custom": float64(1)}},
+				}),
+			},
+			want:   ErrorInsufficientFunds,
+			wantOk: true,
+		},
+		{
+			name: "wrapped",
+			args: args{
+				err: fmt.Errorf("failed, err: %w", &types.InstructionError{Index: 0, Err: types.CustomError(17)}),
+			},
+			want:   ErrorAccountFrozen,
+			wantOk: true,
+		},
+		{
+			name: "unknown code",
+			args: args{
+				err: &types.InstructionError{Index: 0, Err: types.CustomError(100)},
+			},
+			want:   0,
+			wantOk: false,
+		},
+		{
+			name: "not a custom error",
+			args: args{
+				err: errors.New("some error"),
+			},
+			want:   0,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseError(tt.args.err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	assert.Equal(t, "Insufficient funds", ErrorInsufficientFunds.Error())
+	assert.Equal(t, "unknown token program error: 0x64", Error(100).Error())
+}
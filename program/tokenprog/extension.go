@@ -0,0 +1,333 @@
+package tokenprog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// token-2022 accounts with extensions are padded to TokenAccountSize, then an account type
+// and the extensions in type-length-value format follow.
+
+type AccountType uint8
+
+const (
+	AccountTypeUninitialized AccountType = iota
+	AccountTypeMint
+	AccountTypeAccount
+)
+
+type ExtensionType uint16
+
+const (
+	ExtensionTypeUninitialized ExtensionType = iota
+	ExtensionTypeTransferFeeConfig
+	ExtensionTypeTransferFeeAmount
+	ExtensionTypeMintCloseAuthority
+	ExtensionTypeConfidentialTransferMint
+	ExtensionTypeConfidentialTransferAccount
+	ExtensionTypeDefaultAccountState
+	ExtensionTypeImmutableOwner
+	ExtensionTypeMemoTransfer
+	ExtensionTypeNonTransferable
+	ExtensionTypeInterestBearingConfig
+	ExtensionTypeCpiGuard
+	ExtensionTypePermanentDelegate
+	ExtensionTypeNonTransferableAccount
+	ExtensionTypeTransferHook
+	ExtensionTypeTransferHookAccount
+	ExtensionTypeConfidentialTransferFeeConfig
+	ExtensionTypeConfidentialTransferFeeAmount
+	ExtensionTypeMetadataPointer
+	ExtensionTypeTokenMetadata
+	ExtensionTypeGroupPointer
+	ExtensionTypeTokenGroup
+	ExtensionTypeGroupMemberPointer
+	ExtensionTypeTokenGroupMember
+)
+
+// Extension is a token-2022 extension of a mint or a token account.
+// Value is the decoded extension, e.g. TransferFeeConfig. it is nil if the type is not supported yet.
+type Extension struct {
+	Type  ExtensionType
+	Data  []byte
+	Value interface{}
+}
+
+type TransferFee struct {
+	Epoch                  uint64
+	MaximumFee             uint64
+	TransferFeeBasisPoints uint16
+}
+
+// CalculateFee returns the fee of transferring amount, it rounds up the same way as the program
+func (f TransferFee) CalculateFee(amount uint64) uint64 {
+	if f.TransferFeeBasisPoints == 0 || amount == 0 {
+		return 0
+	}
+	fee := amount
+	if f.TransferFeeBasisPoints < 10000 {
+		hi, lo := bits.Mul64(amount, uint64(f.TransferFeeBasisPoints))
+		var carry uint64
+		lo, carry = bits.Add64(lo, 10000-1, 0)
+		hi += carry
+		fee, _ = bits.Div64(hi, lo, 10000)
+	}
+	if fee > f.MaximumFee {
+		return f.MaximumFee
+	}
+	return fee
+}
+
+type TransferFeeConfig struct {
+	TransferFeeConfigAuth *common.PublicKey
+	WithdrawWithheldAuth  *common.PublicKey
+	WithheldAmount        uint64
+	OlderTransferFee      TransferFee
+	NewerTransferFee      TransferFee
+}
+
+// GetEpochFee returns the transfer fee which is in effect at the epoch
+func (c TransferFeeConfig) GetEpochFee(epoch uint64) TransferFee {
+	if epoch >= c.NewerTransferFee.Epoch {
+		return c.NewerTransferFee
+	}
+	return c.OlderTransferFee
+}
+
+type TransferFeeAmount struct {
+	WithheldAmount uint64
+}
+
+type MintCloseAuthority struct {
+	CloseAuth *common.PublicKey
+}
+
+type ConfidentialTransferMint struct {
+	Auth                   *common.PublicKey
+	AutoApproveNewAccounts bool
+	AuditorElGamalPubkey   *[32]byte
+}
+
+type DefaultAccountState struct {
+	State TokenAccountState
+}
+
+type ImmutableOwner struct{}
+
+type MemoTransfer struct {
+	RequireIncomingTransferMemos bool
+}
+
+type NonTransferable struct{}
+
+type InterestBearingConfig struct {
+	RateAuth                *common.PublicKey
+	InitializationTimestamp int64
+	PreUpdateAverageRate    int16
+	LastUpdateTimestamp     int64
+	CurrentRate             int16
+}
+
+type CpiGuard struct {
+	LockCpi bool
+}
+
+type PermanentDelegate struct {
+	Delegate *common.PublicKey
+}
+
+type NonTransferableAccount struct{}
+
+type TransferHook struct {
+	Auth      *common.PublicKey
+	ProgramID *common.PublicKey
+}
+
+type TransferHookAccount struct {
+	Transferring bool
+}
+
+type MetadataPointer struct {
+	Auth            *common.PublicKey
+	MetadataAddress *common.PublicKey
+}
+
+// GetExtension returns the extension of the type
+func (m MintAccount) GetExtension(extensionType ExtensionType) (Extension, bool) {
+	return findExtension(m.Extensions, extensionType)
+}
+
+// GetExtension returns the extension of the type
+func (a TokenAccount) GetExtension(extensionType ExtensionType) (Extension, bool) {
+	return findExtension(a.Extensions, extensionType)
+}
+
+func findExtension(extensions []Extension, extensionType ExtensionType) (Extension, bool) {
+	for _, extension := range extensions {
+		if extension.Type == extensionType {
+			return extension, true
+		}
+	}
+	return Extension{}, false
+}
+
+// extensionsFromData parses extensions of an account whose size is larger than TokenAccountSize
+func extensionsFromData(data []byte, accountType AccountType) ([]Extension, error) {
+	if len(data) <= TokenAccountSize {
+		return nil, ErrInvalidAccountDataSize
+	}
+	if AccountType(data[TokenAccountSize]) != accountType {
+		return nil, ErrInvalidAccountType
+	}
+
+	extensions := []Extension{}
+	current := TokenAccountSize + 1
+	for current+4 <= len(data) {
+		extensionType := ExtensionType(binary.LittleEndian.Uint16(data[current : current+2]))
+		length := int(binary.LittleEndian.Uint16(data[current+2 : current+4]))
+		current += 4
+		// the rest is the space which isn't used yet
+		if extensionType == ExtensionTypeUninitialized {
+			break
+		}
+		if current+length > len(data) {
+			return nil, fmt.Errorf("%w, extension %v is out of range", ErrInvalidAccountDataSize, extensionType)
+		}
+		value, err := decodeExtension(extensionType, data[current:current+length])
+		if err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, Extension{
+			Type:  extensionType,
+			Data:  data[current : current+length],
+			Value: value,
+		})
+		current += length
+	}
+	return extensions, nil
+}
+
+func decodeExtension(extensionType ExtensionType, data []byte) (interface{}, error) {
+	size, ok := extensionSizes[extensionType]
+	if !ok {
+		return nil, nil
+	}
+	if len(data) < size {
+		return nil, fmt.Errorf("%w, extension %v expected %v bytes, got %v", ErrInvalidAccountDataSize, extensionType, size, len(data))
+	}
+
+	switch extensionType {
+	case ExtensionTypeTransferFeeConfig:
+		return TransferFeeConfig{
+			TransferFeeConfigAuth: optionalNonZeroPublicKeyFromData(data[0:32]),
+			WithdrawWithheldAuth:  optionalNonZeroPublicKeyFromData(data[32:64]),
+			WithheldAmount:        binary.LittleEndian.Uint64(data[64:72]),
+			OlderTransferFee:      transferFeeFromData(data[72:90]),
+			NewerTransferFee:      transferFeeFromData(data[90:108]),
+		}, nil
+	case ExtensionTypeTransferFeeAmount:
+		return TransferFeeAmount{
+			WithheldAmount: binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+	case ExtensionTypeMintCloseAuthority:
+		return MintCloseAuthority{
+			CloseAuth: optionalNonZeroPublicKeyFromData(data[:32]),
+		}, nil
+	case ExtensionTypeConfidentialTransferMint:
+		var auditorElGamalPubkey *[32]byte
+		if (common.PublicKeyFromBytes(data[33:65]) != common.PublicKey{}) {
+			var pubkey [32]byte
+			copy(pubkey[:], data[33:65])
+			auditorElGamalPubkey = &pubkey
+		}
+		return ConfidentialTransferMint{
+			Auth:                   optionalNonZeroPublicKeyFromData(data[:32]),
+			AutoApproveNewAccounts: data[32] == 1,
+			AuditorElGamalPubkey:   auditorElGamalPubkey,
+		}, nil
+	case ExtensionTypeDefaultAccountState:
+		return DefaultAccountState{
+			State: TokenAccountState(data[0]),
+		}, nil
+	case ExtensionTypeImmutableOwner:
+		return ImmutableOwner{}, nil
+	case ExtensionTypeMemoTransfer:
+		return MemoTransfer{
+			RequireIncomingTransferMemos: data[0] == 1,
+		}, nil
+	case ExtensionTypeNonTransferable:
+		return NonTransferable{}, nil
+	case ExtensionTypeInterestBearingConfig:
+		return InterestBearingConfig{
+			RateAuth:                optionalNonZeroPublicKeyFromData(data[:32]),
+			InitializationTimestamp: int64(binary.LittleEndian.Uint64(data[32:40])),
+			PreUpdateAverageRate:    int16(binary.LittleEndian.Uint16(data[40:42])),
+			LastUpdateTimestamp:     int64(binary.LittleEndian.Uint64(data[42:50])),
+			CurrentRate:             int16(binary.LittleEndian.Uint16(data[50:52])),
+		}, nil
+	case ExtensionTypeCpiGuard:
+		return CpiGuard{
+			LockCpi: data[0] == 1,
+		}, nil
+	case ExtensionTypePermanentDelegate:
+		return PermanentDelegate{
+			Delegate: optionalNonZeroPublicKeyFromData(data[:32]),
+		}, nil
+	case ExtensionTypeNonTransferableAccount:
+		return NonTransferableAccount{}, nil
+	case ExtensionTypeTransferHook:
+		return TransferHook{
+			Auth:      optionalNonZeroPublicKeyFromData(data[:32]),
+			ProgramID: optionalNonZeroPublicKeyFromData(data[32:64]),
+		}, nil
+	case ExtensionTypeTransferHookAccount:
+		return TransferHookAccount{
+			Transferring: data[0] == 1,
+		}, nil
+	case ExtensionTypeMetadataPointer:
+		return MetadataPointer{
+			Auth:            optionalNonZeroPublicKeyFromData(data[:32]),
+			MetadataAddress: optionalNonZeroPublicKeyFromData(data[32:64]),
+		}, nil
+	}
+	return nil, nil
+}
+
+// extensionSizes are the sizes of extensions which can be decoded
+var extensionSizes = map[ExtensionType]int{
+	ExtensionTypeTransferFeeConfig:        108,
+	ExtensionTypeTransferFeeAmount:        8,
+	ExtensionTypeMintCloseAuthority:       32,
+	ExtensionTypeConfidentialTransferMint: 65,
+	ExtensionTypeDefaultAccountState:      1,
+	ExtensionTypeImmutableOwner:           0,
+	ExtensionTypeMemoTransfer:             1,
+	ExtensionTypeNonTransferable:          0,
+	ExtensionTypeInterestBearingConfig:    52,
+	ExtensionTypeCpiGuard:                 1,
+	ExtensionTypePermanentDelegate:        32,
+	ExtensionTypeNonTransferableAccount:   0,
+	ExtensionTypeTransferHook:             64,
+	ExtensionTypeTransferHookAccount:      1,
+	ExtensionTypeMetadataPointer:          64,
+}
+
+func transferFeeFromData(data []byte) TransferFee {
+	return TransferFee{
+		Epoch:                  binary.LittleEndian.Uint64(data[:8]),
+		MaximumFee:             binary.LittleEndian.Uint64(data[8:16]),
+		TransferFeeBasisPoints: binary.LittleEndian.Uint16(data[16:18]),
+	}
+}
+
+// optionalNonZeroPublicKeyFromData decodes the empty pubkey as nil
+func optionalNonZeroPublicKeyFromData(data []byte) *common.PublicKey {
+	pubkey := common.PublicKeyFromBytes(data)
+	if pubkey == (common.PublicKey{}) {
+		return nil
+	}
+	return &pubkey
+}
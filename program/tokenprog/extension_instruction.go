@@ -0,0 +1,716 @@
+package tokenprog
+
+import (
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/bincode"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// instructions of token-2022 extensions, they always target common.Token2022ProgramID.
+// most of extensions on a mint must be initialized before InitializeMint.
+
+type TransferFeeInstruction uint8
+
+const (
+	TransferFeeInstructionInitializeTransferFeeConfig TransferFeeInstruction = iota
+	TransferFeeInstructionTransferCheckedWithFee
+	TransferFeeInstructionWithdrawWithheldTokensFromMint
+	TransferFeeInstructionWithdrawWithheldTokensFromAccounts
+	TransferFeeInstructionHarvestWithheldTokensToMint
+	TransferFeeInstructionSetTransferFee
+)
+
+type ConfidentialTransferInstruction uint8
+
+const (
+	ConfidentialTransferInstructionInitializeMint ConfidentialTransferInstruction = iota
+)
+
+type DefaultAccountStateInstruction uint8
+
+const (
+	DefaultAccountStateInstructionInitialize DefaultAccountStateInstruction = iota
+	DefaultAccountStateInstructionUpdate
+)
+
+type MemoTransferInstruction uint8
+
+const (
+	MemoTransferInstructionEnable MemoTransferInstruction = iota
+	MemoTransferInstructionDisable
+)
+
+type InterestBearingMintInstruction uint8
+
+const (
+	InterestBearingMintInstructionInitialize InterestBearingMintInstruction = iota
+	InterestBearingMintInstructionUpdateRate
+)
+
+type CpiGuardInstruction uint8
+
+const (
+	CpiGuardInstructionEnable CpiGuardInstruction = iota
+	CpiGuardInstructionDisable
+)
+
+type TransferHookInstruction uint8
+
+const (
+	TransferHookInstructionInitialize TransferHookInstruction = iota
+	TransferHookInstructionUpdate
+)
+
+type MetadataPointerInstruction uint8
+
+const (
+	MetadataPointerInstructionInitialize MetadataPointerInstruction = iota
+	MetadataPointerInstructionUpdate
+)
+
+type InitializeMintCloseAuthorityParam struct {
+	Mint      common.PublicKey
+	CloseAuth *common.PublicKey
+}
+
+// InitializeMintCloseAuthority allows the close authority to close the mint when its supply is zero
+func InitializeMintCloseAuthority(param InitializeMintCloseAuthorityParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction    Instruction
+		CloseAuthority *common.PublicKey
+	}{
+		Instruction:    InstructionInitializeMintCloseAuthority,
+		CloseAuthority: param.CloseAuth,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+type InitializeTransferFeeConfigParam struct {
+	Mint                   common.PublicKey
+	TransferFeeConfigAuth  *common.PublicKey
+	WithdrawWithheldAuth   *common.PublicKey
+	TransferFeeBasisPoints uint16
+	MaximumFee             uint64
+}
+
+func InitializeTransferFeeConfig(param InitializeTransferFeeConfigParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction                Instruction
+		TransferFeeInstruction     TransferFeeInstruction
+		TransferFeeConfigAuthority *common.PublicKey
+		WithdrawWithheldAuthority  *common.PublicKey
+		TransferFeeBasisPoints     uint16
+		MaximumFee                 uint64
+	}{
+		Instruction:                InstructionTransferFeeExtension,
+		TransferFeeInstruction:     TransferFeeInstructionInitializeTransferFeeConfig,
+		TransferFeeConfigAuthority: param.TransferFeeConfigAuth,
+		WithdrawWithheldAuthority:  param.WithdrawWithheldAuth,
+		TransferFeeBasisPoints:     param.TransferFeeBasisPoints,
+		MaximumFee:                 param.MaximumFee,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+type TransferCheckedWithFeeParam struct {
+	From     common.PublicKey
+	To       common.PublicKey
+	Mint     common.PublicKey
+	Auth     common.PublicKey
+	Signers  []common.PublicKey
+	Amount   uint64
+	Decimals uint8
+	Fee      uint64
+}
+
+// TransferCheckedWithFee transfers tokens and checks the fee is what the mint expects
+func TransferCheckedWithFee(param TransferCheckedWithFeeParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+		Amount                 uint64
+		Decimals               uint8
+		Fee                    uint64
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionTransferCheckedWithFee,
+		Amount:                 param.Amount,
+		Decimals:               param.Decimals,
+		Fee:                    param.Fee,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 4+len(param.Signers))
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.From, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.Mint, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: param.To, IsSigner: false, IsWritable: true},
+	)
+	accounts = appendAuth(accounts, param.Auth, param.Signers)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type WithdrawWithheldTokensFromMintParam struct {
+	Mint    common.PublicKey
+	To      common.PublicKey
+	Auth    common.PublicKey
+	Signers []common.PublicKey
+}
+
+func WithdrawWithheldTokensFromMint(param WithdrawWithheldTokensFromMintParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionWithdrawWithheldTokensFromMint,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3+len(param.Signers))
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Mint, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: param.To, IsSigner: false, IsWritable: true},
+	)
+	accounts = appendAuth(accounts, param.Auth, param.Signers)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type WithdrawWithheldTokensFromAccountsParam struct {
+	Mint    common.PublicKey
+	To      common.PublicKey
+	Auth    common.PublicKey
+	Signers []common.PublicKey
+	Sources []common.PublicKey
+}
+
+func WithdrawWithheldTokensFromAccounts(param WithdrawWithheldTokensFromAccountsParam) types.Instruction {
+	if len(param.Sources) > 255 {
+		panic("maximum of sources is 255")
+	}
+
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+		NumTokenAccounts       uint8
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionWithdrawWithheldTokensFromAccounts,
+		NumTokenAccounts:       uint8(len(param.Sources)),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3+len(param.Signers)+len(param.Sources))
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: param.Mint, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: param.To, IsSigner: false, IsWritable: true},
+	)
+	accounts = appendAuth(accounts, param.Auth, param.Signers)
+	for _, source := range param.Sources {
+		accounts = append(accounts, types.AccountMeta{PubKey: source, IsSigner: false, IsWritable: true})
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type HarvestWithheldTokensToMintParam struct {
+	Mint    common.PublicKey
+	Sources []common.PublicKey
+}
+
+// HarvestWithheldTokensToMint moves withheld tokens of token accounts to the mint, anyone can call it
+func HarvestWithheldTokensToMint(param HarvestWithheldTokensToMintParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionHarvestWithheldTokensToMint,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 1+len(param.Sources))
+	accounts = append(accounts, types.AccountMeta{PubKey: param.Mint, IsSigner: false, IsWritable: true})
+	for _, source := range param.Sources {
+		accounts = append(accounts, types.AccountMeta{PubKey: source, IsSigner: false, IsWritable: true})
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type SetTransferFeeParam struct {
+	Mint                   common.PublicKey
+	Auth                   common.PublicKey
+	Signers                []common.PublicKey
+	TransferFeeBasisPoints uint16
+	MaximumFee             uint64
+}
+
+// SetTransferFee sets the transfer fee, it takes effect after two epochs
+func SetTransferFee(param SetTransferFeeParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+		TransferFeeBasisPoints uint16
+		MaximumFee             uint64
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionSetTransferFee,
+		TransferFeeBasisPoints: param.TransferFeeBasisPoints,
+		MaximumFee:             param.MaximumFee,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(param.Signers))
+	accounts = append(accounts, types.AccountMeta{PubKey: param.Mint, IsSigner: false, IsWritable: true})
+	accounts = appendAuth(accounts, param.Auth, param.Signers)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type InitializeConfidentialTransferMintParam struct {
+	Mint                   common.PublicKey
+	Auth                   *common.PublicKey
+	AutoApproveNewAccounts bool
+	AuditorElGamalPubkey   *[32]byte
+}
+
+// InitializeConfidentialTransferMint enables confidential transfers of the mint
+func InitializeConfidentialTransferMint(param InitializeConfidentialTransferMintParam) types.Instruction {
+	var auditorElGamalPubkey [32]byte
+	if param.AuditorElGamalPubkey != nil {
+		auditorElGamalPubkey = *param.AuditorElGamalPubkey
+	}
+	data, err := bincode.SerializeData(struct {
+		Instruction                     Instruction
+		ConfidentialTransferInstruction ConfidentialTransferInstruction
+		Authority                       common.PublicKey
+		AutoApproveNewAccounts          bool
+		AuditorElGamalPubkey            [32]byte
+	}{
+		Instruction:                     InstructionConfidentialTransferExtension,
+		ConfidentialTransferInstruction: ConfidentialTransferInstructionInitializeMint,
+		Authority:                       optionalNonZeroPublicKey(param.Auth),
+		AutoApproveNewAccounts:          param.AutoApproveNewAccounts,
+		AuditorElGamalPubkey:            auditorElGamalPubkey,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+type InitializeDefaultAccountStateParam struct {
+	Mint  common.PublicKey
+	State TokenAccountState
+}
+
+// InitializeDefaultAccountState sets the state of new token accounts of the mint, e.g. TokenAccountFrozen
+func InitializeDefaultAccountState(param InitializeDefaultAccountStateParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction                    Instruction
+		DefaultAccountStateInstruction DefaultAccountStateInstruction
+		State                          TokenAccountState
+	}{
+		Instruction:                    InstructionDefaultAccountStateExtension,
+		DefaultAccountStateInstruction: DefaultAccountStateInstructionInitialize,
+		State:                          param.State,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+type UpdateDefaultAccountStateParam struct {
+	Mint       common.PublicKey
+	FreezeAuth common.PublicKey
+	Signers    []common.PublicKey
+	State      TokenAccountState
+}
+
+func UpdateDefaultAccountState(param UpdateDefaultAccountStateParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction                    Instruction
+		DefaultAccountStateInstruction DefaultAccountStateInstruction
+		State                          TokenAccountState
+	}{
+		Instruction:                    InstructionDefaultAccountStateExtension,
+		DefaultAccountStateInstruction: DefaultAccountStateInstructionUpdate,
+		State:                          param.State,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(param.Signers))
+	accounts = append(accounts, types.AccountMeta{PubKey: param.Mint, IsSigner: false, IsWritable: true})
+	accounts = appendAuth(accounts, param.FreezeAuth, param.Signers)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type EnableRequiredMemoTransfersParam struct {
+	Account common.PublicKey
+	Owner   common.PublicKey
+	Signers []common.PublicKey
+}
+
+// EnableRequiredMemoTransfers requires a memo instruction before every incoming transfer of the account
+func EnableRequiredMemoTransfers(param EnableRequiredMemoTransfersParam) types.Instruction {
+	return accountExtensionInstruction(InstructionMemoTransferExtension, uint8(MemoTransferInstructionEnable), param.Account, param.Owner, param.Signers)
+}
+
+type DisableRequiredMemoTransfersParam struct {
+	Account common.PublicKey
+	Owner   common.PublicKey
+	Signers []common.PublicKey
+}
+
+func DisableRequiredMemoTransfers(param DisableRequiredMemoTransfersParam) types.Instruction {
+	return accountExtensionInstruction(InstructionMemoTransferExtension, uint8(MemoTransferInstructionDisable), param.Account, param.Owner, param.Signers)
+}
+
+type InitializeNonTransferableMintParam struct {
+	Mint common.PublicKey
+}
+
+// InitializeNonTransferableMint makes tokens of the mint can't be transferred
+func InitializeNonTransferableMint(param InitializeNonTransferableMintParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionInitializeNonTransferableMint,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+type InitializeInterestBearingMintParam struct {
+	Mint     common.PublicKey
+	RateAuth *common.PublicKey
+	// Rate is in basis points
+	Rate int16
+}
+
+func InitializeInterestBearingMint(param InitializeInterestBearingMintParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction                    Instruction
+		InterestBearingMintInstruction InterestBearingMintInstruction
+		RateAuthority                  common.PublicKey
+		Rate                           int16
+	}{
+		Instruction:                    InstructionInterestBearingMintExtension,
+		InterestBearingMintInstruction: InterestBearingMintInstructionInitialize,
+		RateAuthority:                  optionalNonZeroPublicKey(param.RateAuth),
+		Rate:                           param.Rate,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+type UpdateInterestBearingMintRateParam struct {
+	Mint     common.PublicKey
+	RateAuth common.PublicKey
+	Signers  []common.PublicKey
+	// Rate is in basis points
+	Rate int16
+}
+
+func UpdateInterestBearingMintRate(param UpdateInterestBearingMintRateParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction                    Instruction
+		InterestBearingMintInstruction InterestBearingMintInstruction
+		Rate                           int16
+	}{
+		Instruction:                    InstructionInterestBearingMintExtension,
+		InterestBearingMintInstruction: InterestBearingMintInstructionUpdateRate,
+		Rate:                           param.Rate,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(param.Signers))
+	accounts = append(accounts, types.AccountMeta{PubKey: param.Mint, IsSigner: false, IsWritable: true})
+	accounts = appendAuth(accounts, param.RateAuth, param.Signers)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+type EnableCpiGuardParam struct {
+	Account common.PublicKey
+	Owner   common.PublicKey
+	Signers []common.PublicKey
+}
+
+// EnableCpiGuard forbids privileged operations of the account from cross-program invocations
+func EnableCpiGuard(param EnableCpiGuardParam) types.Instruction {
+	return accountExtensionInstruction(InstructionCpiGuardExtension, uint8(CpiGuardInstructionEnable), param.Account, param.Owner, param.Signers)
+}
+
+type DisableCpiGuardParam struct {
+	Account common.PublicKey
+	Owner   common.PublicKey
+	Signers []common.PublicKey
+}
+
+func DisableCpiGuard(param DisableCpiGuardParam) types.Instruction {
+	return accountExtensionInstruction(InstructionCpiGuardExtension, uint8(CpiGuardInstructionDisable), param.Account, param.Owner, param.Signers)
+}
+
+type InitializePermanentDelegateParam struct {
+	Mint     common.PublicKey
+	Delegate common.PublicKey
+}
+
+// InitializePermanentDelegate sets a delegate which can transfer or burn tokens of any account of the mint
+func InitializePermanentDelegate(param InitializePermanentDelegateParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+		Delegate    common.PublicKey
+	}{
+		Instruction: InstructionInitializePermanentDelegate,
+		Delegate:    param.Delegate,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+type InitializeTransferHookParam struct {
+	Mint                  common.PublicKey
+	Auth                  *common.PublicKey
+	TransferHookProgramID *common.PublicKey
+}
+
+// InitializeTransferHook sets a program which is invoked on every transfer of the mint
+func InitializeTransferHook(param InitializeTransferHookParam) types.Instruction {
+	return pointerInitializeInstruction(InstructionTransferHookExtension, uint8(TransferHookInstructionInitialize), param.Mint, param.Auth, param.TransferHookProgramID)
+}
+
+type UpdateTransferHookParam struct {
+	Mint                  common.PublicKey
+	Auth                  common.PublicKey
+	Signers               []common.PublicKey
+	TransferHookProgramID *common.PublicKey
+}
+
+func UpdateTransferHook(param UpdateTransferHookParam) types.Instruction {
+	return pointerUpdateInstruction(InstructionTransferHookExtension, uint8(TransferHookInstructionUpdate), param.Mint, param.Auth, param.Signers, param.TransferHookProgramID)
+}
+
+type InitializeMetadataPointerParam struct {
+	Mint            common.PublicKey
+	Auth            *common.PublicKey
+	MetadataAddress *common.PublicKey
+}
+
+// InitializeMetadataPointer sets the account which stores the metadata of the mint
+func InitializeMetadataPointer(param InitializeMetadataPointerParam) types.Instruction {
+	return pointerInitializeInstruction(InstructionMetadataPointerExtension, uint8(MetadataPointerInstructionInitialize), param.Mint, param.Auth, param.MetadataAddress)
+}
+
+type UpdateMetadataPointerParam struct {
+	Mint            common.PublicKey
+	Auth            common.PublicKey
+	Signers         []common.PublicKey
+	MetadataAddress *common.PublicKey
+}
+
+func UpdateMetadataPointer(param UpdateMetadataPointerParam) types.Instruction {
+	return pointerUpdateInstruction(InstructionMetadataPointerExtension, uint8(MetadataPointerInstructionUpdate), param.Mint, param.Auth, param.Signers, param.MetadataAddress)
+}
+
+// accountExtensionInstruction builds enable/disable instructions of token account extensions
+func accountExtensionInstruction(instruction Instruction, extensionInstruction uint8, account, owner common.PublicKey, signers []common.PublicKey) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction          Instruction
+		ExtensionInstruction uint8
+	}{
+		Instruction:          instruction,
+		ExtensionInstruction: extensionInstruction,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signers))
+	accounts = append(accounts, types.AccountMeta{PubKey: account, IsSigner: false, IsWritable: true})
+	accounts = appendAuth(accounts, owner, signers)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// pointerInitializeInstruction builds initialize instructions of extensions which store an authority and an address
+func pointerInitializeInstruction(instruction Instruction, extensionInstruction uint8, mint common.PublicKey, auth, address *common.PublicKey) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction          Instruction
+		ExtensionInstruction uint8
+		Authority            common.PublicKey
+		Address              common.PublicKey
+	}{
+		Instruction:          instruction,
+		ExtensionInstruction: extensionInstruction,
+		Authority:            optionalNonZeroPublicKey(auth),
+		Address:              optionalNonZeroPublicKey(address),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// pointerUpdateInstruction builds update instructions of extensions which store an authority and an address
+func pointerUpdateInstruction(instruction Instruction, extensionInstruction uint8, mint, auth common.PublicKey, signers []common.PublicKey, address *common.PublicKey) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction          Instruction
+		ExtensionInstruction uint8
+		Address              common.PublicKey
+	}{
+		Instruction:          instruction,
+		ExtensionInstruction: extensionInstruction,
+		Address:              optionalNonZeroPublicKey(address),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signers))
+	accounts = append(accounts, types.AccountMeta{PubKey: mint, IsSigner: false, IsWritable: true})
+	accounts = appendAuth(accounts, auth, signers)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// appendAuth appends an authority, it is a signer unless it is a multisig account
+func appendAuth(accounts []types.AccountMeta, auth common.PublicKey, signers []common.PublicKey) []types.AccountMeta {
+	accounts = append(accounts, types.AccountMeta{PubKey: auth, IsSigner: len(signers) == 0, IsWritable: false})
+	for _, signerPubkey := range signers {
+		accounts = append(accounts, types.AccountMeta{PubKey: signerPubkey, IsSigner: true, IsWritable: false})
+	}
+	return accounts
+}
+
+// optionalNonZeroPublicKey encodes none as the empty pubkey
+func optionalNonZeroPublicKey(pubkey *common.PublicKey) common.PublicKey {
+	if pubkey == nil {
+		return common.PublicKey{}
+	}
+	return *pubkey
+}
@@ -0,0 +1,239 @@
+package tokenprog
+
+import (
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtensionInstructionData(t *testing.T) {
+	mint := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	auth := common.PublicKeyFromString("11111111111111111111111111111112")
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        []byte
+	}{
+		{
+			name:        "initialize transfer fee config",
+			instruction: InitializeTransferFeeConfig(InitializeTransferFeeConfigParam{Mint: mint, WithdrawWithheldAuth: &auth, TransferFeeBasisPoints: 50, MaximumFee: 1000}),
+			want: append(
+				append([]byte{26, 0, 0, 1}, auth.Bytes()...),
+				50, 0, 0xe8, 0x03, 0, 0, 0, 0, 0, 0,
+			),
+		},
+		{
+			name:        "transfer checked with fee",
+			instruction: TransferCheckedWithFee(TransferCheckedWithFeeParam{Amount: 1, Decimals: 9, Fee: 2}),
+			want:        []byte{26, 1, 1, 0, 0, 0, 0, 0, 0, 0, 9, 2, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:        "initialize interest bearing mint",
+			instruction: InitializeInterestBearingMint(InitializeInterestBearingMintParam{Mint: mint, Rate: -1}),
+			want:        append(append([]byte{33, 0}, make([]byte, 32)...), 0xff, 0xff),
+		},
+		{
+			name:        "initialize default account state",
+			instruction: InitializeDefaultAccountState(InitializeDefaultAccountStateParam{Mint: mint, State: TokenAccountFrozen}),
+			want:        []byte{28, 0, 2},
+		},
+		{
+			name:        "enable required memo transfers",
+			instruction: EnableRequiredMemoTransfers(EnableRequiredMemoTransfersParam{}),
+			want:        []byte{30, 0},
+		},
+		{
+			name:        "disable cpi guard",
+			instruction: DisableCpiGuard(DisableCpiGuardParam{}),
+			want:        []byte{34, 1},
+		},
+		{
+			name:        "update metadata pointer",
+			instruction: UpdateMetadataPointer(UpdateMetadataPointerParam{MetadataAddress: &auth}),
+			want:        append([]byte{39, 1}, auth.Bytes()...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, common.Token2022ProgramID, tt.instruction.ProgramID)
+			assert.Equal(t, tt.want, tt.instruction.Data)
+		})
+	}
+}
+
+func TestInstructionNumbering(t *testing.T) {
+	// pinned to the TokenInstruction enum of spl-token-2022
+	tests := []struct {
+		instruction Instruction
+		want        uint8
+	}{
+		{InstructionInitializeMint, 0},
+		{InstructionInitializeMint2, 20},
+		{InstructionGetAccountDataSize, 21},
+		{InstructionInitializeImmutableOwner, 22},
+		{InstructionAmountToUiAmount, 23},
+		{InstructionUiAmountToAmount, 24},
+		{InstructionInitializeMintCloseAuthority, 25},
+		{InstructionTransferFeeExtension, 26},
+		{InstructionConfidentialTransferExtension, 27},
+		{InstructionDefaultAccountStateExtension, 28},
+		{InstructionReallocate, 29},
+		{InstructionMemoTransferExtension, 30},
+		{InstructionCreateNativeMint, 31},
+		{InstructionInitializeNonTransferableMint, 32},
+		{InstructionInterestBearingMintExtension, 33},
+		{InstructionCpiGuardExtension, 34},
+		{InstructionInitializePermanentDelegate, 35},
+		{InstructionTransferHookExtension, 36},
+		{InstructionConfidentialTransferFeeExtension, 37},
+		{InstructionWithdrawExcessLamports, 38},
+		{InstructionMetadataPointerExtension, 39},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, uint8(tt.instruction))
+	}
+}
+
+func TestDecodeExtensionInstruction(t *testing.T) {
+	mint := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	account := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	auth := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+	to := common.PublicKeyFromString("9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde")
+	signer := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	auditor := [32]byte{1, 2, 3}
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+	}{
+		{
+			name:        "initialize mint close authority",
+			instruction: InitializeMintCloseAuthority(InitializeMintCloseAuthorityParam{Mint: mint, CloseAuth: &auth}),
+			want:        InitializeMintCloseAuthorityParam{Mint: mint, CloseAuth: &auth},
+		},
+		{
+			name:        "initialize transfer fee config",
+			instruction: InitializeTransferFeeConfig(InitializeTransferFeeConfigParam{Mint: mint, TransferFeeConfigAuth: &auth, TransferFeeBasisPoints: 50, MaximumFee: 1000}),
+			want:        InitializeTransferFeeConfigParam{Mint: mint, TransferFeeConfigAuth: &auth, TransferFeeBasisPoints: 50, MaximumFee: 1000},
+		},
+		{
+			name:        "transfer checked with fee",
+			instruction: TransferCheckedWithFee(TransferCheckedWithFeeParam{From: account, To: to, Mint: mint, Auth: auth, Amount: 100, Decimals: 2, Fee: 1}),
+			want:        TransferCheckedWithFeeParam{From: account, To: to, Mint: mint, Auth: auth, Amount: 100, Decimals: 2, Fee: 1},
+		},
+		{
+			name:        "withdraw withheld tokens from mint",
+			instruction: WithdrawWithheldTokensFromMint(WithdrawWithheldTokensFromMintParam{Mint: mint, To: to, Auth: auth}),
+			want:        WithdrawWithheldTokensFromMintParam{Mint: mint, To: to, Auth: auth},
+		},
+		{
+			name:        "withdraw withheld tokens from accounts",
+			instruction: WithdrawWithheldTokensFromAccounts(WithdrawWithheldTokensFromAccountsParam{Mint: mint, To: to, Auth: auth, Signers: []common.PublicKey{signer}, Sources: []common.PublicKey{account}}),
+			want:        WithdrawWithheldTokensFromAccountsParam{Mint: mint, To: to, Auth: auth, Signers: []common.PublicKey{signer}, Sources: []common.PublicKey{account}},
+		},
+		{
+			name:        "harvest withheld tokens to mint",
+			instruction: HarvestWithheldTokensToMint(HarvestWithheldTokensToMintParam{Mint: mint, Sources: []common.PublicKey{account, to}}),
+			want:        HarvestWithheldTokensToMintParam{Mint: mint, Sources: []common.PublicKey{account, to}},
+		},
+		{
+			name:        "set transfer fee",
+			instruction: SetTransferFee(SetTransferFeeParam{Mint: mint, Auth: auth, TransferFeeBasisPoints: 10, MaximumFee: 5}),
+			want:        SetTransferFeeParam{Mint: mint, Auth: auth, TransferFeeBasisPoints: 10, MaximumFee: 5},
+		},
+		{
+			name:        "initialize confidential transfer mint",
+			instruction: InitializeConfidentialTransferMint(InitializeConfidentialTransferMintParam{Mint: mint, Auth: &auth, AutoApproveNewAccounts: true, AuditorElGamalPubkey: &auditor}),
+			want:        InitializeConfidentialTransferMintParam{Mint: mint, Auth: &auth, AutoApproveNewAccounts: true, AuditorElGamalPubkey: &auditor},
+		},
+		{
+			name:        "initialize default account state",
+			instruction: InitializeDefaultAccountState(InitializeDefaultAccountStateParam{Mint: mint, State: TokenAccountFrozen}),
+			want:        InitializeDefaultAccountStateParam{Mint: mint, State: TokenAccountFrozen},
+		},
+		{
+			name:        "update default account state",
+			instruction: UpdateDefaultAccountState(UpdateDefaultAccountStateParam{Mint: mint, FreezeAuth: auth, State: TokenAccountStateInitialized}),
+			want:        UpdateDefaultAccountStateParam{Mint: mint, FreezeAuth: auth, State: TokenAccountStateInitialized},
+		},
+		{
+			name:        "enable required memo transfers",
+			instruction: EnableRequiredMemoTransfers(EnableRequiredMemoTransfersParam{Account: account, Owner: auth}),
+			want:        EnableRequiredMemoTransfersParam{Account: account, Owner: auth},
+		},
+		{
+			name:        "disable required memo transfers",
+			instruction: DisableRequiredMemoTransfers(DisableRequiredMemoTransfersParam{Account: account, Owner: auth, Signers: []common.PublicKey{signer}}),
+			want:        DisableRequiredMemoTransfersParam{Account: account, Owner: auth, Signers: []common.PublicKey{signer}},
+		},
+		{
+			name:        "initialize non transferable mint",
+			instruction: InitializeNonTransferableMint(InitializeNonTransferableMintParam{Mint: mint}),
+			want:        InitializeNonTransferableMintParam{Mint: mint},
+		},
+		{
+			name:        "initialize interest bearing mint",
+			instruction: InitializeInterestBearingMint(InitializeInterestBearingMintParam{Mint: mint, RateAuth: &auth, Rate: 500}),
+			want:        InitializeInterestBearingMintParam{Mint: mint, RateAuth: &auth, Rate: 500},
+		},
+		{
+			name:        "update interest bearing mint rate",
+			instruction: UpdateInterestBearingMintRate(UpdateInterestBearingMintRateParam{Mint: mint, RateAuth: auth, Rate: -500}),
+			want:        UpdateInterestBearingMintRateParam{Mint: mint, RateAuth: auth, Rate: -500},
+		},
+		{
+			name:        "enable cpi guard",
+			instruction: EnableCpiGuard(EnableCpiGuardParam{Account: account, Owner: auth}),
+			want:        EnableCpiGuardParam{Account: account, Owner: auth},
+		},
+		{
+			name:        "disable cpi guard",
+			instruction: DisableCpiGuard(DisableCpiGuardParam{Account: account, Owner: auth}),
+			want:        DisableCpiGuardParam{Account: account, Owner: auth},
+		},
+		{
+			name:        "initialize permanent delegate",
+			instruction: InitializePermanentDelegate(InitializePermanentDelegateParam{Mint: mint, Delegate: auth}),
+			want:        InitializePermanentDelegateParam{Mint: mint, Delegate: auth},
+		},
+		{
+			name:        "initialize transfer hook",
+			instruction: InitializeTransferHook(InitializeTransferHookParam{Mint: mint, Auth: &auth, TransferHookProgramID: &to}),
+			want:        InitializeTransferHookParam{Mint: mint, Auth: &auth, TransferHookProgramID: &to},
+		},
+		{
+			name:        "update transfer hook",
+			instruction: UpdateTransferHook(UpdateTransferHookParam{Mint: mint, Auth: auth}),
+			want:        UpdateTransferHookParam{Mint: mint, Auth: auth},
+		},
+		{
+			name:        "initialize metadata pointer",
+			instruction: InitializeMetadataPointer(InitializeMetadataPointerParam{Mint: mint, MetadataAddress: &mint}),
+			want:        InitializeMetadataPointerParam{Mint: mint, MetadataAddress: &mint},
+		},
+		{
+			name:        "update metadata pointer",
+			instruction: UpdateMetadataPointer(UpdateMetadataPointerParam{Mint: mint, Auth: auth, MetadataAddress: &to}),
+			want:        UpdateMetadataPointerParam{Mint: mint, Auth: auth, MetadataAddress: &to},
+		},
+		{
+			name:        "token-2022 transfer",
+			instruction: Transfer(TransferParam{From: account, To: to, Auth: auth, Amount: 1, ProgramID: common.Token2022ProgramID}),
+			want:        TransferParam{From: account, To: to, Auth: auth, Amount: 1, ProgramID: common.Token2022ProgramID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := DecodeInstruction(types.Instruction{ProgramID: common.TokenProgramID, Accounts: make([]types.AccountMeta, 1), Data: []byte{29}})
+	assert.ErrorIs(t, err, types.ErrUnknownInstruction)
+}
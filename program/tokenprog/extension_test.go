@@ -0,0 +1,134 @@
+package tokenprog
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func tlv(extensionType ExtensionType, value []byte) []byte {
+	b := make([]byte, 4, 4+len(value))
+	binary.LittleEndian.PutUint16(b[:2], uint16(extensionType))
+	binary.LittleEndian.PutUint16(b[2:], uint16(len(value)))
+	return append(b, value...)
+}
+
+func TestMintAccountFromData_Extensions(t *testing.T) {
+	auth := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+	metadata := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+
+	base := make([]byte, TokenAccountSize)
+	copy(base[0:4], Some)
+	copy(base[4:36], auth.Bytes())
+	binary.LittleEndian.PutUint64(base[36:44], 1000)
+	base[44] = 6
+	base[45] = 1
+
+	transferFeeConfig := make([]byte, 108)
+	copy(transferFeeConfig[32:64], auth.Bytes())
+	binary.LittleEndian.PutUint64(transferFeeConfig[64:72], 7)
+	binary.LittleEndian.PutUint64(transferFeeConfig[90:98], 100)
+	binary.LittleEndian.PutUint64(transferFeeConfig[98:106], 5000)
+	binary.LittleEndian.PutUint16(transferFeeConfig[106:108], 50)
+
+	data := append(base, byte(AccountTypeMint))
+	data = append(data, tlv(ExtensionTypeTransferFeeConfig, transferFeeConfig)...)
+	data = append(data, tlv(ExtensionTypeMetadataPointer, append(make([]byte, 32), metadata.Bytes()...))...)
+	data = append(data, tlv(ExtensionTypeTokenMetadata, []byte{1, 2, 3})...)
+
+	got, err := MintAccountFromData(data)
+	assert.NoError(t, err)
+	assert.Equal(t, &auth, got.MintAuthority)
+	assert.Equal(t, uint64(1000), got.Supply)
+	assert.Equal(t, uint8(6), got.Decimals)
+	assert.True(t, got.IsInitialized)
+	assert.Len(t, got.Extensions, 3)
+
+	extension, ok := got.GetExtension(ExtensionTypeTransferFeeConfig)
+	assert.True(t, ok)
+	assert.Equal(t, TransferFeeConfig{
+		WithdrawWithheldAuth: &auth,
+		WithheldAmount:       7,
+		NewerTransferFee:     TransferFee{Epoch: 100, MaximumFee: 5000, TransferFeeBasisPoints: 50},
+	}, extension.Value)
+
+	extension, ok = got.GetExtension(ExtensionTypeMetadataPointer)
+	assert.True(t, ok)
+	assert.Equal(t, MetadataPointer{MetadataAddress: &metadata}, extension.Value)
+
+	extension, ok = got.GetExtension(ExtensionTypeTokenMetadata)
+	assert.True(t, ok)
+	assert.Nil(t, extension.Value)
+	assert.Equal(t, []byte{1, 2, 3}, extension.Data)
+
+	_, ok = got.GetExtension(ExtensionTypeCpiGuard)
+	assert.False(t, ok)
+
+	_, err = MintAccountFromData(append(base, byte(AccountTypeAccount)))
+	assert.Equal(t, ErrInvalidAccountType, err)
+
+	_, err = MintAccountFromData(data[:len(data)-1])
+	assert.ErrorIs(t, err, ErrInvalidAccountDataSize)
+}
+
+func TestDeserializeTokenAccount_Token2022(t *testing.T) {
+	mint := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	owner := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	data := make([]byte, TokenAccountSize)
+	copy(data[0:32], mint.Bytes())
+	copy(data[32:64], owner.Bytes())
+	binary.LittleEndian.PutUint64(data[64:72], 10)
+	data[108] = byte(TokenAccountStateInitialized)
+	data = append(data, byte(AccountTypeAccount))
+	data = append(data, tlv(ExtensionTypeImmutableOwner, nil)...)
+	data = append(data, tlv(ExtensionTypeTransferFeeAmount, []byte{3, 0, 0, 0, 0, 0, 0, 0})...)
+	data = append(data, tlv(ExtensionTypeCpiGuard, []byte{1})...)
+
+	got, err := DeserializeTokenAccount(data, common.Token2022ProgramID)
+	assert.NoError(t, err)
+	assert.Equal(t, mint, got.Mint)
+	assert.Equal(t, owner, got.Owner)
+	assert.Equal(t, uint64(10), got.Amount)
+	assert.Equal(t, []Extension{
+		{Type: ExtensionTypeImmutableOwner, Data: []byte{}, Value: ImmutableOwner{}},
+		{Type: ExtensionTypeTransferFeeAmount, Data: []byte{3, 0, 0, 0, 0, 0, 0, 0}, Value: TransferFeeAmount{WithheldAmount: 3}},
+		{Type: ExtensionTypeCpiGuard, Data: []byte{1}, Value: CpiGuard{LockCpi: true}},
+	}, got.Extensions)
+
+	_, err = DeserializeTokenAccount(data, common.TokenProgramID)
+	assert.Equal(t, ErrInvalidAccountDataSize, err)
+
+	got, err = DeserializeTokenAccount(data[:TokenAccountSize], common.Token2022ProgramID)
+	assert.NoError(t, err)
+	assert.Nil(t, got.Extensions)
+}
+
+func TestTransferFee_CalculateFee(t *testing.T) {
+	tests := []struct {
+		name   string
+		fee    TransferFee
+		amount uint64
+		want   uint64
+	}{
+		{name: "no fee", fee: TransferFee{MaximumFee: 100}, amount: 1000, want: 0},
+		{name: "round up", fee: TransferFee{TransferFeeBasisPoints: 50, MaximumFee: 100}, amount: 1001, want: 6},
+		{name: "maximum fee", fee: TransferFee{TransferFeeBasisPoints: 50, MaximumFee: 3}, amount: 1000, want: 3},
+		{name: "full fee", fee: TransferFee{TransferFeeBasisPoints: 10000, MaximumFee: 5000}, amount: 1000, want: 1000},
+		{name: "large amount", fee: TransferFee{TransferFeeBasisPoints: 9999, MaximumFee: ^uint64(0)}, amount: ^uint64(0), want: 18444899399302180660},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fee.CalculateFee(tt.amount))
+		})
+	}
+
+	config := TransferFeeConfig{
+		OlderTransferFee: TransferFee{Epoch: 0, TransferFeeBasisPoints: 1},
+		NewerTransferFee: TransferFee{Epoch: 10, TransferFeeBasisPoints: 2},
+	}
+	assert.Equal(t, uint16(1), config.GetEpochFee(9).TransferFeeBasisPoints)
+	assert.Equal(t, uint16(2), config.GetEpochFee(10).TransferFeeBasisPoints)
+}
@@ -30,6 +30,27 @@ const (
 	InstructionInitializeAccount3
 	InstructionInitializeMultisig2
 	InstructionInitializeMint2
+	InstructionGetAccountDataSize
+	InstructionInitializeImmutableOwner
+	InstructionAmountToUiAmount
+	InstructionUiAmountToAmount
+
+	// token-2022 only
+	InstructionInitializeMintCloseAuthority
+	InstructionTransferFeeExtension
+	InstructionConfidentialTransferExtension
+	InstructionDefaultAccountStateExtension
+	InstructionReallocate
+	InstructionMemoTransferExtension
+	InstructionCreateNativeMint
+	InstructionInitializeNonTransferableMint
+	InstructionInterestBearingMintExtension
+	InstructionCpiGuardExtension
+	InstructionInitializePermanentDelegate
+	InstructionTransferHookExtension
+	InstructionConfidentialTransferFeeExtension
+	InstructionWithdrawExcessLamports
+	InstructionMetadataPointerExtension
 )
 
 type InitializeMintParam struct {
@@ -37,6 +58,7 @@ type InitializeMintParam struct {
 	Mint       common.PublicKey
 	MintAuth   common.PublicKey
 	FreezeAuth *common.PublicKey
+	ProgramID  common.PublicKey
 }
 
 // InitializeMint init a mint, if you don't need to freeze, pass the empty pubKey common.PublicKey{}
@@ -63,7 +85,7 @@ func InitializeMint(param InitializeMintParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts: []types.AccountMeta{
 			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
 			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
@@ -73,9 +95,10 @@ func InitializeMint(param InitializeMintParam) types.Instruction {
 }
 
 type InitializeAccountParam struct {
-	Account common.PublicKey
-	Mint    common.PublicKey
-	Owner   common.PublicKey
+	Account   common.PublicKey
+	Mint      common.PublicKey
+	Owner     common.PublicKey
+	ProgramID common.PublicKey
 }
 
 // InitializeAccount init a token account which can receive token
@@ -96,7 +119,7 @@ func InitializeAccount(param InitializeAccountParam) types.Instruction {
 		{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
 	}
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
@@ -106,6 +129,7 @@ type InitializeMultisigParam struct {
 	Account     common.PublicKey
 	Signers     []common.PublicKey
 	MinRequired uint8
+	ProgramID   common.PublicKey
 }
 
 func InitializeMultisig(param InitializeMultisigParam) types.Instruction {
@@ -140,18 +164,19 @@ func InitializeMultisig(param InitializeMultisigParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type TransferParam struct {
-	From    common.PublicKey
-	To      common.PublicKey
-	Auth    common.PublicKey
-	Signers []common.PublicKey
-	Amount  uint64
+	From      common.PublicKey
+	To        common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	Amount    uint64
+	ProgramID common.PublicKey
 }
 
 func Transfer(param TransferParam) types.Instruction {
@@ -174,18 +199,19 @@ func Transfer(param TransferParam) types.Instruction {
 		accounts = append(accounts, types.AccountMeta{PubKey: signerPubkey, IsSigner: true, IsWritable: false})
 	}
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type ApproveParam struct {
-	From    common.PublicKey
-	To      common.PublicKey
-	Auth    common.PublicKey
-	Signers []common.PublicKey
-	Amount  uint64
+	From      common.PublicKey
+	To        common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	Amount    uint64
+	ProgramID common.PublicKey
 }
 
 func Approve(param ApproveParam) types.Instruction {
@@ -209,16 +235,17 @@ func Approve(param ApproveParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type RevokeParam struct {
-	From    common.PublicKey
-	Auth    common.PublicKey
-	Signers []common.PublicKey
+	From      common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	ProgramID common.PublicKey
 }
 
 func Revoke(param RevokeParam) types.Instruction {
@@ -241,7 +268,7 @@ func Revoke(param RevokeParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
@@ -254,14 +281,26 @@ const (
 	AuthorityTypeFreezeAccount
 	AuthorityTypeAccountOwner
 	AuthorityTypeCloseAccount
+
+	// token-2022 only
+	AuthorityTypeTransferFeeConfig
+	AuthorityTypeWithheldWithdraw
+	AuthorityTypeCloseMint
+	AuthorityTypeInterestRate
+	AuthorityTypePermanentDelegate
+	AuthorityTypeConfidentialTransferMint
+	AuthorityTypeTransferHookProgramID
+	AuthorityTypeConfidentialTransferFeeConfig
+	AuthorityTypeMetadataPointer
 )
 
 type SetAuthorityParam struct {
-	Account  common.PublicKey
-	NewAuth  *common.PublicKey
-	AuthType AuthorityType
-	Auth     common.PublicKey
-	Signers  []common.PublicKey
+	Account   common.PublicKey
+	NewAuth   *common.PublicKey
+	AuthType  AuthorityType
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	ProgramID common.PublicKey
 }
 
 func SetAuthority(param SetAuthorityParam) types.Instruction {
@@ -294,18 +333,19 @@ func SetAuthority(param SetAuthorityParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type MintToParam struct {
-	Mint    common.PublicKey
-	To      common.PublicKey
-	Auth    common.PublicKey
-	Signers []common.PublicKey
-	Amount  uint64
+	Mint      common.PublicKey
+	To        common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	Amount    uint64
+	ProgramID common.PublicKey
 }
 
 func MintTo(param MintToParam) types.Instruction {
@@ -331,18 +371,19 @@ func MintTo(param MintToParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type BurnParam struct {
-	Account common.PublicKey
-	Mint    common.PublicKey
-	Auth    common.PublicKey
-	Signers []common.PublicKey
-	Amount  uint64
+	Account   common.PublicKey
+	Mint      common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	Amount    uint64
+	ProgramID common.PublicKey
 }
 
 func Burn(param BurnParam) types.Instruction {
@@ -368,17 +409,18 @@ func Burn(param BurnParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type CloseAccountParam struct {
-	Account common.PublicKey
-	Auth    common.PublicKey
-	Signers []common.PublicKey
-	To      common.PublicKey
+	Account   common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	To        common.PublicKey
+	ProgramID common.PublicKey
 }
 
 // Close an account and transfer its all SOL to dest, only account's token balance is zero can be closed.
@@ -401,17 +443,18 @@ func CloseAccount(param CloseAccountParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type FreezeAccountParam struct {
-	Account common.PublicKey
-	Mint    common.PublicKey
-	Auth    common.PublicKey
-	Signers []common.PublicKey
+	Account   common.PublicKey
+	Mint      common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	ProgramID common.PublicKey
 }
 
 func FreezeAccount(param FreezeAccountParam) types.Instruction {
@@ -433,17 +476,18 @@ func FreezeAccount(param FreezeAccountParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type ThawAccountParam struct {
-	Account common.PublicKey
-	Mint    common.PublicKey
-	Auth    common.PublicKey
-	Signers []common.PublicKey
+	Account   common.PublicKey
+	Mint      common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	ProgramID common.PublicKey
 }
 
 func ThawAccount(param ThawAccountParam) types.Instruction {
@@ -465,20 +509,21 @@ func ThawAccount(param ThawAccountParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type TransferCheckedParam struct {
-	From     common.PublicKey
-	To       common.PublicKey
-	Mint     common.PublicKey
-	Auth     common.PublicKey
-	Signers  []common.PublicKey
-	Amount   uint64
-	Decimals uint8
+	From      common.PublicKey
+	To        common.PublicKey
+	Mint      common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	Amount    uint64
+	Decimals  uint8
+	ProgramID common.PublicKey
 }
 
 func TransferChecked(param TransferCheckedParam) types.Instruction {
@@ -505,20 +550,21 @@ func TransferChecked(param TransferCheckedParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type ApproveCheckedParam struct {
-	From     common.PublicKey
-	Mint     common.PublicKey
-	To       common.PublicKey
-	Auth     common.PublicKey
-	Signers  []common.PublicKey
-	Amount   uint64
-	Decimals uint8
+	From      common.PublicKey
+	Mint      common.PublicKey
+	To        common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	Amount    uint64
+	Decimals  uint8
+	ProgramID common.PublicKey
 }
 
 func ApproveChecked(param ApproveCheckedParam) types.Instruction {
@@ -545,19 +591,20 @@ func ApproveChecked(param ApproveCheckedParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type MintToCheckedParam struct {
-	Mint     common.PublicKey
-	Auth     common.PublicKey
-	Signers  []common.PublicKey
-	To       common.PublicKey
-	Amount   uint64
-	Decimals uint8
+	Mint      common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	To        common.PublicKey
+	Amount    uint64
+	Decimals  uint8
+	ProgramID common.PublicKey
 }
 
 func MintToChecked(param MintToCheckedParam) types.Instruction {
@@ -585,19 +632,20 @@ func MintToChecked(param MintToCheckedParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type BurnCheckedParam struct {
-	Account  common.PublicKey
-	Auth     common.PublicKey
-	Signers  []common.PublicKey
-	Mint     common.PublicKey
-	Amount   uint64
-	Decimals uint8
+	Account   common.PublicKey
+	Auth      common.PublicKey
+	Signers   []common.PublicKey
+	Mint      common.PublicKey
+	Amount    uint64
+	Decimals  uint8
+	ProgramID common.PublicKey
 }
 
 func BurnChecked(param BurnCheckedParam) types.Instruction {
@@ -625,16 +673,17 @@ func BurnChecked(param BurnCheckedParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 type InitializeAccount2Param struct {
-	Account common.PublicKey
-	Mint    common.PublicKey
-	Owner   common.PublicKey
+	Account   common.PublicKey
+	Mint      common.PublicKey
+	Owner     common.PublicKey
+	ProgramID common.PublicKey
 }
 
 func InitializeAccount2(param InitializeAccount2Param) types.Instruction {
@@ -650,7 +699,7 @@ func InitializeAccount2(param InitializeAccount2Param) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts: []types.AccountMeta{
 			{PubKey: param.Account, IsSigner: false, IsWritable: true},
 			{PubKey: param.Mint, IsSigner: false, IsWritable: false},
@@ -661,7 +710,8 @@ func InitializeAccount2(param InitializeAccount2Param) types.Instruction {
 }
 
 type SyncNativeParam struct {
-	Account common.PublicKey
+	Account   common.PublicKey
+	ProgramID common.PublicKey
 }
 
 // SyncNative will update your wrapped SOL balance
@@ -676,7 +726,7 @@ func SyncNative(param SyncNativeParam) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts: []types.AccountMeta{
 			{PubKey: param.Account, IsSigner: false, IsWritable: true},
 		},
@@ -685,9 +735,10 @@ func SyncNative(param SyncNativeParam) types.Instruction {
 }
 
 type InitializeAccount3Param struct {
-	Account common.PublicKey
-	Mint    common.PublicKey
-	Owner   common.PublicKey
+	Account   common.PublicKey
+	Mint      common.PublicKey
+	Owner     common.PublicKey
+	ProgramID common.PublicKey
 }
 
 func InitializeAccount3(param InitializeAccount3Param) types.Instruction {
@@ -703,7 +754,7 @@ func InitializeAccount3(param InitializeAccount3Param) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts: []types.AccountMeta{
 			{PubKey: param.Account, IsSigner: false, IsWritable: true},
 			{PubKey: param.Mint, IsSigner: false, IsWritable: false},
@@ -716,6 +767,7 @@ type InitializeMultisig2Param struct {
 	Account     common.PublicKey
 	Signers     []common.PublicKey
 	MinRequired uint8
+	ProgramID   common.PublicKey
 }
 
 func InitializeMultisig2(param InitializeMultisig2Param) types.Instruction {
@@ -749,7 +801,7 @@ func InitializeMultisig2(param InitializeMultisig2Param) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts:  accounts,
 		Data:      data,
 	}
@@ -760,6 +812,7 @@ type InitializeMint2Param struct {
 	Mint       common.PublicKey
 	MintAuth   common.PublicKey
 	FreezeAuth *common.PublicKey
+	ProgramID  common.PublicKey
 }
 
 func InitializeMint2(param InitializeMint2Param) types.Instruction {
@@ -785,10 +838,18 @@ func InitializeMint2(param InitializeMint2Param) types.Instruction {
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID(param.ProgramID),
 		Accounts: []types.AccountMeta{
 			{PubKey: param.Mint, IsSigner: false, IsWritable: true},
 		},
 		Data: data,
 	}
 }
+
+// programID returns the token program if id is empty, builders take a ProgramID to target the token-2022 program
+func programID(id common.PublicKey) common.PublicKey {
+	if id == (common.PublicKey{}) {
+		return common.TokenProgramID
+	}
+	return id
+}
@@ -61,11 +61,19 @@ type MintAccount struct {
 	Decimals        uint8
 	IsInitialized   bool
 	FreezeAuthority *common.PublicKey
+	// token-2022 only
+	Extensions []Extension
 }
 
+// MintAccountFromData decodes a mint, extensions are decoded if it is a token-2022 mint with extensions
 func MintAccountFromData(data []byte) (MintAccount, error) {
+	var extensions []Extension
 	if len(data) != MintAccountSize {
-		return MintAccount{}, ErrInvalidAccountDataSize
+		var err error
+		extensions, err = extensionsFromData(data, AccountTypeMint)
+		if err != nil {
+			return MintAccount{}, err
+		}
 	}
 
 	var mint *common.PublicKey
@@ -92,6 +100,7 @@ func MintAccountFromData(data []byte) (MintAccount, error) {
 		Decimals:        decimals,
 		IsInitialized:   isInitialized,
 		FreezeAuthority: freezeAuthority,
+		Extensions:      extensions,
 	}, nil
 }
 
@@ -116,11 +125,19 @@ type TokenAccount struct {
 	IsNative        *uint64
 	DelegatedAmount uint64
 	CloseAuthority  *common.PublicKey
+	// token-2022 only
+	Extensions []Extension
 }
 
+// TokenAccountFromData decodes a token account, extensions are decoded if it is a token-2022 account with extensions
 func TokenAccountFromData(data []byte) (TokenAccount, error) {
+	var extensions []Extension
 	if len(data) != TokenAccountSize {
-		return TokenAccount{}, ErrInvalidAccountDataSize
+		var err error
+		extensions, err = extensionsFromData(data, AccountTypeAccount)
+		if err != nil {
+			return TokenAccount{}, err
+		}
 	}
 
 	mint := common.PublicKeyFromBytes(data[:32])
@@ -160,12 +177,34 @@ func TokenAccountFromData(data []byte) (TokenAccount, error) {
 		IsNative:        isNative,
 		DelegatedAmount: delegatedAmount,
 		CloseAuthority:  closeAuthority,
+		Extensions:      extensions,
 	}, nil
 }
 
+// DeserializeTokenAccount decodes a token account of the token program or the token-2022 program
 func DeserializeTokenAccount(data []byte, accountOwner common.PublicKey) (TokenAccount, error) {
-	if accountOwner != common.TokenProgramID {
+	switch accountOwner {
+	case common.TokenProgramID:
+		if len(data) != TokenAccountSize {
+			return TokenAccount{}, ErrInvalidAccountDataSize
+		}
+	case common.Token2022ProgramID:
+	default:
 		return TokenAccount{}, ErrInvalidAccountOwner
 	}
 	return TokenAccountFromData(data)
 }
+
+// DeserializeMintAccount decodes a mint of the token program or the token-2022 program
+func DeserializeMintAccount(data []byte, accountOwner common.PublicKey) (MintAccount, error) {
+	switch accountOwner {
+	case common.TokenProgramID:
+		if len(data) != MintAccountSize {
+			return MintAccount{}, ErrInvalidAccountDataSize
+		}
+	case common.Token2022ProgramID:
+	default:
+		return MintAccount{}, ErrInvalidAccountOwner
+	}
+	return MintAccountFromData(data)
+}
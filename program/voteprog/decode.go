@@ -0,0 +1,191 @@
+package voteprog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// DecodeInstruction decodes a vote program instruction to its param, e.g. WithdrawParam for a withdraw instruction
+func DecodeInstruction(instruction types.Instruction) (interface{}, error) {
+	if instruction.ProgramID != common.VoteProgramID {
+		return nil, types.ErrProgramIDMismatch
+	}
+	if len(instruction.Data) < 4 {
+		return nil, fmt.Errorf("%w, data size is not enough for instruction type", types.ErrInvalidInstructionData)
+	}
+	accounts := instruction.Accounts
+	data := instruction.Data[4:]
+
+	switch Instruction(binary.LittleEndian.Uint32(instruction.Data[:4])) {
+	case InstructionInitializeAccount:
+		if err := check(accounts, 4, data, 97); err != nil {
+			return nil, err
+		}
+		return InitializeAccountParam{
+			Vote:                 accounts[0].PubKey,
+			Node:                 common.PublicKeyFromBytes(data[:32]),
+			AuthorizedVoter:      common.PublicKeyFromBytes(data[32:64]),
+			AuthorizedWithdrawer: common.PublicKeyFromBytes(data[64:96]),
+			Commission:           data[96],
+		}, nil
+
+	case InstructionAuthorize:
+		if err := check(accounts, 3, data, 36); err != nil {
+			return nil, err
+		}
+		return AuthorizeParam{
+			Vote:     accounts[0].PubKey,
+			Auth:     accounts[2].PubKey,
+			NewAuth:  common.PublicKeyFromBytes(data[:32]),
+			AuthType: VoteAuthorizationType(binary.LittleEndian.Uint32(data[32:36])),
+		}, nil
+
+	case InstructionVote:
+		if err := check(accounts, 4, data, 8); err != nil {
+			return nil, err
+		}
+		n := binary.LittleEndian.Uint64(data[:8])
+		data = data[8:]
+		if uint64(len(data))/8 < n {
+			return nil, fmt.Errorf("%w, data size is not enough for slots", types.ErrInvalidInstructionData)
+		}
+		slots := make([]uint64, 0, n)
+		for i := uint64(0); i < n; i++ {
+			slots = append(slots, binary.LittleEndian.Uint64(data[:8]))
+			data = data[8:]
+		}
+		hash, timestamp, err := decodeHashAndTimestamp(data)
+		if err != nil {
+			return nil, err
+		}
+		return VoteParam{
+			Vote:      accounts[0].PubKey,
+			Auth:      accounts[3].PubKey,
+			Slots:     slots,
+			Hash:      hash,
+			Timestamp: timestamp,
+		}, nil
+
+	case InstructionWithdraw:
+		if err := check(accounts, 3, data, 8); err != nil {
+			return nil, err
+		}
+		return WithdrawParam{
+			Vote:     accounts[0].PubKey,
+			Auth:     accounts[2].PubKey,
+			To:       accounts[1].PubKey,
+			Lamports: binary.LittleEndian.Uint64(data[:8]),
+		}, nil
+
+	case InstructionUpdateValidatorIdentity:
+		if err := check(accounts, 3, data, 0); err != nil {
+			return nil, err
+		}
+		return UpdateValidatorIdentityParam{
+			Vote:    accounts[0].PubKey,
+			Auth:    accounts[2].PubKey,
+			NewNode: accounts[1].PubKey,
+		}, nil
+
+	case InstructionUpdateCommission:
+		if err := check(accounts, 2, data, 1); err != nil {
+			return nil, err
+		}
+		return UpdateCommissionParam{
+			Vote:       accounts[0].PubKey,
+			Auth:       accounts[1].PubKey,
+			Commission: data[0],
+		}, nil
+
+	case InstructionAuthorizeChecked:
+		if err := check(accounts, 4, data, 4); err != nil {
+			return nil, err
+		}
+		return AuthorizeCheckedParam{
+			Vote:     accounts[0].PubKey,
+			Auth:     accounts[2].PubKey,
+			NewAuth:  accounts[3].PubKey,
+			AuthType: VoteAuthorizationType(binary.LittleEndian.Uint32(data[:4])),
+		}, nil
+
+	case InstructionCompactUpdateVoteState:
+		if err := check(accounts, 2, data, 8); err != nil {
+			return nil, err
+		}
+		var root *uint64
+		prevSlot := binary.LittleEndian.Uint64(data[:8])
+		if prevSlot != math.MaxUint64 {
+			v := prevSlot
+			root = &v
+		} else {
+			prevSlot = 0
+		}
+		data = data[8:]
+		n, l := binary.Uvarint(data)
+		if l <= 0 {
+			return nil, fmt.Errorf("%w, invalid lockouts length", types.ErrInvalidInstructionData)
+		}
+		data = data[l:]
+		// each lockout takes at least a 1-byte offset and a 1-byte confirmation count
+		if uint64(len(data))/2 < n {
+			return nil, fmt.Errorf("%w, data size is not enough for lockouts", types.ErrInvalidInstructionData)
+		}
+		lockouts := make([]Lockout, 0, n)
+		for i := uint64(0); i < n; i++ {
+			offset, l := binary.Uvarint(data)
+			if l <= 0 || len(data) < l+1 {
+				return nil, fmt.Errorf("%w, invalid lockout", types.ErrInvalidInstructionData)
+			}
+			prevSlot += offset
+			lockouts = append(lockouts, Lockout{Slot: prevSlot, ConfirmationCount: uint32(data[l])})
+			data = data[l+1:]
+		}
+		hash, timestamp, err := decodeHashAndTimestamp(data)
+		if err != nil {
+			return nil, err
+		}
+		return CompactUpdateVoteStateParam{
+			Vote:      accounts[0].PubKey,
+			Auth:      accounts[1].PubKey,
+			Root:      root,
+			Lockouts:  lockouts,
+			Hash:      hash,
+			Timestamp: timestamp,
+		}, nil
+	}
+
+	return nil, types.ErrUnknownInstruction
+}
+
+func check(accounts []types.AccountMeta, accountsLen int, data []byte, dataLen int) error {
+	if len(accounts) < accountsLen {
+		return fmt.Errorf("%w, expected %v, got %v", types.ErrNotEnoughAccounts, accountsLen, len(accounts))
+	}
+	if len(data) < dataLen {
+		return fmt.Errorf("%w, data size is not enough", types.ErrInvalidInstructionData)
+	}
+	return nil
+}
+
+func decodeHashAndTimestamp(data []byte) (string, *int64, error) {
+	if len(data) < 33 {
+		return "", nil, fmt.Errorf("%w, data size is not enough for hash and timestamp", types.ErrInvalidInstructionData)
+	}
+	hash := base58.Encode(data[:32])
+	switch data[32] {
+	case 0:
+		return hash, nil, nil
+	case 1:
+		if len(data) < 41 {
+			return "", nil, fmt.Errorf("%w, data size is not enough for timestamp", types.ErrInvalidInstructionData)
+		}
+		timestamp := int64(binary.LittleEndian.Uint64(data[33:41]))
+		return hash, &timestamp, nil
+	}
+	return "", nil, fmt.Errorf("%w, invalid timestamp", types.ErrInvalidInstructionData)
+}
@@ -0,0 +1,115 @@
+package voteprog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInstruction(t *testing.T) {
+	vote := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	other := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+	hash := "9aE476sH92Vz7DMPyq5WLPkrKWivxeuTKEFKd2sZZcde"
+
+	voteInstruction, err := Vote(VoteParam{Vote: vote, Auth: auth, Slots: []uint64{10, 11}, Hash: hash, Timestamp: pointer.Int64(1700000000)})
+	assert.Nil(t, err)
+	compactUpdateVoteStateInstruction, err := CompactUpdateVoteState(CompactUpdateVoteStateParam{
+		Vote:     vote,
+		Auth:     auth,
+		Root:     pointer.Uint64(100),
+		Lockouts: []Lockout{{Slot: 101, ConfirmationCount: 31}, {Slot: 1000, ConfirmationCount: 1}},
+		Hash:     hash,
+	})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        interface{}
+		err         error
+	}{
+		{
+			name:        "initialize account",
+			instruction: InitializeAccount(InitializeAccountParam{Vote: vote, Node: auth, AuthorizedVoter: auth, AuthorizedWithdrawer: other, Commission: 5}),
+			want:        InitializeAccountParam{Vote: vote, Node: auth, AuthorizedVoter: auth, AuthorizedWithdrawer: other, Commission: 5},
+		},
+		{
+			name:        "authorize",
+			instruction: Authorize(AuthorizeParam{Vote: vote, Auth: auth, NewAuth: other, AuthType: VoteAuthorizationTypeVoter}),
+			want:        AuthorizeParam{Vote: vote, Auth: auth, NewAuth: other, AuthType: VoteAuthorizationTypeVoter},
+		},
+		{
+			name:        "authorize checked",
+			instruction: AuthorizeChecked(AuthorizeCheckedParam{Vote: vote, Auth: auth, NewAuth: other, AuthType: VoteAuthorizationTypeWithdrawer}),
+			want:        AuthorizeCheckedParam{Vote: vote, Auth: auth, NewAuth: other, AuthType: VoteAuthorizationTypeWithdrawer},
+		},
+		{
+			name:        "vote",
+			instruction: voteInstruction,
+			want:        VoteParam{Vote: vote, Auth: auth, Slots: []uint64{10, 11}, Hash: hash, Timestamp: pointer.Int64(1700000000)},
+		},
+		{
+			name:        "withdraw",
+			instruction: Withdraw(WithdrawParam{Vote: vote, Auth: auth, To: other, Lamports: 100}),
+			want:        WithdrawParam{Vote: vote, Auth: auth, To: other, Lamports: 100},
+		},
+		{
+			name:        "update validator identity",
+			instruction: UpdateValidatorIdentity(UpdateValidatorIdentityParam{Vote: vote, Auth: auth, NewNode: other}),
+			want:        UpdateValidatorIdentityParam{Vote: vote, Auth: auth, NewNode: other},
+		},
+		{
+			name:        "update commission",
+			instruction: UpdateCommission(UpdateCommissionParam{Vote: vote, Auth: auth, Commission: 100}),
+			want:        UpdateCommissionParam{Vote: vote, Auth: auth, Commission: 100},
+		},
+		{
+			name:        "compact update vote state",
+			instruction: compactUpdateVoteStateInstruction,
+			want: CompactUpdateVoteStateParam{
+				Vote:     vote,
+				Auth:     auth,
+				Root:     pointer.Uint64(100),
+				Lockouts: []Lockout{{Slot: 101, ConfirmationCount: 31}, {Slot: 1000, ConfirmationCount: 1}},
+				Hash:     hash,
+			},
+		},
+		{
+			name:        "program id mismatch",
+			instruction: types.Instruction{ProgramID: common.StakeProgramID, Data: []byte{3, 0, 0, 0}},
+			err:         types.ErrProgramIDMismatch,
+		},
+		{
+			name:        "unknown instruction",
+			instruction: types.Instruction{ProgramID: common.VoteProgramID, Data: []byte{255, 0, 0, 0}},
+			err:         types.ErrUnknownInstruction,
+		},
+		{
+			name:        "invalid vote",
+			instruction: types.Instruction{ProgramID: common.VoteProgramID, Accounts: make([]types.AccountMeta, 4), Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "huge lockouts length",
+			instruction: types.Instruction{ProgramID: common.VoteProgramID, Accounts: make([]types.AccountMeta, 2), Data: []byte{12, 0, 0, 0, 100, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+			err:         types.ErrInvalidInstructionData,
+		},
+		{
+			name:        "not enough accounts",
+			instruction: types.Instruction{ProgramID: common.VoteProgramID, Accounts: make([]types.AccountMeta, 2), Data: []byte{3, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}},
+			err:         types.ErrNotEnoughAccounts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeInstruction(tt.instruction)
+			assert.True(t, errors.Is(err, tt.err), err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
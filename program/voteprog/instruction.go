@@ -0,0 +1,345 @@
+package voteprog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/bincode"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// AccountSize is the size of a vote account
+const AccountSize uint64 = 3762
+
+type Instruction uint32
+
+const (
+	InstructionInitializeAccount Instruction = iota
+	InstructionAuthorize
+	InstructionVote
+	InstructionWithdraw
+	InstructionUpdateValidatorIdentity
+	InstructionUpdateCommission
+	InstructionVoteSwitch
+	InstructionAuthorizeChecked
+	InstructionUpdateVoteState
+	InstructionUpdateVoteStateSwitch
+	InstructionAuthorizeWithSeed
+	InstructionAuthorizeCheckedWithSeed
+	InstructionCompactUpdateVoteState
+	InstructionCompactUpdateVoteStateSwitch
+	InstructionTowerSync
+	InstructionTowerSyncSwitch
+)
+
+type VoteAuthorizationType uint32
+
+const (
+	VoteAuthorizationTypeVoter VoteAuthorizationType = iota
+	VoteAuthorizationTypeWithdrawer
+)
+
+type InitializeAccountParam struct {
+	Vote                 common.PublicKey
+	Node                 common.PublicKey
+	AuthorizedVoter      common.PublicKey
+	AuthorizedWithdrawer common.PublicKey
+	Commission           uint8
+}
+
+func InitializeAccount(param InitializeAccountParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction          Instruction
+		Node                 common.PublicKey
+		AuthorizedVoter      common.PublicKey
+		AuthorizedWithdrawer common.PublicKey
+		Commission           uint8
+	}{
+		Instruction:          InstructionInitializeAccount,
+		Node:                 param.Node,
+		AuthorizedVoter:      param.AuthorizedVoter,
+		AuthorizedWithdrawer: param.AuthorizedWithdrawer,
+		Commission:           param.Commission,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Vote, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Node, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type AuthorizeParam struct {
+	Vote     common.PublicKey
+	Auth     common.PublicKey
+	NewAuth  common.PublicKey
+	AuthType VoteAuthorizationType
+}
+
+func Authorize(param AuthorizeParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction           Instruction
+		NewAuthorized         common.PublicKey
+		VoteAuthorizationType VoteAuthorizationType
+	}{
+		Instruction:           InstructionAuthorize,
+		NewAuthorized:         param.NewAuth,
+		VoteAuthorizationType: param.AuthType,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Vote, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type AuthorizeCheckedParam struct {
+	Vote     common.PublicKey
+	Auth     common.PublicKey
+	NewAuth  common.PublicKey
+	AuthType VoteAuthorizationType
+}
+
+// AuthorizeChecked is the same as Authorize but the new authority must sign
+func AuthorizeChecked(param AuthorizeCheckedParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction           Instruction
+		VoteAuthorizationType VoteAuthorizationType
+	}{
+		Instruction:           InstructionAuthorizeChecked,
+		VoteAuthorizationType: param.AuthType,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Vote, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+			{PubKey: param.NewAuth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type WithdrawParam struct {
+	Vote     common.PublicKey
+	Auth     common.PublicKey
+	To       common.PublicKey
+	Lamports uint64
+}
+
+func Withdraw(param WithdrawParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+		Lamports    uint64
+	}{
+		Instruction: InstructionWithdraw,
+		Lamports:    param.Lamports,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Vote, IsSigner: false, IsWritable: true},
+			{PubKey: param.To, IsSigner: false, IsWritable: true},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type UpdateValidatorIdentityParam struct {
+	Vote    common.PublicKey
+	Auth    common.PublicKey
+	NewNode common.PublicKey
+}
+
+// UpdateValidatorIdentity changes the node of the vote account, both the withdraw authority and the new node must sign
+func UpdateValidatorIdentity(param UpdateValidatorIdentityParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionUpdateValidatorIdentity,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Vote, IsSigner: false, IsWritable: true},
+			{PubKey: param.NewNode, IsSigner: true, IsWritable: false},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type UpdateCommissionParam struct {
+	Vote       common.PublicKey
+	Auth       common.PublicKey
+	Commission uint8
+}
+
+func UpdateCommission(param UpdateCommissionParam) types.Instruction {
+	data, err := bincode.SerializeData(struct {
+		Instruction Instruction
+		Commission  uint8
+	}{
+		Instruction: InstructionUpdateCommission,
+		Commission:  param.Commission,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Vote, IsSigner: false, IsWritable: true},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+type VoteParam struct {
+	Vote common.PublicKey
+	Auth common.PublicKey
+	// Slots are the voted slots in ascending order
+	Slots []uint64
+	// Hash is the base58 bank hash of the last slot
+	Hash      string
+	Timestamp *int64
+}
+
+func Vote(param VoteParam) (types.Instruction, error) {
+	hash, err := decodeHash(param.Hash)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+
+	data := appendUint32(nil, uint32(InstructionVote))
+	data = appendUint64(data, uint64(len(param.Slots)))
+	for _, slot := range param.Slots {
+		data = appendUint64(data, slot)
+	}
+	data = append(data, hash...)
+	data = appendTimestamp(data, param.Timestamp)
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Vote, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarSlotHashesPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}, nil
+}
+
+type CompactUpdateVoteStateParam struct {
+	Vote      common.PublicKey
+	Auth      common.PublicKey
+	Root      *uint64
+	Lockouts  []Lockout
+	Hash      string
+	Timestamp *int64
+}
+
+// CompactUpdateVoteState replaces the tower of the vote account. the lockouts are serialized as
+// offsets to the previous slot (or the root) to keep the transaction small.
+func CompactUpdateVoteState(param CompactUpdateVoteStateParam) (types.Instruction, error) {
+	hash, err := decodeHash(param.Hash)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+
+	data := appendUint32(nil, uint32(InstructionCompactUpdateVoteState))
+
+	root := uint64(math.MaxUint64)
+	prevSlot := uint64(0)
+	if param.Root != nil {
+		root = *param.Root
+		prevSlot = root
+	}
+	data = appendUint64(data, root)
+	data = append(data, bincode.UintToVarLenBytes(uint64(len(param.Lockouts)))...)
+	for _, lockout := range param.Lockouts {
+		if lockout.Slot < prevSlot || lockout.ConfirmationCount > math.MaxUint8 {
+			return types.Instruction{}, fmt.Errorf("invalid lockout, slot: %v, confirmation count: %v", lockout.Slot, lockout.ConfirmationCount)
+		}
+		data = append(data, bincode.UintToVarLenBytes(lockout.Slot-prevSlot)...)
+		data = append(data, uint8(lockout.ConfirmationCount))
+		prevSlot = lockout.Slot
+	}
+	data = append(data, hash...)
+	data = appendTimestamp(data, param.Timestamp)
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: param.Vote, IsSigner: false, IsWritable: true},
+			{PubKey: param.Auth, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}, nil
+}
+
+func decodeHash(hash string) ([]byte, error) {
+	b, err := base58.Decode(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hash, err: %v", err)
+	}
+	if len(b) != 32 {
+		return nil, fmt.Errorf("invalid hash length %v", len(b))
+	}
+	return b, nil
+}
+
+func appendTimestamp(data []byte, timestamp *int64) []byte {
+	if timestamp == nil {
+		return append(data, 0)
+	}
+	return appendUint64(append(data, 1), uint64(*timestamp))
+}
+
+func appendUint32(data []byte, v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return append(data, b...)
+}
+
+func appendUint64(data []byte, v uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return append(data, b...)
+}
@@ -0,0 +1,148 @@
+package voteprog
+
+import (
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstructionData(t *testing.T) {
+	vote := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	zeroHash := "11111111111111111111111111111111"
+
+	voteInstruction, err := Vote(VoteParam{Vote: vote, Auth: auth, Slots: []uint64{1, 2}, Hash: zeroHash, Timestamp: pointer.Int64(3)})
+	assert.Nil(t, err)
+	compactUpdateVoteStateInstruction, err := CompactUpdateVoteState(CompactUpdateVoteStateParam{
+		Vote:     vote,
+		Auth:     auth,
+		Root:     pointer.Uint64(100),
+		Lockouts: []Lockout{{Slot: 101, ConfirmationCount: 3}, {Slot: 102, ConfirmationCount: 2}, {Slot: 230, ConfirmationCount: 1}},
+		Hash:     zeroHash,
+	})
+	assert.Nil(t, err)
+	compactUpdateVoteStateWithoutRootInstruction, err := CompactUpdateVoteState(CompactUpdateVoteStateParam{Vote: vote, Auth: auth, Lockouts: []Lockout{{Slot: 5, ConfirmationCount: 1}}, Hash: zeroHash})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        []byte
+	}{
+		{
+			name:        "authorize checked",
+			instruction: AuthorizeChecked(AuthorizeCheckedParam{Vote: vote, Auth: auth, NewAuth: auth, AuthType: VoteAuthorizationTypeWithdrawer}),
+			want:        []byte{7, 0, 0, 0, 1, 0, 0, 0},
+		},
+		{
+			name:        "update commission",
+			instruction: UpdateCommission(UpdateCommissionParam{Vote: vote, Auth: auth, Commission: 10}),
+			want:        []byte{5, 0, 0, 0, 10},
+		},
+		{
+			name:        "vote",
+			instruction: voteInstruction,
+			want: append(
+				append([]byte{2, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}, make([]byte, 32)...),
+				1, 3, 0, 0, 0, 0, 0, 0, 0,
+			),
+		},
+		{
+			name:        "compact update vote state",
+			instruction: compactUpdateVoteStateInstruction,
+			want: append(
+				append([]byte{12, 0, 0, 0, 100, 0, 0, 0, 0, 0, 0, 0, 3, 1, 3, 1, 2, 0x80, 1, 1}, make([]byte, 32)...),
+				0,
+			),
+		},
+		{
+			name:        "compact update vote state without root",
+			instruction: compactUpdateVoteStateWithoutRootInstruction,
+			want: append(
+				append([]byte{12, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 1, 5, 1}, make([]byte, 32)...),
+				0,
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, common.VoteProgramID, tt.instruction.ProgramID)
+			assert.Equal(t, tt.want, tt.instruction.Data)
+		})
+	}
+}
+
+func TestInvalidVoteParam(t *testing.T) {
+	vote := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	zeroHash := "11111111111111111111111111111111"
+
+	tests := []struct {
+		name string
+		fn   func() (types.Instruction, error)
+	}{
+		{
+			name: "vote with invalid base58 hash",
+			fn: func() (types.Instruction, error) {
+				return Vote(VoteParam{Vote: vote, Auth: auth, Slots: []uint64{1}, Hash: "0OIl"})
+			},
+		},
+		{
+			name: "vote with short hash",
+			fn: func() (types.Instruction, error) {
+				return Vote(VoteParam{Vote: vote, Auth: auth, Slots: []uint64{1}, Hash: "1111"})
+			},
+		},
+		{
+			name: "compact update vote state with invalid hash",
+			fn: func() (types.Instruction, error) {
+				return CompactUpdateVoteState(CompactUpdateVoteStateParam{Vote: vote, Auth: auth, Lockouts: []Lockout{{Slot: 5, ConfirmationCount: 1}}, Hash: ""})
+			},
+		},
+		{
+			name: "compact update vote state with unsorted lockouts",
+			fn: func() (types.Instruction, error) {
+				return CompactUpdateVoteState(CompactUpdateVoteStateParam{Vote: vote, Auth: auth, Lockouts: []Lockout{{Slot: 5, ConfirmationCount: 2}, {Slot: 4, ConfirmationCount: 1}}, Hash: zeroHash})
+			},
+		},
+		{
+			name: "compact update vote state with lockout before root",
+			fn: func() (types.Instruction, error) {
+				return CompactUpdateVoteState(CompactUpdateVoteStateParam{Vote: vote, Auth: auth, Root: pointer.Uint64(10), Lockouts: []Lockout{{Slot: 5, ConfirmationCount: 1}}, Hash: zeroHash})
+			},
+		},
+		{
+			name: "compact update vote state with confirmation count over 255",
+			fn: func() (types.Instruction, error) {
+				return CompactUpdateVoteState(CompactUpdateVoteStateParam{Vote: vote, Auth: auth, Lockouts: []Lockout{{Slot: 5, ConfirmationCount: 256}}, Hash: zeroHash})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				_, err := tt.fn()
+				assert.NotNil(t, err)
+			})
+		})
+	}
+}
+
+func TestUpdateValidatorIdentity(t *testing.T) {
+	vote := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	node := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+
+	assert.Equal(t, types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: vote, IsSigner: false, IsWritable: true},
+			{PubKey: node, IsSigner: true, IsWritable: false},
+			{PubKey: auth, IsSigner: true, IsWritable: false},
+		},
+		Data: []byte{4, 0, 0, 0},
+	}, UpdateValidatorIdentity(UpdateValidatorIdentityParam{Vote: vote, Auth: auth, NewNode: node}))
+}
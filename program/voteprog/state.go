@@ -0,0 +1,258 @@
+package voteprog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+var ErrInvalidAccountDataSize = errors.New("invalid account data size")
+
+type VoteStateVersion uint32
+
+const (
+	VoteStateVersionV0_23_5 VoteStateVersion = iota
+	VoteStateVersionV1_14_11
+	VoteStateVersionCurrent
+)
+
+type Lockout struct {
+	Slot              uint64
+	ConfirmationCount uint32
+}
+
+type LandedVote struct {
+	// Latency is always 0 for the versions before VoteStateVersionCurrent
+	Latency uint8
+	Lockout
+}
+
+type AuthorizedVoter struct {
+	Epoch  uint64
+	Pubkey common.PublicKey
+}
+
+type EpochCredits struct {
+	Epoch       uint64
+	Credits     uint64
+	PrevCredits uint64
+}
+
+// Earned returns the credits earned in the epoch
+func (e EpochCredits) Earned() uint64 {
+	return e.Credits - e.PrevCredits
+}
+
+type BlockTimestamp struct {
+	Slot      uint64
+	Timestamp int64
+}
+
+// VoteState is the state of a vote account. all versions are converted to the current layout.
+type VoteState struct {
+	Version              VoteStateVersion
+	NodePubkey           common.PublicKey
+	AuthorizedWithdrawer common.PublicKey
+	Commission           uint8
+	Votes                []LandedVote
+	RootSlot             *uint64
+	AuthorizedVoters     []AuthorizedVoter
+	EpochCredits         []EpochCredits
+	LastTimestamp        BlockTimestamp
+}
+
+// Credits returns the total credits the vote account has earned
+func (s VoteState) Credits() uint64 {
+	if len(s.EpochCredits) == 0 {
+		return 0
+	}
+	return s.EpochCredits[len(s.EpochCredits)-1].Credits
+}
+
+// GetAuthorizedVoter returns the authorized voter at the epoch
+func (s VoteState) GetAuthorizedVoter(epoch uint64) (common.PublicKey, bool) {
+	// authorized voters are sorted by epoch, the latest one which is not after the epoch is in effect
+	for i := len(s.AuthorizedVoters) - 1; i >= 0; i-- {
+		if s.AuthorizedVoters[i].Epoch <= epoch {
+			return s.AuthorizedVoters[i].Pubkey, true
+		}
+	}
+	return common.PublicKey{}, false
+}
+
+const (
+	// prior voters is a circular buffer of 32 (pubkey, epoch start, epoch end) and an index
+	priorVotersSize = 32*(32+8+8) + 8 + 1
+	// the v0.23.5 one has an additional slot in each item and no is empty flag
+	priorVotersV0_23_5Size = 32*(32+8+8+8) + 8
+)
+
+func VoteStateDeserialize(data []byte) (VoteState, error) {
+	r := reader{data: data}
+	state := VoteState{
+		Version: VoteStateVersion(r.uint32()),
+	}
+
+	switch state.Version {
+	case VoteStateVersionV0_23_5:
+		state.NodePubkey = r.pubkey()
+		authorizedVoter := r.pubkey()
+		authorizedVoterEpoch := r.uint64()
+		state.AuthorizedVoters = []AuthorizedVoter{{Epoch: authorizedVoterEpoch, Pubkey: authorizedVoter}}
+		r.skip(priorVotersV0_23_5Size)
+		state.AuthorizedWithdrawer = r.pubkey()
+		state.Commission = r.uint8()
+		state.Votes = r.votes(false)
+		state.RootSlot = r.optionalUint64()
+	case VoteStateVersionV1_14_11, VoteStateVersionCurrent:
+		state.NodePubkey = r.pubkey()
+		state.AuthorizedWithdrawer = r.pubkey()
+		state.Commission = r.uint8()
+		state.Votes = r.votes(state.Version == VoteStateVersionCurrent)
+		state.RootSlot = r.optionalUint64()
+		state.AuthorizedVoters = r.authorizedVoters()
+		r.skip(priorVotersSize)
+	default:
+		if r.err != nil {
+			return VoteState{}, r.err
+		}
+		return VoteState{}, fmt.Errorf("unknown vote state version %v", state.Version)
+	}
+	state.EpochCredits = r.epochCredits()
+	state.LastTimestamp = BlockTimestamp{
+		Slot:      r.uint64(),
+		Timestamp: int64(r.uint64()),
+	}
+
+	if r.err != nil {
+		return VoteState{}, r.err
+	}
+	return state, nil
+}
+
+// reader reads bincode values in order, it keeps the first error and returns zero values after that
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.data) < n {
+		r.err = ErrInvalidAccountDataSize
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) skip(n int) {
+	r.next(n)
+}
+
+func (r *reader) uint8() uint8 {
+	b := r.next(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *reader) uint32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (r *reader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (r *reader) pubkey() common.PublicKey {
+	b := r.next(32)
+	if b == nil {
+		return common.PublicKey{}
+	}
+	return common.PublicKeyFromBytes(b)
+}
+
+func (r *reader) optionalUint64() *uint64 {
+	switch r.uint8() {
+	case 0:
+		return nil
+	case 1:
+		v := r.uint64()
+		return &v
+	}
+	if r.err == nil {
+		r.err = errors.New("invalid option")
+	}
+	return nil
+}
+
+// length reads a u64 length prefix and makes sure there is enough data for the items
+func (r *reader) length(itemSize int) int {
+	n := r.uint64()
+	if r.err == nil && n > uint64(len(r.data)/itemSize) {
+		r.err = ErrInvalidAccountDataSize
+	}
+	if r.err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+func (r *reader) votes(withLatency bool) []LandedVote {
+	itemSize := 12
+	if withLatency {
+		itemSize = 13
+	}
+	n := r.length(itemSize)
+	votes := make([]LandedVote, 0, n)
+	for i := 0; i < n; i++ {
+		var vote LandedVote
+		if withLatency {
+			vote.Latency = r.uint8()
+		}
+		vote.Slot = r.uint64()
+		vote.ConfirmationCount = r.uint32()
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+func (r *reader) authorizedVoters() []AuthorizedVoter {
+	n := r.length(40)
+	voters := make([]AuthorizedVoter, 0, n)
+	for i := 0; i < n; i++ {
+		voters = append(voters, AuthorizedVoter{
+			Epoch:  r.uint64(),
+			Pubkey: r.pubkey(),
+		})
+	}
+	return voters
+}
+
+func (r *reader) epochCredits() []EpochCredits {
+	n := r.length(24)
+	credits := make([]EpochCredits, 0, n)
+	for i := 0; i < n; i++ {
+		credits = append(credits, EpochCredits{
+			Epoch:       r.uint64(),
+			Credits:     r.uint64(),
+			PrevCredits: r.uint64(),
+		})
+	}
+	return credits
+}
@@ -0,0 +1,165 @@
+package voteprog
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func u32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+func u64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return b
+}
+
+func concat(bs ...[]byte) []byte {
+	var data []byte
+	for _, b := range bs {
+		data = append(data, b...)
+	}
+	return data
+}
+
+func TestVoteStateDeserialize(t *testing.T) {
+	node := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	voter := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	withdrawer := common.PublicKeyFromString("DuNVVSmxNkXZvzxKXzcpbKpFHtJm1tWzAkaEJNaX5B4t")
+
+	tail := concat(
+		// epoch credits
+		u64(2), u64(9), u64(100), u64(50), u64(10), u64(180), u64(100),
+		// last timestamp
+		u64(1000), u64(1700000000),
+	)
+	wantTail := VoteState{
+		EpochCredits: []EpochCredits{
+			{Epoch: 9, Credits: 100, PrevCredits: 50},
+			{Epoch: 10, Credits: 180, PrevCredits: 100},
+		},
+		LastTimestamp: BlockTimestamp{Slot: 1000, Timestamp: 1700000000},
+	}
+
+	current := concat(
+		u32(2), node.Bytes(), withdrawer.Bytes(), []byte{7},
+		// votes with latency
+		u64(2), []byte{1}, u64(998), u32(2), []byte{0}, u64(999), u32(1),
+		// root slot
+		[]byte{1}, u64(900),
+		// authorized voters
+		u64(2), u64(10), voter.Bytes(), u64(12), node.Bytes(),
+		make([]byte, priorVotersSize),
+		tail,
+	)
+	v1_14_11 := concat(
+		u32(1), node.Bytes(), withdrawer.Bytes(), []byte{7},
+		u64(1), u64(999), u32(1),
+		[]byte{0},
+		u64(1), u64(10), voter.Bytes(),
+		make([]byte, priorVotersSize),
+		tail,
+	)
+	v0_23_5 := concat(
+		u32(0), node.Bytes(), voter.Bytes(), u64(10),
+		make([]byte, priorVotersV0_23_5Size),
+		withdrawer.Bytes(), []byte{7},
+		u64(1), u64(999), u32(1),
+		[]byte{0},
+		tail,
+	)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    VoteState
+		wantErr bool
+	}{
+		{
+			name: "current",
+			data: current,
+			want: VoteState{
+				Version:              VoteStateVersionCurrent,
+				NodePubkey:           node,
+				AuthorizedWithdrawer: withdrawer,
+				Commission:           7,
+				Votes: []LandedVote{
+					{Latency: 1, Lockout: Lockout{Slot: 998, ConfirmationCount: 2}},
+					{Lockout: Lockout{Slot: 999, ConfirmationCount: 1}},
+				},
+				RootSlot:         pointer.Uint64(900),
+				AuthorizedVoters: []AuthorizedVoter{{Epoch: 10, Pubkey: voter}, {Epoch: 12, Pubkey: node}},
+				EpochCredits:     wantTail.EpochCredits,
+				LastTimestamp:    wantTail.LastTimestamp,
+			},
+		},
+		{
+			name: "v1.14.11",
+			data: v1_14_11,
+			want: VoteState{
+				Version:              VoteStateVersionV1_14_11,
+				NodePubkey:           node,
+				AuthorizedWithdrawer: withdrawer,
+				Commission:           7,
+				Votes:                []LandedVote{{Lockout: Lockout{Slot: 999, ConfirmationCount: 1}}},
+				AuthorizedVoters:     []AuthorizedVoter{{Epoch: 10, Pubkey: voter}},
+				EpochCredits:         wantTail.EpochCredits,
+				LastTimestamp:        wantTail.LastTimestamp,
+			},
+		},
+		{
+			name: "v0.23.5",
+			data: v0_23_5,
+			want: VoteState{
+				Version:              VoteStateVersionV0_23_5,
+				NodePubkey:           node,
+				AuthorizedWithdrawer: withdrawer,
+				Commission:           7,
+				Votes:                []LandedVote{{Lockout: Lockout{Slot: 999, ConfirmationCount: 1}}},
+				AuthorizedVoters:     []AuthorizedVoter{{Epoch: 10, Pubkey: voter}},
+				EpochCredits:         wantTail.EpochCredits,
+				LastTimestamp:        wantTail.LastTimestamp,
+			},
+		},
+		{
+			name:    "data size is not enough",
+			data:    current[:len(current)-1],
+			wantErr: true,
+		},
+		{
+			name:    "invalid votes length",
+			data:    concat(u32(2), node.Bytes(), withdrawer.Bytes(), []byte{7}, u64(1<<60)),
+			wantErr: true,
+		},
+		{
+			name:    "unknown version",
+			data:    u32(9),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VoteStateDeserialize(tt.data)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	state, err := VoteStateDeserialize(current)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(180), state.Credits())
+	assert.Equal(t, uint64(80), state.EpochCredits[1].Earned())
+	_, ok := state.GetAuthorizedVoter(9)
+	assert.False(t, ok)
+	got, _ := state.GetAuthorizedVoter(11)
+	assert.Equal(t, voter, got)
+	got, _ = state.GetAuthorizedVoter(12)
+	assert.Equal(t, node, got)
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// BatchRequest is a single call of a batch request
+type BatchRequest struct {
+	Method string
+	Params []interface{}
+}
+
+// NewBatchRequest creates a BatchRequest, params are the same as Call's
+func NewBatchRequest(method string, params ...interface{}) BatchRequest {
+	return BatchRequest{
+		Method: method,
+		Params: params,
+	}
+}
+
+// BatchCall sends all requests in a single json-rpc batch and returns the body of each response in the order of requests.
+// each body can be decoded as its own response, e.g. GetAccountInfoResponse, the error of a single request is in its GeneralResponse.
+func (c *RpcClient) BatchCall(ctx context.Context, requests []BatchRequest) ([][]byte, error) {
+	if len(requests) == 0 {
+		return [][]byte{}, nil
+	}
+
+	// prepare payload, ids are the positions of requests
+	payload := make([]jsonRpcRequest, 0, len(requests))
+	methods := make([]string, 0, len(requests))
+	for i, r := range requests {
+		methods = append(methods, r.Method)
+		payload = append(payload, jsonRpcRequest{
+			JsonRpc: "2.0",
+			Id:      uint64(i + 1),
+			Method:  r.Method,
+			Params:  r.Params,
+		})
+	}
+	j, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare payload, err: %v", err)
+	}
+
+	body, err := c.post(ctx, Request{Methods: methods, Payload: j})
+	if err != nil {
+		return nil, fmt.Errorf("rpc: call error, err: %w, body: %v", err, string(body))
+	}
+
+	// the whole batch is rejected, e.g. the node doesn't support batch requests
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var res GeneralResponse
+		if err := json.Unmarshal(trimmed, &res); err != nil {
+			return nil, fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+		}
+		if res.Error != nil {
+			return nil, fmt.Errorf("rpc: batch request failed, code: %v, message: %v", res.Error.Code, res.Error.Message)
+		}
+		return nil, fmt.Errorf("rpc: unexpected batch response, body: %v", string(body))
+	}
+
+	var rawResponses []json.RawMessage
+	if err := json.Unmarshal(body, &rawResponses); err != nil {
+		return nil, fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+	}
+
+	// responses may come in any order
+	output := make([][]byte, len(requests))
+	for _, raw := range rawResponses {
+		var res GeneralResponse
+		if err := json.Unmarshal(raw, &res); err != nil {
+			return nil, fmt.Errorf("rpc: failed to json decode body, err: %v", err)
+		}
+		if res.ID == 0 || res.ID > uint64(len(requests)) {
+			return nil, fmt.Errorf("rpc: unexpected response id: %v", res.ID)
+		}
+		if output[res.ID-1] != nil {
+			return nil, fmt.Errorf("rpc: duplicate response id: %v", res.ID)
+		}
+		output[res.ID-1] = raw
+	}
+	for i := range output {
+		if output[i] == nil {
+			return nil, fmt.Errorf("rpc: missing response of request %v, method: %v", i, requests[i].Method)
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBatchCall(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `[{"jsonrpc":"2.0", "id":1, "method":"getBalance", "params":["RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"]},{"jsonrpc":"2.0", "id":2, "method":"getSlot"},{"jsonrpc":"2.0", "id":3, "method":"getBalance", "params":["invalid", {"commitment":"finalized"}]}]`,
+			ResponseBody: `[{"jsonrpc":"2.0","result":1,"id":2},{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":3},{"jsonrpc":"2.0","result":{"context":{"slot":73914708},"value":6999995000},"id":1}]`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.BatchCall(
+					context.TODO(),
+					[]BatchRequest{
+						NewBatchRequest("getBalance", "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7"),
+						NewBatchRequest("getSlot"),
+						NewBatchRequest("getBalance", "invalid", GetBalanceConfig{Commitment: CommitmentFinalized}),
+					},
+				)
+			},
+			ExpectedResponse: [][]byte{
+				[]byte(`{"jsonrpc":"2.0","result":{"context":{"slot":73914708},"value":6999995000},"id":1}`),
+				[]byte(`{"jsonrpc":"2.0","result":1,"id":2}`),
+				[]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param: Invalid"},"id":3}`),
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `[{"jsonrpc":"2.0", "id":1, "method":"getSlot"},{"jsonrpc":"2.0", "id":2, "method":"getSlot"}]`,
+			ResponseBody: `[{"jsonrpc":"2.0","result":1,"id":2}]`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.BatchCall(
+					context.TODO(),
+					[]BatchRequest{
+						NewBatchRequest("getSlot"),
+						NewBatchRequest("getSlot"),
+					},
+				)
+			},
+			ExpectedResponse: [][]byte(nil),
+			ExpectedError:    errors.New("rpc: missing response of request 0, method: getSlot"),
+		},
+		{
+			RequestBody:  `[{"jsonrpc":"2.0", "id":1, "method":"getSlot"}]`,
+			ResponseBody: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.BatchCall(
+					context.TODO(),
+					[]BatchRequest{
+						NewBatchRequest("getSlot"),
+					},
+				)
+			},
+			ExpectedResponse: [][]byte(nil),
+			ExpectedError:    errors.New("rpc: batch request failed, code: -32600, message: Invalid request"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httputil"
+	"time"
 )
 
 const (
@@ -46,9 +47,24 @@ type GeneralResponse struct {
 }
 
 type RpcClient struct {
-	endpoint   string
-	httpClient *http.Client
-	debug      bool
+	endpoint    string
+	httpClient  *http.Client
+	debug       bool
+	middlewares []Middleware
+	retry       Middleware
+	rateLimit   Middleware
+	pool        *EndpointPool
+}
+
+// HTTPError is returned by Call if http code beyond 200~300
+type HTTPError struct {
+	StatusCode int
+	// RetryAfter is parsed from the Retry-After header, zero if it is absent
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("get status code: %v", e.StatusCode)
 }
 
 func NewRpcClient(endpoint string) RpcClient { return New(WithEndpoint(endpoint)) }
@@ -79,9 +95,33 @@ func (c *RpcClient) Call(ctx context.Context, params ...interface{}) ([]byte, er
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare payload, err: %v", err)
 	}
+	return c.post(ctx, Request{Methods: []string{params[0].(string)}, Payload: j})
+}
 
+// post passes the request through middlewares and sends it
+func (c *RpcClient) post(ctx context.Context, r Request) ([]byte, error) {
+	handler := Handler(func(ctx context.Context, r Request) ([]byte, error) {
+		return c.send(ctx, c.endpoint, r)
+	})
+	if c.pool != nil {
+		handler = c.pool.handler(c.send)
+	}
+	if c.rateLimit != nil {
+		handler = c.rateLimit(handler)
+	}
+	if c.retry != nil {
+		handler = c.retry(handler)
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+	return handler(ctx, r)
+}
+
+// send posts the payload to the endpoint and returns body of response
+func (c *RpcClient) send(ctx context.Context, endpoint string, r Request) ([]byte, error) {
 	// prepare request
-	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(j))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(r.Payload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to do http.NewRequestWithContext, err: %v", err)
 	}
@@ -132,7 +172,10 @@ func (c *RpcClient) Call(ctx context.Context, params ...interface{}) ([]byte, er
 				log.Println("response", string(content))
 			}
 		}
-		return body, fmt.Errorf("get status code: %v", res.StatusCode)
+		return body, &HTTPError{
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+		}
 	}
 
 	return body, nil
@@ -161,7 +204,7 @@ func preparePayload(params []interface{}) ([]byte, error) {
 
 func (c *RpcClient) processRpcCall(body []byte, rpcErr error, res interface{}) error {
 	if rpcErr != nil {
-		return fmt.Errorf("rpc: call error, err: %v, body: %v", rpcErr, string(body))
+		return fmt.Errorf("rpc: call error, err: %w, body: %v", rpcErr, string(body))
 	}
 	err := json.Unmarshal(body, &res)
 	if err != nil {
@@ -1,7 +1,12 @@
 package rpc
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -53,3 +58,39 @@ func Test_preparePayload(t *testing.T) {
 		})
 	}
 }
+
+func TestRpcClient_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Retry-After", "3")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	c := New(WithEndpoint(server.URL))
+
+	tests := []struct {
+		name    string
+		rpcCall func() error
+	}{
+		{
+			name: "call",
+			rpcCall: func() error {
+				_, err := c.GetSlot(context.Background())
+				return err
+			},
+		},
+		{
+			name: "batch call",
+			rpcCall: func() error {
+				_, err := c.BatchCall(context.Background(), []BatchRequest{NewBatchRequest("getSlot")})
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var httpErr *HTTPError
+			assert.True(t, errors.As(tt.rpcCall(), &httpErr))
+			assert.Equal(t, &HTTPError{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second}, httpErr)
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Endpoint is a rpc endpoint of an EndpointPool, requests are balanced by Weight
+type Endpoint struct {
+	URL string
+	// Weight default is 1
+	Weight int
+}
+
+type EndpointPoolConfig struct {
+	Endpoints []Endpoint
+	// HTTPClient is used by health checks, default is a bare bone http client
+	HTTPClient *http.Client
+	// HealthCheckInterval is how often getHealth and getSlot are checked, default is 10s
+	HealthCheckInterval time.Duration
+	// MaxSlotLag is how many slots an endpoint can be behind the highest one before it is routed around, default is 50
+	MaxSlotLag uint64
+	// MaxConsecutiveFailures marks an endpoint unhealthy until the next health check, default is 3
+	MaxConsecutiveFailures int
+	// BroadcastSendTransaction sends sendTransaction to all available endpoints concurrently
+	BroadcastSendTransaction bool
+}
+
+// EndpointStats is a snapshot of an endpoint in an EndpointPool
+type EndpointStats struct {
+	URL             string
+	Weight          int
+	Healthy         bool
+	Slot            uint64
+	SlotsBehind     uint64
+	Requests        uint64
+	Failures        uint64
+	AverageLatency  time.Duration
+	LastError       error
+	LastHealthCheck time.Time
+}
+
+type endpointState struct {
+	url    string
+	weight int
+	rpc    RpcClient
+
+	// guarded by EndpointPool.mu
+	healthy             bool
+	slot                uint64
+	consecutiveFailures int
+	requests            uint64
+	failures            uint64
+	totalLatency        time.Duration
+	lastError           error
+	lastHealthCheck     time.Time
+}
+
+// EndpointPool balances requests between several endpoints, routes around unhealthy or lagging ones and fails over on errors.
+// use it by WithEndpointPool, the pool can be shared by many clients.
+type EndpointPool struct {
+	cfg       EndpointPoolConfig
+	endpoints []*endpointState
+
+	mu        sync.Mutex
+	closeOnce sync.Once
+	closed    chan struct{}
+	done      chan struct{}
+}
+
+// NewEndpointPool creates a pool and starts health checks in background. Close stops them.
+func NewEndpointPool(cfg EndpointPoolConfig) (*EndpointPool, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, errors.New("no endpoints")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{}
+	}
+	if cfg.HealthCheckInterval == 0 {
+		cfg.HealthCheckInterval = 10 * time.Second
+	}
+	if cfg.MaxSlotLag == 0 {
+		cfg.MaxSlotLag = 50
+	}
+	if cfg.MaxConsecutiveFailures == 0 {
+		cfg.MaxConsecutiveFailures = 3
+	}
+
+	p := &EndpointPool{
+		cfg:       cfg,
+		endpoints: make([]*endpointState, 0, len(cfg.Endpoints)),
+		closed:    make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	for _, e := range cfg.Endpoints {
+		weight := e.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		p.endpoints = append(p.endpoints, &endpointState{
+			url:     e.URL,
+			weight:  weight,
+			rpc:     New(WithEndpoint(e.URL), WithHTTPClient(cfg.HTTPClient)),
+			healthy: true,
+		})
+	}
+	go p.runHealthCheck()
+	return p, nil
+}
+
+// WithEndpointPool is an Option that sends requests through the pool instead of the single endpoint
+func WithEndpointPool(p *EndpointPool) Option {
+	return func(r *RpcClient) {
+		r.pool = p
+	}
+}
+
+// Close stops health checks
+func (p *EndpointPool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.closed)
+	})
+	<-p.done
+}
+
+// Stats returns a snapshot of all endpoints in the order of config
+func (p *EndpointPool) Stats() []EndpointStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	highestSlot := p.highestSlot()
+	output := make([]EndpointStats, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		stats := EndpointStats{
+			URL:             e.url,
+			Weight:          e.weight,
+			Healthy:         e.healthy,
+			Slot:            e.slot,
+			Requests:        e.requests,
+			Failures:        e.failures,
+			LastError:       e.lastError,
+			LastHealthCheck: e.lastHealthCheck,
+		}
+		if e.slot < highestSlot {
+			stats.SlotsBehind = highestSlot - e.slot
+		}
+		if e.requests > 0 {
+			stats.AverageLatency = e.totalLatency / time.Duration(e.requests)
+		}
+		output = append(output, stats)
+	}
+	return output
+}
+
+func (p *EndpointPool) handler(send func(ctx context.Context, endpoint string, r Request) ([]byte, error)) Handler {
+	return func(ctx context.Context, r Request) ([]byte, error) {
+		if p.cfg.BroadcastSendTransaction && containsMethod(r.Methods, "sendTransaction") {
+			return p.broadcast(ctx, r, send)
+		}
+
+		var body []byte
+		var err error
+		tried := map[*endpointState]bool{}
+		for len(tried) < len(p.endpoints) {
+			e := p.pick(tried)
+			tried[e] = true
+			body, err = p.do(ctx, e, r, send)
+			if err == nil || ctx.Err() != nil {
+				break
+			}
+		}
+		if err == errEndpointBehind {
+			return body, nil
+		}
+		return body, err
+	}
+}
+
+// broadcast sends the request to all available endpoints and waits for all of them.
+// it returns the first successful response.
+func (p *EndpointPool) broadcast(ctx context.Context, r Request, send func(ctx context.Context, endpoint string, r Request) ([]byte, error)) ([]byte, error) {
+	p.mu.Lock()
+	endpoints := p.available(nil)
+	p.mu.Unlock()
+
+	type result struct {
+		body []byte
+		err  error
+	}
+	results := make([]result, len(endpoints))
+	var wg sync.WaitGroup
+	for i := range endpoints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body, err := p.do(ctx, endpoints[i], r, send)
+			results[i] = result{body: body, err: err}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, res := range results {
+		if res.err == nil {
+			return res.body, nil
+		}
+	}
+	for _, res := range results {
+		if res.err == errEndpointBehind {
+			return res.body, nil
+		}
+	}
+	return results[0].body, results[0].err
+}
+
+// do sends the request to the endpoint and records the result
+func (p *EndpointPool) do(ctx context.Context, e *endpointState, r Request, send func(ctx context.Context, endpoint string, r Request) ([]byte, error)) ([]byte, error) {
+	start := time.Now()
+	body, err := send(ctx, e.url, r)
+	latency := time.Since(start)
+	if err == nil && hasRetryableError(body, map[int]bool{ErrorCodeNodeUnhealthy: true}) {
+		err = errEndpointBehind
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e.requests++
+	e.totalLatency += latency
+	if err != nil && ctx.Err() == nil {
+		e.failures++
+		e.lastError = err
+		e.consecutiveFailures++
+		if e.consecutiveFailures >= p.cfg.MaxConsecutiveFailures {
+			e.healthy = false
+		}
+	} else if err == nil {
+		e.consecutiveFailures = 0
+	}
+	return body, err
+}
+
+// errEndpointBehind fails over a response with a node behind error, the response is returned if all endpoints are behind
+var errEndpointBehind = errors.New("endpoint is behind")
+
+// pick chooses an endpoint by weight, available ones are preferred
+func (p *EndpointPool) pick(exclude map[*endpointState]bool) *endpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	candidates := p.available(exclude)
+
+	total := 0
+	for _, e := range candidates {
+		total += e.weight
+	}
+	n := rand.Intn(total)
+	for _, e := range candidates {
+		if n < e.weight {
+			return e
+		}
+		n -= e.weight
+	}
+	return candidates[len(candidates)-1]
+}
+
+// available returns healthy endpoints which are not lagging, or all if none of them is.
+// it must be called with p.mu held.
+func (p *EndpointPool) available(exclude map[*endpointState]bool) []*endpointState {
+	highestSlot := p.highestSlot()
+	output := []*endpointState{}
+	rest := []*endpointState{}
+	for _, e := range p.endpoints {
+		if exclude[e] {
+			continue
+		}
+		if e.healthy && e.slot+p.cfg.MaxSlotLag >= highestSlot {
+			output = append(output, e)
+		} else {
+			rest = append(rest, e)
+		}
+	}
+	if len(output) == 0 {
+		return rest
+	}
+	return output
+}
+
+// highestSlot must be called with p.mu held
+func (p *EndpointPool) highestSlot() uint64 {
+	var slot uint64
+	for _, e := range p.endpoints {
+		if e.healthy && e.slot > slot {
+			slot = e.slot
+		}
+	}
+	return slot
+}
+
+func (p *EndpointPool) runHealthCheck() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		p.checkHealth()
+		select {
+		case <-p.closed:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *EndpointPool) checkHealth() {
+	ctx, cancel := context.WithTimeout(context.Background(), p.cfg.HealthCheckInterval)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func(e *endpointState) {
+			defer wg.Done()
+			err := checkEndpointHealth(ctx, e.rpc)
+			var slot uint64
+			if err == nil {
+				var res GetSlotResponse
+				res, err = e.rpc.GetSlotWithConfig(ctx, GetSlotConfig{Commitment: CommitmentProcessed})
+				if err == nil && res.Error != nil {
+					err = errors.New(res.Error.Message)
+				}
+				slot = res.Result
+			}
+
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			e.lastHealthCheck = time.Now()
+			e.healthy = err == nil
+			if err != nil {
+				e.lastError = err
+				return
+			}
+			e.slot = slot
+			e.consecutiveFailures = 0
+		}(e)
+	}
+	wg.Wait()
+}
+
+func checkEndpointHealth(ctx context.Context, c RpcClient) error {
+	res, err := c.GetHealth(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return errors.New(res.Error.Message)
+	}
+	return nil
+}
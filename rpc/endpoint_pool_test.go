@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNode struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	healthy    bool
+	slot       uint64
+	statusCode int
+	calls      map[string]int
+}
+
+func newTestNode(t *testing.T, healthy bool, slot uint64) *testNode {
+	n := &testNode{
+		healthy:    healthy,
+		slot:       slot,
+		statusCode: http.StatusOK,
+		calls:      map[string]int{},
+	}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		assert.Nil(t, err)
+		var r jsonRpcRequest
+		assert.Nil(t, json.Unmarshal(body, &r))
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		n.calls[r.Method]++
+		switch r.Method {
+		case "getHealth":
+			if n.healthy {
+				fmt.Fprint(rw, `{"jsonrpc":"2.0","result":"ok","id":1}`)
+			} else {
+				fmt.Fprint(rw, `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is unhealthy"},"id":1}`)
+			}
+		case "getSlot":
+			fmt.Fprintf(rw, `{"jsonrpc":"2.0","result":%v,"id":1}`, n.slot)
+		default:
+			rw.WriteHeader(n.statusCode)
+			fmt.Fprintf(rw, `{"jsonrpc":"2.0","result":"%v","id":1}`, n.URL)
+		}
+	}))
+	return n
+}
+
+func (n *testNode) callCount(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+func newTestPool(t *testing.T, cfg EndpointPoolConfig) *EndpointPool {
+	p, err := NewEndpointPool(cfg)
+	require.Nil(t, err)
+	// wait for the first health check
+	assert.Eventually(t, func() bool {
+		for _, s := range p.Stats() {
+			if s.LastHealthCheck.IsZero() {
+				return false
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond)
+	return p
+}
+
+func TestEndpointPool_RouteAround(t *testing.T) {
+	a := newTestNode(t, true, 1000)
+	defer a.Close()
+	behind := newTestNode(t, true, 900)
+	defer behind.Close()
+	unhealthy := newTestNode(t, false, 1000)
+	defer unhealthy.Close()
+
+	p := newTestPool(t, EndpointPoolConfig{
+		Endpoints: []Endpoint{{URL: a.URL}, {URL: behind.URL}, {URL: unhealthy.URL}},
+	})
+	defer p.Close()
+
+	c := New(WithEndpointPool(p))
+	for i := 0; i < 10; i++ {
+		body, err := c.Call(context.Background(), "getBalance")
+		assert.Nil(t, err)
+		assert.JSONEq(t, fmt.Sprintf(`{"jsonrpc":"2.0","result":"%v","id":1}`, a.URL), string(body))
+	}
+	assert.Equal(t, 10, a.callCount("getBalance"))
+
+	stats := p.Stats()
+	assert.Equal(t, a.URL, stats[0].URL)
+	assert.True(t, stats[0].Healthy)
+	assert.Equal(t, uint64(10), stats[0].Requests)
+	assert.Equal(t, uint64(0), stats[0].SlotsBehind)
+	assert.True(t, stats[1].Healthy)
+	assert.Equal(t, uint64(100), stats[1].SlotsBehind)
+	assert.False(t, stats[2].Healthy)
+	assert.EqualError(t, stats[2].LastError, "Node is unhealthy")
+}
+
+func TestEndpointPool_Failover(t *testing.T) {
+	a := newTestNode(t, true, 1000)
+	defer a.Close()
+	b := newTestNode(t, true, 1000)
+	defer b.Close()
+	a.statusCode = http.StatusServiceUnavailable
+
+	p := newTestPool(t, EndpointPoolConfig{
+		Endpoints:              []Endpoint{{URL: a.URL, Weight: 100}, {URL: b.URL, Weight: 1}},
+		MaxConsecutiveFailures: 2,
+	})
+	defer p.Close()
+
+	c := New(WithEndpointPool(p))
+	for i := 0; i < 5; i++ {
+		body, err := c.Call(context.Background(), "getBalance")
+		assert.Nil(t, err)
+		assert.JSONEq(t, fmt.Sprintf(`{"jsonrpc":"2.0","result":"%v","id":1}`, b.URL), string(body))
+	}
+
+	// a is marked unhealthy after 2 failures and isn't tried anymore
+	stats := p.Stats()
+	assert.False(t, stats[0].Healthy)
+	assert.Equal(t, uint64(2), stats[0].Failures)
+	assert.Equal(t, &HTTPError{StatusCode: http.StatusServiceUnavailable}, stats[0].LastError)
+	assert.Equal(t, uint64(5), stats[1].Requests)
+
+	// all endpoints fail
+	b.mu.Lock()
+	b.statusCode = http.StatusBadGateway
+	b.mu.Unlock()
+	_, err := c.Call(context.Background(), "getBalance")
+	assert.IsType(t, &HTTPError{}, err)
+}
+
+func TestEndpointPool_BroadcastSendTransaction(t *testing.T) {
+	a := newTestNode(t, true, 1000)
+	defer a.Close()
+	b := newTestNode(t, true, 1000)
+	defer b.Close()
+
+	p := newTestPool(t, EndpointPoolConfig{
+		Endpoints:                []Endpoint{{URL: a.URL}, {URL: b.URL}},
+		BroadcastSendTransaction: true,
+	})
+	defer p.Close()
+
+	c := New(WithEndpointPool(p))
+	_, err := c.Call(context.Background(), "sendTransaction", "tx")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, a.callCount("sendTransaction"))
+	assert.Equal(t, 1, b.callCount("sendTransaction"))
+
+	_, err = c.Call(context.Background(), "getBalance")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, a.callCount("getBalance")+b.callCount("getBalance"))
+}
+
+func TestNewEndpointPool(t *testing.T) {
+	_, err := NewEndpointPool(EndpointPoolConfig{})
+	assert.EqualError(t, err, "no endpoints")
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// server error codes of solana json rpc
+const (
+	ErrorCodeBlockCleanedUp                           = -32001
+	ErrorCodeSendTransactionPreflightFailure          = -32002
+	ErrorCodeTransactionSignatureVerificationFailure  = -32003
+	ErrorCodeBlockNotAvailable                        = -32004
+	ErrorCodeNodeUnhealthy                            = -32005
+	ErrorCodeTransactionPrecompileVerificationFailure = -32006
+	ErrorCodeSlotSkipped                              = -32007
+	ErrorCodeNoSnapshot                               = -32008
+	ErrorCodeLongTermStorageSlotSkipped               = -32009
+	ErrorCodeKeyExcludedFromSecondaryIndex            = -32010
+	ErrorCodeTransactionHistoryNotAvailable           = -32011
+	ErrorCodeScanError                                = -32012
+	ErrorCodeTransactionSignatureLenMismatch          = -32013
+	ErrorCodeBlockStatusNotAvailableYet               = -32014
+	ErrorCodeUnsupportedTransactionVersion            = -32015
+	ErrorCodeMinContextSlotNotReached                 = -32016
+)
+
+// JSONRPCError is an error rpc response as an error, it can be used with errors.As
+type JSONRPCError struct {
+	Code    int
+	Message string
+	Data    map[string]interface{}
+}
+
+// NewJSONRPCError converts an error rpc response to an error
+func NewJSONRPCError(res ErrorResponse) *JSONRPCError {
+	return &JSONRPCError{
+		Code:    res.Code,
+		Message: res.Message,
+		Data:    res.Data,
+	}
+}
+
+func (e *JSONRPCError) Error() string {
+	errRes, err := json.Marshal(ErrorResponse{Code: e.Code, Message: e.Message, Data: e.Data})
+	if err != nil {
+		return fmt.Sprintf("rpc response error: %v", *e)
+	}
+	return fmt.Sprintf("rpc response error: %v", string(errRes))
+}
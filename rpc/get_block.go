@@ -39,13 +39,15 @@ const (
 type GetBlockTransaction struct {
 	Transaction interface{}      `json:"transaction"`
 	Meta        *TransactionMeta `json:"meta"`
+	Version     interface{}      `json:"version,omitempty"` // "legacy" or a version number
 }
 
 type GetBlockConfig struct {
-	Encoding           GetBlockConfigEncoding           `json:"encoding,omitempty"`           // default: "json"
-	TransactionDetails GetBlockConfigTransactionDetails `json:"transactionDetails,omitempty"` // default: "full", either "full", "signatures", "none"
-	Rewards            *bool                            `json:"rewards,omitempty"`            // default: true
-	Commitment         Commitment                       `json:"commitment,omitempty"`         // "processed" is not supported
+	Encoding                       GetBlockConfigEncoding           `json:"encoding,omitempty"`           // default: "json"
+	TransactionDetails             GetBlockConfigTransactionDetails `json:"transactionDetails,omitempty"` // default: "full", either "full", "signatures", "none"
+	Rewards                        *bool                            `json:"rewards,omitempty"`            // default: true
+	Commitment                     Commitment                       `json:"commitment,omitempty"`         // "processed" is not supported
+	MaxSupportedTransactionVersion *uint8                           `json:"maxSupportedTransactionVersion,omitempty"`
 }
 
 type GetBlockConfigEncoding string
@@ -12,7 +12,8 @@ type GetBlockHeightResponse struct {
 
 // GetBlockHeightConfig is a option config for `getBlockHeight`
 type GetBlockHeightConfig struct {
-	Commitment Commitment `json:"commitment,omitempty"`
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot *uint64    `json:"minContextSlot,omitempty"`
 }
 
 // GetBlockHeight returns the current block height of the node
@@ -3,6 +3,8 @@ package rpc
 import (
 	"context"
 	"testing"
+
+	"github.com/portto/solana-go-sdk/pkg/pointer"
 )
 
 func TestGetBlockHeight(t *testing.T) {
@@ -44,6 +46,27 @@ func TestGetBlockHeight(t *testing.T) {
 			},
 			ExpectedError: nil,
 		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getBlockHeight", "params":[{"minContextSlot": 83518000}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":83518231,"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetBlockHeightWithConfig(
+					context.Background(),
+					GetBlockHeightConfig{
+						MinContextSlot: pointer.Uint64(83518000),
+					},
+				)
+			},
+			ExpectedResponse: GetBlockHeightResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: 83518231,
+			},
+			ExpectedError: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
@@ -19,15 +19,16 @@ type GetEpochInfoResponseResult struct {
 
 // GetEpochInfoConfig is a option config for `getEpochInfo`
 type GetEpochInfoConfig struct {
-	Commitment Commitment `json:"commitment,omitempty"`
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot *uint64    `json:"minContextSlot,omitempty"`
 }
 
-// GetEpochInfo returns the SOL balance
+// GetEpochInfo returns information about the current epoch
 func (c *RpcClient) GetEpochInfo(ctx context.Context) (GetEpochInfoResponse, error) {
 	return c.processGetEpochInfo(c.Call(ctx, "getEpochInfo"))
 }
 
-// GetEpochInfoWithConfig returns the SOL balance
+// GetEpochInfoWithConfig returns information about the current epoch
 func (c *RpcClient) GetEpochInfoWithConfig(ctx context.Context, cfg GetEpochInfoConfig) (GetEpochInfoResponse, error) {
 	return c.processGetEpochInfo(c.Call(ctx, "getEpochInfo", cfg))
 }
@@ -62,6 +62,34 @@ func TestGetEpochInfo(t *testing.T) {
 			},
 			ExpectedError: nil,
 		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getEpochInfo", "params":[{"commitment": "confirmed", "minContextSlot": 86715000}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"absoluteSlot":86715194,"blockHeight":84901570,"epoch":200,"slotIndex":315194,"slotsInEpoch":432000},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetEpochInfoWithConfig(
+					context.TODO(),
+					GetEpochInfoConfig{
+						Commitment:     CommitmentConfirmed,
+						MinContextSlot: pointer.Uint64(86715000),
+					},
+				)
+			},
+			ExpectedResponse: GetEpochInfoResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetEpochInfoResponseResult{
+					AbsoluteSlot: 86715194,
+					BlockHeight:  84901570,
+					Epoch:        200,
+					SlotIndex:    315194,
+					SlotsInEpoch: 432000,
+				},
+			},
+			ExpectedError: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
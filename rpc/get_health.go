@@ -0,0 +1,21 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetHealthResponse is a full raw rpc response of `getHealth`
+type GetHealthResponse struct {
+	GeneralResponse
+	Result string `json:"result"`
+}
+
+// GetHealth returns the current health of the node, an unhealthy node returns an error response
+func (c *RpcClient) GetHealth(ctx context.Context) (GetHealthResponse, error) {
+	return c.processGetHealth(c.Call(ctx, "getHealth"))
+}
+
+func (c *RpcClient) processGetHealth(body []byte, rpcErr error) (res GetHealthResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
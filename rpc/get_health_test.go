@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetHealth(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getHealth"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":"ok","id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetHealth(
+					context.TODO(),
+				)
+			},
+			ExpectedResponse: GetHealthResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: "ok",
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getHealth"}`,
+			ResponseBody: `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 42 slots","data":{"numSlotsBehind":42}},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetHealth(
+					context.TODO(),
+				)
+			},
+			ExpectedResponse: GetHealthResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error: &ErrorResponse{
+						Code:    -32005,
+						Message: "Node is behind by 42 slots",
+						Data: map[string]interface{}{
+							"numSlotsBehind": float64(42),
+						},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
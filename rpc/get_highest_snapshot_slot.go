@@ -0,0 +1,27 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetHighestSnapshotSlotResponse is a full raw rpc response of `getHighestSnapshotSlot`
+type GetHighestSnapshotSlotResponse struct {
+	GeneralResponse
+	Result GetHighestSnapshotSlotResult `json:"result"`
+}
+
+// GetHighestSnapshotSlotResult is a part of raw rpc response of `getHighestSnapshotSlot`
+type GetHighestSnapshotSlotResult struct {
+	Full        uint64  `json:"full"`
+	Incremental *uint64 `json:"incremental"`
+}
+
+// GetHighestSnapshotSlot returns the highest slot information that the node has snapshots for
+func (c *RpcClient) GetHighestSnapshotSlot(ctx context.Context) (GetHighestSnapshotSlotResponse, error) {
+	return c.processGetHighestSnapshotSlot(c.Call(ctx, "getHighestSnapshotSlot"))
+}
+
+func (c *RpcClient) processGetHighestSnapshotSlot(body []byte, rpcErr error) (res GetHighestSnapshotSlotResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
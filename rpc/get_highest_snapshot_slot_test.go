@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+)
+
+func TestGetHighestSnapshotSlot(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getHighestSnapshotSlot"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"full":100,"incremental":110},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetHighestSnapshotSlot(context.TODO())
+			},
+			ExpectedResponse: GetHighestSnapshotSlotResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetHighestSnapshotSlotResult{
+					Full:        100,
+					Incremental: pointer.Uint64(110),
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getHighestSnapshotSlot"}`,
+			ResponseBody: `{"jsonrpc":"2.0","error":{"code":-32008,"message":"No snapshot"},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetHighestSnapshotSlot(context.TODO())
+			},
+			ExpectedResponse: GetHighestSnapshotSlotResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error: &ErrorResponse{
+						Code:    -32008,
+						Message: "No snapshot",
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetLargestAccountsResponse is a full raw rpc response of `getLargestAccounts`
+type GetLargestAccountsResponse struct {
+	GeneralResponse
+	Result GetLargestAccountsResult `json:"result"`
+}
+
+// GetLargestAccountsResult is a part of raw rpc response of `getLargestAccounts`
+type GetLargestAccountsResult struct {
+	Context Context                         `json:"context"`
+	Value   []GetLargestAccountsResultValue `json:"value"`
+}
+
+// GetLargestAccountsResultValue is a part of raw rpc response of `getLargestAccounts`
+type GetLargestAccountsResultValue struct {
+	Lamports uint64 `json:"lamports"`
+	Address  string `json:"address"`
+}
+
+type GetLargestAccountsConfigFilter string
+
+const (
+	GetLargestAccountsConfigFilterCirculating    GetLargestAccountsConfigFilter = "circulating"
+	GetLargestAccountsConfigFilterNonCirculating GetLargestAccountsConfigFilter = "nonCirculating"
+)
+
+// GetLargestAccountsConfig is a option config for `getLargestAccounts`
+type GetLargestAccountsConfig struct {
+	Commitment Commitment                     `json:"commitment,omitempty"`
+	Filter     GetLargestAccountsConfigFilter `json:"filter,omitempty"`
+}
+
+// GetLargestAccounts returns the 20 largest accounts by lamport balance, the result may be cached up to two hours
+func (c *RpcClient) GetLargestAccounts(ctx context.Context) (GetLargestAccountsResponse, error) {
+	return c.processGetLargestAccounts(c.Call(ctx, "getLargestAccounts"))
+}
+
+// GetLargestAccountsWithConfig returns the 20 largest accounts by lamport balance, the result may be cached up to two hours
+func (c *RpcClient) GetLargestAccountsWithConfig(ctx context.Context, cfg GetLargestAccountsConfig) (GetLargestAccountsResponse, error) {
+	return c.processGetLargestAccounts(c.Call(ctx, "getLargestAccounts", cfg))
+}
+
+func (c *RpcClient) processGetLargestAccounts(body []byte, rpcErr error) (res GetLargestAccountsResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
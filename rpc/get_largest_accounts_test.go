@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetLargestAccounts(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLargestAccounts"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":54},"value":[{"lamports":999974,"address":"99P8ZgtJYe1buSK8JXkvpLh8xPsCFuLYhz9hQFNw93WJ"},{"lamports":42,"address":"uPwWLo16MVehpyWqsLkK3Ka8nLowWvAHbBChqv2FZeL"}]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetLargestAccounts(context.TODO())
+			},
+			ExpectedResponse: GetLargestAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetLargestAccountsResult{
+					Context: Context{
+						Slot: 54,
+					},
+					Value: []GetLargestAccountsResultValue{
+						{
+							Lamports: 999974,
+							Address:  "99P8ZgtJYe1buSK8JXkvpLh8xPsCFuLYhz9hQFNw93WJ",
+						},
+						{
+							Lamports: 42,
+							Address:  "uPwWLo16MVehpyWqsLkK3Ka8nLowWvAHbBChqv2FZeL",
+						},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLargestAccounts", "params":[{"commitment":"finalized","filter":"circulating"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":54},"value":[]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetLargestAccountsWithConfig(
+					context.TODO(),
+					GetLargestAccountsConfig{
+						Commitment: CommitmentFinalized,
+						Filter:     GetLargestAccountsConfigFilterCirculating,
+					},
+				)
+			},
+			ExpectedResponse: GetLargestAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetLargestAccountsResult{
+					Context: Context{
+						Slot: 54,
+					},
+					Value: []GetLargestAccountsResultValue{},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetLeaderScheduleResponse is a full raw rpc response of `getLeaderSchedule`
+type GetLeaderScheduleResponse struct {
+	GeneralResponse
+	Result GetLeaderScheduleResult `json:"result"`
+}
+
+// GetLeaderScheduleResult maps a validator identity to its leader slot indices relative to the first slot
+// of the epoch. it is nil if the epoch is not found.
+type GetLeaderScheduleResult map[string][]uint64
+
+// GetLeaderScheduleConfig is a option config for `getLeaderSchedule`
+type GetLeaderScheduleConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+	// Identity only returns results for this validator identity
+	Identity string `json:"identity,omitempty"`
+}
+
+// GetLeaderSchedule returns the leader schedule of the current epoch
+func (c *RpcClient) GetLeaderSchedule(ctx context.Context) (GetLeaderScheduleResponse, error) {
+	return c.processGetLeaderSchedule(c.Call(ctx, "getLeaderSchedule"))
+}
+
+// GetLeaderScheduleWithConfig returns the leader schedule of the current epoch
+func (c *RpcClient) GetLeaderScheduleWithConfig(ctx context.Context, cfg GetLeaderScheduleConfig) (GetLeaderScheduleResponse, error) {
+	return c.processGetLeaderSchedule(c.Call(ctx, "getLeaderSchedule", cfg))
+}
+
+// GetLeaderScheduleBySlot returns the leader schedule of the epoch which the slot is in
+func (c *RpcClient) GetLeaderScheduleBySlot(ctx context.Context, slot uint64) (GetLeaderScheduleResponse, error) {
+	return c.processGetLeaderSchedule(c.Call(ctx, "getLeaderSchedule", slot))
+}
+
+// GetLeaderScheduleBySlotWithConfig returns the leader schedule of the epoch which the slot is in
+func (c *RpcClient) GetLeaderScheduleBySlotWithConfig(ctx context.Context, slot uint64, cfg GetLeaderScheduleConfig) (GetLeaderScheduleResponse, error) {
+	return c.processGetLeaderSchedule(c.Call(ctx, "getLeaderSchedule", slot, cfg))
+}
+
+func (c *RpcClient) processGetLeaderSchedule(body []byte, rpcErr error) (res GetLeaderScheduleResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
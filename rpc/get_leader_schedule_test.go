@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetLeaderSchedule(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLeaderSchedule"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F":[0,1,2,3]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetLeaderSchedule(context.TODO())
+			},
+			ExpectedResponse: GetLeaderScheduleResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetLeaderScheduleResult{
+					"4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F": {0, 1, 2, 3},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLeaderSchedule", "params":[{"identity":"4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F":[0,1]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetLeaderScheduleWithConfig(
+					context.TODO(),
+					GetLeaderScheduleConfig{
+						Identity: "4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F",
+					},
+				)
+			},
+			ExpectedResponse: GetLeaderScheduleResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetLeaderScheduleResult{
+					"4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F": {0, 1},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLeaderSchedule", "params":[100000000]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":null,"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetLeaderScheduleBySlot(context.TODO(), 100000000)
+			},
+			ExpectedResponse: GetLeaderScheduleResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: nil,
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getLeaderSchedule", "params":[10, {"commitment":"finalized"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F":[7]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetLeaderScheduleBySlotWithConfig(
+					context.TODO(),
+					10,
+					GetLeaderScheduleConfig{
+						Commitment: CommitmentFinalized,
+					},
+				)
+			},
+			ExpectedResponse: GetLeaderScheduleResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetLeaderScheduleResult{
+					"4Qkev8aNZcqFNSRhQzwyLMFSsi94jHqE8WNVTJzTP99F": {7},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetMaxRetransmitSlotResponse is a full raw rpc response of `getMaxRetransmitSlot`
+type GetMaxRetransmitSlotResponse struct {
+	GeneralResponse
+	Result uint64 `json:"result"`
+}
+
+// GetMaxRetransmitSlot returns the max slot seen from retransmit stage
+func (c *RpcClient) GetMaxRetransmitSlot(ctx context.Context) (GetMaxRetransmitSlotResponse, error) {
+	return c.processGetMaxRetransmitSlot(c.Call(ctx, "getMaxRetransmitSlot"))
+}
+
+func (c *RpcClient) processGetMaxRetransmitSlot(body []byte, rpcErr error) (res GetMaxRetransmitSlotResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
@@ -0,0 +1,21 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetMaxShredInsertSlotResponse is a full raw rpc response of `getMaxShredInsertSlot`
+type GetMaxShredInsertSlotResponse struct {
+	GeneralResponse
+	Result uint64 `json:"result"`
+}
+
+// GetMaxShredInsertSlot returns the max slot seen from after shred insert
+func (c *RpcClient) GetMaxShredInsertSlot(ctx context.Context) (GetMaxShredInsertSlotResponse, error) {
+	return c.processGetMaxShredInsertSlot(c.Call(ctx, "getMaxShredInsertSlot"))
+}
+
+func (c *RpcClient) processGetMaxShredInsertSlot(body []byte, rpcErr error) (res GetMaxShredInsertSlotResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetMaxShredInsertSlot(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getMaxShredInsertSlot"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":1235,"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetMaxShredInsertSlot(context.TODO())
+			},
+			ExpectedResponse: GetMaxShredInsertSlotResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: 1235,
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
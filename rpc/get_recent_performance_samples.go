@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetRecentPerformanceSamplesResponse is a full raw rpc response of `getRecentPerformanceSamples`
+type GetRecentPerformanceSamplesResponse struct {
+	GeneralResponse
+	Result []GetRecentPerformanceSamplesResult `json:"result"`
+}
+
+// GetRecentPerformanceSamplesResult is a part of raw rpc response of `getRecentPerformanceSamples`
+type GetRecentPerformanceSamplesResult struct {
+	Slot                   uint64  `json:"slot"`
+	NumTransactions        uint64  `json:"numTransactions"`
+	NumSlots               uint64  `json:"numSlots"`
+	SamplePeriodSecs       uint16  `json:"samplePeriodSecs"`
+	NumNonVoteTransactions *uint64 `json:"numNonVoteTransactions"`
+}
+
+// GetRecentPerformanceSamples returns a list of recent performance samples, in reverse slot order.
+// performance samples are taken every 60 seconds.
+func (c *RpcClient) GetRecentPerformanceSamples(ctx context.Context) (GetRecentPerformanceSamplesResponse, error) {
+	return c.processGetRecentPerformanceSamples(c.Call(ctx, "getRecentPerformanceSamples"))
+}
+
+// GetRecentPerformanceSamplesWithLimit returns at most limit samples, the maximum is 720
+func (c *RpcClient) GetRecentPerformanceSamplesWithLimit(ctx context.Context, limit uint64) (GetRecentPerformanceSamplesResponse, error) {
+	return c.processGetRecentPerformanceSamples(c.Call(ctx, "getRecentPerformanceSamples", limit))
+}
+
+func (c *RpcClient) processGetRecentPerformanceSamples(body []byte, rpcErr error) (res GetRecentPerformanceSamplesResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+)
+
+func TestGetRecentPerformanceSamples(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getRecentPerformanceSamples"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":[{"numSlots":126,"numTransactions":126,"numNonVoteTransactions":1,"samplePeriodSecs":60,"slot":348125},{"numSlots":126,"numTransactions":126,"samplePeriodSecs":60,"slot":347999}],"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetRecentPerformanceSamples(context.TODO())
+			},
+			ExpectedResponse: GetRecentPerformanceSamplesResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: []GetRecentPerformanceSamplesResult{
+					{
+						Slot:                   348125,
+						NumTransactions:        126,
+						NumSlots:               126,
+						SamplePeriodSecs:       60,
+						NumNonVoteTransactions: pointer.Uint64(1),
+					},
+					{
+						Slot:             347999,
+						NumTransactions:  126,
+						NumSlots:         126,
+						SamplePeriodSecs: 60,
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getRecentPerformanceSamples", "params":[1]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":[{"numSlots":126,"numTransactions":126,"numNonVoteTransactions":1,"samplePeriodSecs":60,"slot":348125}],"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetRecentPerformanceSamplesWithLimit(context.TODO(), 1)
+			},
+			ExpectedResponse: GetRecentPerformanceSamplesResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: []GetRecentPerformanceSamplesResult{
+					{
+						Slot:                   348125,
+						NumTransactions:        126,
+						NumSlots:               126,
+						SamplePeriodSecs:       60,
+						NumNonVoteTransactions: pointer.Uint64(1),
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
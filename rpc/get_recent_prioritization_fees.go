@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetRecentPrioritizationFeesResponse is a full raw rpc response of `getRecentPrioritizationFees`
+type GetRecentPrioritizationFeesResponse struct {
+	GeneralResponse
+	Result []GetRecentPrioritizationFeesResult `json:"result"`
+}
+
+// GetRecentPrioritizationFeesResult is a part of raw rpc response of `getRecentPrioritizationFees`
+type GetRecentPrioritizationFeesResult struct {
+	Slot uint64 `json:"slot"`
+	// PrioritizationFee is in micro-lamports per compute unit
+	PrioritizationFee uint64 `json:"prioritizationFee"`
+}
+
+// GetRecentPrioritizationFees returns a list of prioritization fees from recent blocks. if addresses are provided,
+// the fee of a slot is the minimum fee to land a transaction which locks all of them as writable.
+func (c *RpcClient) GetRecentPrioritizationFees(ctx context.Context, addresses []string) (GetRecentPrioritizationFeesResponse, error) {
+	if len(addresses) == 0 {
+		return c.processGetRecentPrioritizationFees(c.Call(ctx, "getRecentPrioritizationFees"))
+	}
+	return c.processGetRecentPrioritizationFees(c.Call(ctx, "getRecentPrioritizationFees", addresses))
+}
+
+func (c *RpcClient) processGetRecentPrioritizationFees(body []byte, rpcErr error) (res GetRecentPrioritizationFeesResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetRecentPrioritizationFees(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getRecentPrioritizationFees"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":[{"slot":348125,"prioritizationFee":0},{"slot":348126,"prioritizationFee":1000}],"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetRecentPrioritizationFees(context.TODO(), nil)
+			},
+			ExpectedResponse: GetRecentPrioritizationFeesResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: []GetRecentPrioritizationFeesResult{
+					{
+						Slot:              348125,
+						PrioritizationFee: 0,
+					},
+					{
+						Slot:              348126,
+						PrioritizationFee: 1000,
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getRecentPrioritizationFees", "params":[["CxELquR1gPP8wHe33gZ4QxqGB3sZ9RSwsJ2KshVewkFY"]]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":[{"slot":348125,"prioritizationFee":500}],"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetRecentPrioritizationFees(context.TODO(), []string{"CxELquR1gPP8wHe33gZ4QxqGB3sZ9RSwsJ2KshVewkFY"})
+			},
+			ExpectedResponse: GetRecentPrioritizationFeesResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: []GetRecentPrioritizationFeesResult{
+					{
+						Slot:              348125,
+						PrioritizationFee: 500,
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetSlotLeaderResponse is a full raw rpc response of `getSlotLeader`
+type GetSlotLeaderResponse struct {
+	GeneralResponse
+	Result string `json:"result"`
+}
+
+// GetSlotLeaderConfig is a option config for `getSlotLeader`
+type GetSlotLeaderConfig struct {
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot *uint64    `json:"minContextSlot,omitempty"`
+}
+
+// GetSlotLeader returns the current slot leader
+func (c *RpcClient) GetSlotLeader(ctx context.Context) (GetSlotLeaderResponse, error) {
+	return c.processGetSlotLeader(c.Call(ctx, "getSlotLeader"))
+}
+
+// GetSlotLeaderWithConfig returns the current slot leader
+func (c *RpcClient) GetSlotLeaderWithConfig(ctx context.Context, cfg GetSlotLeaderConfig) (GetSlotLeaderResponse, error) {
+	return c.processGetSlotLeader(c.Call(ctx, "getSlotLeader", cfg))
+}
+
+func (c *RpcClient) processGetSlotLeader(body []byte, rpcErr error) (res GetSlotLeaderResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
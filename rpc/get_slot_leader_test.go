@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+)
+
+func TestGetSlotLeader(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getSlotLeader"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":"ENvAW7JScgYq6o4zKZwewtkzzJgDzuJAFxYasvmEQdpS","id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetSlotLeader(context.TODO())
+			},
+			ExpectedResponse: GetSlotLeaderResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: "ENvAW7JScgYq6o4zKZwewtkzzJgDzuJAFxYasvmEQdpS",
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getSlotLeader", "params":[{"commitment":"processed","minContextSlot":1000}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":"ENvAW7JScgYq6o4zKZwewtkzzJgDzuJAFxYasvmEQdpS","id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetSlotLeaderWithConfig(
+					context.TODO(),
+					GetSlotLeaderConfig{
+						Commitment:     CommitmentProcessed,
+						MinContextSlot: pointer.Uint64(1000),
+					},
+				)
+			},
+			ExpectedResponse: GetSlotLeaderResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: "ENvAW7JScgYq6o4zKZwewtkzzJgDzuJAFxYasvmEQdpS",
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
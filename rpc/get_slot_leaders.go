@@ -0,0 +1,21 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetSlotLeadersResponse is a full raw rpc response of `getSlotLeaders`
+type GetSlotLeadersResponse struct {
+	GeneralResponse
+	Result []string `json:"result"`
+}
+
+// GetSlotLeaders returns the slot leaders for a given slot range, the limit is between 1 and 5,000
+func (c *RpcClient) GetSlotLeaders(ctx context.Context, startSlot uint64, limit uint64) (GetSlotLeadersResponse, error) {
+	return c.processGetSlotLeaders(c.Call(ctx, "getSlotLeaders", startSlot, limit))
+}
+
+func (c *RpcClient) processGetSlotLeaders(body []byte, rpcErr error) (res GetSlotLeadersResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetSlotLeaders(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getSlotLeaders", "params":[100, 2]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":["ChorusmmK7i1AxXeiTtQgQZhQNiXYU84ULeaYF1EH15n","ChorusmmK7i1AxXeiTtQgQZhQNiXYU84ULeaYF1EH15n"],"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetSlotLeaders(context.TODO(), 100, 2)
+			},
+			ExpectedResponse: GetSlotLeadersResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: []string{
+					"ChorusmmK7i1AxXeiTtQgQZhQNiXYU84ULeaYF1EH15n",
+					"ChorusmmK7i1AxXeiTtQgQZhQNiXYU84ULeaYF1EH15n",
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetStakeMinimumDelegationResponse is a full raw rpc response of `getStakeMinimumDelegation`
+type GetStakeMinimumDelegationResponse struct {
+	GeneralResponse
+	Result GetStakeMinimumDelegationResult `json:"result"`
+}
+
+// GetStakeMinimumDelegationResult is a part of raw rpc response of `getStakeMinimumDelegation`
+type GetStakeMinimumDelegationResult struct {
+	Context Context `json:"context"`
+	Value   uint64  `json:"value"`
+}
+
+// GetStakeMinimumDelegationConfig is a option config for `getStakeMinimumDelegation`
+type GetStakeMinimumDelegationConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
+
+// GetStakeMinimumDelegation returns the stake minimum delegation, in lamports
+func (c *RpcClient) GetStakeMinimumDelegation(ctx context.Context) (GetStakeMinimumDelegationResponse, error) {
+	return c.processGetStakeMinimumDelegation(c.Call(ctx, "getStakeMinimumDelegation"))
+}
+
+// GetStakeMinimumDelegationWithConfig returns the stake minimum delegation, in lamports
+func (c *RpcClient) GetStakeMinimumDelegationWithConfig(ctx context.Context, cfg GetStakeMinimumDelegationConfig) (GetStakeMinimumDelegationResponse, error) {
+	return c.processGetStakeMinimumDelegation(c.Call(ctx, "getStakeMinimumDelegation", cfg))
+}
+
+func (c *RpcClient) processGetStakeMinimumDelegation(body []byte, rpcErr error) (res GetStakeMinimumDelegationResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
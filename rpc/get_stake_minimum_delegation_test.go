@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetStakeMinimumDelegation(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getStakeMinimumDelegation"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":501},"value":1000000000},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetStakeMinimumDelegation(context.TODO())
+			},
+			ExpectedResponse: GetStakeMinimumDelegationResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetStakeMinimumDelegationResult{
+					Context: Context{
+						Slot: 501,
+					},
+					Value: 1000000000,
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getStakeMinimumDelegation", "params":[{"commitment":"confirmed"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":501},"value":1},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetStakeMinimumDelegationWithConfig(
+					context.TODO(),
+					GetStakeMinimumDelegationConfig{
+						Commitment: CommitmentConfirmed,
+					},
+				)
+			},
+			ExpectedResponse: GetStakeMinimumDelegationResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetStakeMinimumDelegationResult{
+					Context: Context{
+						Slot: 501,
+					},
+					Value: 1,
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetSupplyResponse is a full raw rpc response of `getSupply`
+type GetSupplyResponse struct {
+	GeneralResponse
+	Result GetSupplyResult `json:"result"`
+}
+
+// GetSupplyResult is a part of raw rpc response of `getSupply`
+type GetSupplyResult struct {
+	Context Context              `json:"context"`
+	Value   GetSupplyResultValue `json:"value"`
+}
+
+// GetSupplyResultValue is a part of raw rpc response of `getSupply`
+type GetSupplyResultValue struct {
+	Total                  uint64   `json:"total"`
+	Circulating            uint64   `json:"circulating"`
+	NonCirculating         uint64   `json:"nonCirculating"`
+	NonCirculatingAccounts []string `json:"nonCirculatingAccounts"`
+}
+
+// GetSupplyConfig is a option config for `getSupply`
+type GetSupplyConfig struct {
+	Commitment                        Commitment `json:"commitment,omitempty"`
+	ExcludeNonCirculatingAccountsList bool       `json:"excludeNonCirculatingAccountsList,omitempty"`
+}
+
+// GetSupply returns information about the current supply
+func (c *RpcClient) GetSupply(ctx context.Context) (GetSupplyResponse, error) {
+	return c.processGetSupply(c.Call(ctx, "getSupply"))
+}
+
+// GetSupplyWithConfig returns information about the current supply
+func (c *RpcClient) GetSupplyWithConfig(ctx context.Context, cfg GetSupplyConfig) (GetSupplyResponse, error) {
+	return c.processGetSupply(c.Call(ctx, "getSupply", cfg))
+}
+
+func (c *RpcClient) processGetSupply(body []byte, rpcErr error) (res GetSupplyResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetSupply(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getSupply"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1114},"value":{"circulating":16000,"nonCirculating":1000000,"nonCirculatingAccounts":["FEy8pTbP5fEoqMV1GdTz83byuJDr2Yx6m5tfjDrRCY1M"],"total":1016000}},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetSupply(context.TODO())
+			},
+			ExpectedResponse: GetSupplyResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetSupplyResult{
+					Context: Context{
+						Slot: 1114,
+					},
+					Value: GetSupplyResultValue{
+						Total:                  1016000,
+						Circulating:            16000,
+						NonCirculating:         1000000,
+						NonCirculatingAccounts: []string{"FEy8pTbP5fEoqMV1GdTz83byuJDr2Yx6m5tfjDrRCY1M"},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getSupply", "params":[{"excludeNonCirculatingAccountsList":true}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1114},"value":{"circulating":16000,"nonCirculating":1000000,"nonCirculatingAccounts":[],"total":1016000}},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetSupplyWithConfig(
+					context.TODO(),
+					GetSupplyConfig{
+						ExcludeNonCirculatingAccountsList: true,
+					},
+				)
+			},
+			ExpectedResponse: GetSupplyResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetSupplyResult{
+					Context: Context{
+						Slot: 1114,
+					},
+					Value: GetSupplyResultValue{
+						Total:                  1016000,
+						Circulating:            16000,
+						NonCirculating:         1000000,
+						NonCirculatingAccounts: []string{},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
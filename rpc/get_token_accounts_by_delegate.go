@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetTokenAccountsByDelegateResponse is a full rpc response for `getTokenAccountsByDelegate`
+type GetTokenAccountsByDelegateResponse struct {
+	GeneralResponse
+	Result GetTokenAccountsByDelegateResponseResult `json:"result"`
+}
+
+type GetTokenAccountsByDelegateResponseResult struct {
+	Context Context              `json:"context"`
+	Value   []GetProgramAccounts `json:"value"`
+}
+
+type GetTokenAccountsByDelegateConfigEncoding string
+
+const (
+	// GetTokenAccountsByDelegateConfigEncodingBase58 limited to Account data of less than 128 bytes
+	GetTokenAccountsByDelegateConfigEncodingBase58     GetTokenAccountsByDelegateConfigEncoding = "base58"
+	GetTokenAccountsByDelegateConfigEncodingJsonParsed GetTokenAccountsByDelegateConfigEncoding = "jsonParsed"
+	GetTokenAccountsByDelegateConfigEncodingBase64     GetTokenAccountsByDelegateConfigEncoding = "base64"
+	GetTokenAccountsByDelegateConfigEncodingBase64Zstd GetTokenAccountsByDelegateConfigEncoding = "base64+zstd"
+)
+
+// GetTokenAccountsByDelegateConfig is a option config for `getTokenAccountsByDelegate`
+type GetTokenAccountsByDelegateConfig struct {
+	Commitment     Commitment                                 `json:"commitment,omitempty"`
+	Encoding       GetTokenAccountsByDelegateConfigEncoding   `json:"encoding,omitempty"`
+	DataSlice      *GetTokenAccountsByDelegateConfigDataSlice `json:"dataSlice,omitempty"`
+	MinContextSlot *uint64                                    `json:"minContextSlot,omitempty"`
+}
+
+// GetTokenAccountsByDelegateConfigDataSlice is a part of GetTokenAccountsByDelegateConfig
+type GetTokenAccountsByDelegateConfigDataSlice struct {
+	Offset uint64 `json:"offset"`
+	Length uint64 `json:"length"`
+}
+
+// GetTokenAccountsByDelegateConfigFilter either mint or programId
+type GetTokenAccountsByDelegateConfigFilter struct {
+	Mint      string `json:"mint,omitempty"`
+	ProgramId string `json:"programId,omitempty"`
+}
+
+// GetTokenAccountsByDelegate returns all SPL Token accounts by approved delegate
+func (c *RpcClient) GetTokenAccountsByDelegate(ctx context.Context, base58Addr string, filter GetTokenAccountsByDelegateConfigFilter) (GetTokenAccountsByDelegateResponse, error) {
+	return c.processGetTokenAccountsByDelegate(c.Call(ctx, "getTokenAccountsByDelegate", base58Addr, filter))
+}
+
+// GetTokenAccountsByDelegateWithConfig returns all SPL Token accounts by approved delegate
+func (c *RpcClient) GetTokenAccountsByDelegateWithConfig(ctx context.Context, base58Addr string, filter GetTokenAccountsByDelegateConfigFilter, cfg GetTokenAccountsByDelegateConfig) (GetTokenAccountsByDelegateResponse, error) {
+	return c.processGetTokenAccountsByDelegate(c.Call(ctx, "getTokenAccountsByDelegate", base58Addr, filter, cfg))
+}
+
+func (c *RpcClient) processGetTokenAccountsByDelegate(body []byte, rpcErr error) (res GetTokenAccountsByDelegateResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
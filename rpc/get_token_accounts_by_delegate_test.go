@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestGetTokenAccountsByDelegate(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenAccountsByDelegate", "params":["4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T", {"mint": "4UyUTBdhPkFiu7ZE8zfxnE6hbbzf8LKo1uR5wSi5MYE3"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":88024144},"value":[{"account":{"data":"error: data too large for bs58 encoding","executable":false,"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","rentEpoch":203},"pubkey":"AyHWro8zumyZN68Mhuk6mhNUUQ2VX5qux2pMD4HnN3aJ"}]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetTokenAccountsByDelegate(
+					context.TODO(),
+					"4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T",
+					GetTokenAccountsByDelegateConfigFilter{
+						Mint: "4UyUTBdhPkFiu7ZE8zfxnE6hbbzf8LKo1uR5wSi5MYE3",
+					},
+				)
+			},
+			ExpectedResponse: GetTokenAccountsByDelegateResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetTokenAccountsByDelegateResponseResult{
+					Context: Context{
+						Slot: 88024144,
+					},
+					Value: []GetProgramAccounts{
+						{
+							Pubkey: "AyHWro8zumyZN68Mhuk6mhNUUQ2VX5qux2pMD4HnN3aJ",
+							Account: AccountInfo{
+								Lamports:   2039280,
+								Owner:      "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+								RentEpoch:  203,
+								Data:       "error: data too large for bs58 encoding",
+								Executable: false,
+							},
+						},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenAccountsByDelegate", "params":["4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T", {"programId": "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}, {"encoding": "base64"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":88024144},"value":[]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetTokenAccountsByDelegateWithConfig(
+					context.TODO(),
+					"4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T",
+					GetTokenAccountsByDelegateConfigFilter{
+						ProgramId: common.TokenProgramID.ToBase58(),
+					},
+					GetTokenAccountsByDelegateConfig{
+						Encoding: GetTokenAccountsByDelegateConfigEncodingBase64,
+					},
+				)
+			},
+			ExpectedResponse: GetTokenAccountsByDelegateResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetTokenAccountsByDelegateResponseResult{
+					Context: Context{
+						Slot: 88024144,
+					},
+					Value: []GetProgramAccounts{},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
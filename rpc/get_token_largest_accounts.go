@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetTokenLargestAccountsResponse is a full raw rpc response of `getTokenLargestAccounts`
+type GetTokenLargestAccountsResponse struct {
+	GeneralResponse
+	Result GetTokenLargestAccountsResult `json:"result"`
+}
+
+// GetTokenLargestAccountsResult is a part of raw rpc response of `getTokenLargestAccounts`
+type GetTokenLargestAccountsResult struct {
+	Context Context                              `json:"context"`
+	Value   []GetTokenLargestAccountsResultValue `json:"value"`
+}
+
+// GetTokenLargestAccountsResultValue is a part of raw rpc response of `getTokenLargestAccounts`
+type GetTokenLargestAccountsResultValue struct {
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+	Decimals       uint8  `json:"decimals"`
+	UIAmountString string `json:"uiAmountString"`
+}
+
+// GetTokenLargestAccountsConfig is a option config for `getTokenLargestAccounts`
+type GetTokenLargestAccountsConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
+
+// GetTokenLargestAccounts returns the 20 largest accounts of a particular SPL Token type
+func (c *RpcClient) GetTokenLargestAccounts(ctx context.Context, mintAddr string) (GetTokenLargestAccountsResponse, error) {
+	return c.processGetTokenLargestAccounts(c.Call(ctx, "getTokenLargestAccounts", mintAddr))
+}
+
+// GetTokenLargestAccountsWithConfig returns the 20 largest accounts of a particular SPL Token type
+func (c *RpcClient) GetTokenLargestAccountsWithConfig(ctx context.Context, mintAddr string, cfg GetTokenLargestAccountsConfig) (GetTokenLargestAccountsResponse, error) {
+	return c.processGetTokenLargestAccounts(c.Call(ctx, "getTokenLargestAccounts", mintAddr, cfg))
+}
+
+func (c *RpcClient) processGetTokenLargestAccounts(body []byte, rpcErr error) (res GetTokenLargestAccountsResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
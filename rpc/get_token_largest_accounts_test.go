@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetTokenLargestAccounts(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenLargestAccounts", "params":["3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E"]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1114},"value":[{"address":"FYjHNoFtSQ5uijKrZFyYAxvEr87hsKXkXcxkcmkBAf4r","amount":"771","decimals":2,"uiAmount":7.71,"uiAmountString":"7.71"}]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetTokenLargestAccounts(context.TODO(), "3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E")
+			},
+			ExpectedResponse: GetTokenLargestAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetTokenLargestAccountsResult{
+					Context: Context{
+						Slot: 1114,
+					},
+					Value: []GetTokenLargestAccountsResultValue{
+						{
+							Address:        "FYjHNoFtSQ5uijKrZFyYAxvEr87hsKXkXcxkcmkBAf4r",
+							Amount:         "771",
+							Decimals:       2,
+							UIAmountString: "7.71",
+						},
+					},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getTokenLargestAccounts", "params":["3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E", {"commitment":"confirmed"}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":1114},"value":[]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetTokenLargestAccountsWithConfig(
+					context.TODO(),
+					"3wyAj7Rt1TWVPZVteFJPLa26JmLvdb1CAKEFZm3NY75E",
+					GetTokenLargestAccountsConfig{
+						Commitment: CommitmentConfirmed,
+					},
+				)
+			},
+			ExpectedResponse: GetTokenLargestAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetTokenLargestAccountsResult{
+					Context: Context{
+						Slot: 1114,
+					},
+					Value: []GetTokenLargestAccountsResultValue{},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
@@ -16,6 +16,7 @@ type GetTransactionResult struct {
 	Meta        *TransactionMeta `json:"meta"`
 	Transaction interface{}      `json:"transaction"`
 	BlockTime   *int64           `json:"blockTime"`
+	Version     interface{}      `json:"version,omitempty"` // "legacy" or a version number
 }
 
 // TransactionMeta is a part of GetTransactionResult
@@ -28,6 +29,13 @@ type TransactionMeta struct {
 	PostTokenBalances []TransactionMetaTokenBalance     `json:"postTokenBalances"`
 	LogMessages       []string                          `json:"logMessages"`
 	InnerInstructions []TransactionMetaInnerInstruction `json:"innerInstructions"`
+	LoadedAddresses   TransactionLoadedAddresses        `json:"loadedAddresses"`
+}
+
+// TransactionLoadedAddresses is a part of TransactionMeta, it lists accounts loaded from address lookup tables
+type TransactionLoadedAddresses struct {
+	Writable []string `json:"writable"`
+	Readonly []string `json:"readonly"`
 }
 
 // TransactionMetaTokenBalance is a part of TransactionMeta
@@ -69,8 +77,9 @@ const (
 
 // GetTransactionConfig is a option config for `getTransaction`
 type GetTransactionConfig struct {
-	Encoding   GetTransactionConfigEncoding `json:"encoding,omitempty"`
-	Commitment Commitment                   `json:"commitment,omitempty"` // "processed" is not supported
+	Encoding                       GetTransactionConfigEncoding `json:"encoding,omitempty"`
+	Commitment                     Commitment                   `json:"commitment,omitempty"` // "processed" is not supported
+	MaxSupportedTransactionVersion *uint8                       `json:"maxSupportedTransactionVersion,omitempty"`
 }
 
 type GetTransactionConfigEncoding string
@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+)
+
+// GetVoteAccountsResponse is a full raw rpc response of `getVoteAccounts`
+type GetVoteAccountsResponse struct {
+	GeneralResponse
+	Result GetVoteAccountsResult `json:"result"`
+}
+
+// GetVoteAccountsResult is a part of raw rpc response of `getVoteAccounts`
+type GetVoteAccountsResult struct {
+	Current    []GetVoteAccountsResultValue `json:"current"`
+	Delinquent []GetVoteAccountsResultValue `json:"delinquent"`
+}
+
+// GetVoteAccountsResultValue is a part of raw rpc response of `getVoteAccounts`
+type GetVoteAccountsResultValue struct {
+	VotePubkey       string `json:"votePubkey"`
+	NodePubkey       string `json:"nodePubkey"`
+	ActivatedStake   uint64 `json:"activatedStake"`
+	EpochVoteAccount bool   `json:"epochVoteAccount"`
+	Commission       uint8  `json:"commission"`
+	LastVote         uint64 `json:"lastVote"`
+	// EpochCredits is a list of [epoch, credits, previousCredits]
+	EpochCredits [][3]uint64 `json:"epochCredits"`
+	RootSlot     uint64      `json:"rootSlot"`
+}
+
+// GetVoteAccountsConfig is a option config for `getVoteAccounts`
+type GetVoteAccountsConfig struct {
+	Commitment              Commitment `json:"commitment,omitempty"`
+	VotePubkey              string     `json:"votePubkey,omitempty"`
+	KeepUnstakedDelinquents bool       `json:"keepUnstakedDelinquents,omitempty"`
+	DelinquentSlotDistance  uint64     `json:"delinquentSlotDistance,omitempty"`
+}
+
+// GetVoteAccounts returns the account info and associated stake for all the voting accounts in the current bank
+func (c *RpcClient) GetVoteAccounts(ctx context.Context) (GetVoteAccountsResponse, error) {
+	return c.processGetVoteAccounts(c.Call(ctx, "getVoteAccounts"))
+}
+
+// GetVoteAccountsWithConfig returns the account info and associated stake for all the voting accounts in the current bank
+func (c *RpcClient) GetVoteAccountsWithConfig(ctx context.Context, cfg GetVoteAccountsConfig) (GetVoteAccountsResponse, error) {
+	return c.processGetVoteAccounts(c.Call(ctx, "getVoteAccounts", cfg))
+}
+
+func (c *RpcClient) processGetVoteAccounts(body []byte, rpcErr error) (res GetVoteAccountsResponse, err error) {
+	err = c.processRpcCall(body, rpcErr, &res)
+	return
+}
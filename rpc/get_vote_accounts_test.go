@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetVoteAccounts(t *testing.T) {
+	tests := []testRpcCallParam{
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getVoteAccounts"}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"current":[{"activatedStake":500029968930560,"commission":100,"epochCredits":[[1,64,0],[2,192,64]],"epochVoteAccount":true,"lastVote":147,"nodePubkey":"Cizx4TTBo1gV5Ep1BXPs3ZjJBjBtFnZ8PSDGvpA1yK7F","rootSlot":42,"votePubkey":"3ZT31jkAGhUaw8jsy4bTknwBMP8i4Eueh52By4zXcsVw"}],"delinquent":[]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetVoteAccounts(context.TODO())
+			},
+			ExpectedResponse: GetVoteAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetVoteAccountsResult{
+					Current: []GetVoteAccountsResultValue{
+						{
+							VotePubkey:       "3ZT31jkAGhUaw8jsy4bTknwBMP8i4Eueh52By4zXcsVw",
+							NodePubkey:       "Cizx4TTBo1gV5Ep1BXPs3ZjJBjBtFnZ8PSDGvpA1yK7F",
+							ActivatedStake:   500029968930560,
+							EpochVoteAccount: true,
+							Commission:       100,
+							LastVote:         147,
+							EpochCredits:     [][3]uint64{{1, 64, 0}, {2, 192, 64}},
+							RootSlot:         42,
+						},
+					},
+					Delinquent: []GetVoteAccountsResultValue{},
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"getVoteAccounts", "params":[{"commitment":"confirmed","votePubkey":"3ZT31jkAGhUaw8jsy4bTknwBMP8i4Eueh52By4zXcsVw","keepUnstakedDelinquents":true,"delinquentSlotDistance":128}]}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"current":[],"delinquent":[]},"id":1}`,
+			RpcCall: func(rc RpcClient) (interface{}, error) {
+				return rc.GetVoteAccountsWithConfig(
+					context.TODO(),
+					GetVoteAccountsConfig{
+						Commitment:              CommitmentConfirmed,
+						VotePubkey:              "3ZT31jkAGhUaw8jsy4bTknwBMP8i4Eueh52By4zXcsVw",
+						KeepUnstakedDelinquents: true,
+						DelinquentSlotDistance:  128,
+					},
+				)
+			},
+			ExpectedResponse: GetVoteAccountsResponse{
+				GeneralResponse: GeneralResponse{
+					JsonRPC: "2.0",
+					ID:      1,
+					Error:   nil,
+				},
+				Result: GetVoteAccountsResult{
+					Current:    []GetVoteAccountsResultValue{},
+					Delinquent: []GetVoteAccountsResultValue{},
+				},
+			},
+			ExpectedError: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			testRpcCall(t, tt)
+		})
+	}
+}
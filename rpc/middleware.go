@@ -0,0 +1,26 @@
+package rpc
+
+import (
+	"context"
+)
+
+// Request is a raw json-rpc request passed through middlewares
+type Request struct {
+	// Methods are the methods in the payload, a batch request has more than one
+	Methods []string
+	Payload []byte
+}
+
+// Handler sends a request and returns body of response
+type Handler func(ctx context.Context, r Request) ([]byte, error)
+
+// Middleware wraps a Handler, e.g. for logging or metrics
+type Middleware func(next Handler) Handler
+
+// WithMiddleware is an Option that adds middlewares around each request.
+// they are applied in order, the first one is the outermost. retry and rate limit are always inside them.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(r *RpcClient) {
+		r.middlewares = append(r.middlewares, middlewares...)
+	}
+}
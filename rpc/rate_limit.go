@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit is an Option that limits the client to rps requests per second with a token bucket of size burst.
+// a batch request takes one token. requests wait for a token until ctx is done.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(r *RpcClient) {
+		if rps <= 0 {
+			r.rateLimit = nil
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		bucket := &tokenBucket{
+			rate:   rps,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   time.Now(),
+		}
+		r.rateLimit = func(next Handler) Handler {
+			return func(ctx context.Context, req Request) ([]byte, error) {
+				if err := bucket.wait(ctx); err != nil {
+					return nil, err
+				}
+				return next(ctx, req)
+			}
+		}
+	}
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait takes a token, it blocks until a token is available or ctx is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		d := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
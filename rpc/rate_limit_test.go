@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := rw.Write([]byte(`{"jsonrpc":"2.0","result":1,"id":1}`))
+		assert.Nil(t, err)
+	}))
+	defer server.Close()
+
+	c := New(WithEndpoint(server.URL), WithRateLimit(20, 2))
+
+	// the first two take the burst, the next two wait 50ms each
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		_, err := c.Call(context.Background(), "getSlot")
+		assert.Nil(t, err)
+	}
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(90*time.Millisecond))
+
+	// waiting for a token stops once ctx is done
+	c = New(WithEndpoint(server.URL), WithRateLimit(0.1, 1))
+	_, err := c.Call(context.Background(), "getSlot")
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.Call(ctx, "getSlot")
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
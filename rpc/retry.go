@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryableStatusCodes are http status codes which are usually transient
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryableErrorCodes are json-rpc error codes which are likely to succeed later
+var DefaultRetryableErrorCodes = []int{
+	ErrorCodeBlockNotAvailable,
+	ErrorCodeNodeUnhealthy,
+	ErrorCodeBlockStatusNotAvailableYet,
+	ErrorCodeMinContextSlotNotReached,
+}
+
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt, default is 3. 0 turns retries off.
+	MaxRetries *int
+	// InitialBackoff is the wait before the first retry, it doubles after each retry. default is 200ms
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff, default is 5s. a longer Retry-After from the server is still honored.
+	MaxBackoff time.Duration
+	// RetryableStatusCodes default is DefaultRetryableStatusCodes
+	RetryableStatusCodes []int
+	// RetryableErrorCodes are json-rpc error codes to retry, default is DefaultRetryableErrorCodes
+	RetryableErrorCodes []int
+	// RetrySendTransaction enables retrying requests which contain sendTransaction.
+	// it is off by default because a failed request may still have reached the node.
+	RetrySendTransaction bool
+}
+
+// WithRetry is an Option that retries failed requests with exponential backoff and jitter.
+// transport errors, RetryableStatusCodes and RetryableErrorCodes are retried.
+func WithRetry(cfg RetryConfig) Option {
+	return func(r *RpcClient) {
+		r.retry = newRetryMiddleware(cfg)
+	}
+}
+
+func newRetryMiddleware(cfg RetryConfig) Middleware {
+	maxRetries := 3
+	if cfg.MaxRetries != nil {
+		maxRetries = *cfg.MaxRetries
+	}
+	if cfg.InitialBackoff == 0 {
+		cfg.InitialBackoff = 200 * time.Millisecond
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = 5 * time.Second
+	}
+	if cfg.RetryableStatusCodes == nil {
+		cfg.RetryableStatusCodes = DefaultRetryableStatusCodes
+	}
+	if cfg.RetryableErrorCodes == nil {
+		cfg.RetryableErrorCodes = DefaultRetryableErrorCodes
+	}
+	statusCodes := map[int]bool{}
+	for _, code := range cfg.RetryableStatusCodes {
+		statusCodes[code] = true
+	}
+	errorCodes := map[int]bool{}
+	for _, code := range cfg.RetryableErrorCodes {
+		errorCodes[code] = true
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, r Request) ([]byte, error) {
+			if !cfg.RetrySendTransaction && containsMethod(r.Methods, "sendTransaction") {
+				return next(ctx, r)
+			}
+
+			backoff := cfg.InitialBackoff
+			for attempt := 0; ; attempt++ {
+				body, err := next(ctx, r)
+				if attempt >= maxRetries || ctx.Err() != nil {
+					return body, err
+				}
+
+				var wait time.Duration
+				var httpErr *HTTPError
+				switch {
+				case errors.As(err, &httpErr):
+					if !statusCodes[httpErr.StatusCode] {
+						return body, err
+					}
+					wait = httpErr.RetryAfter
+				case err != nil:
+					// transport error
+				case !hasRetryableError(body, errorCodes):
+					return body, err
+				}
+
+				if jittered := jitter(backoff); jittered > wait {
+					wait = jittered
+				}
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return body, err
+				case <-timer.C:
+				}
+
+				backoff *= 2
+				if backoff > cfg.MaxBackoff {
+					backoff = cfg.MaxBackoff
+				}
+			}
+		}
+	}
+}
+
+// hasRetryableError reports whether the response, or any response of a batch, has a retryable error code
+func hasRetryableError(body []byte, codes map[int]bool) bool {
+	body = bytes.TrimSpace(body)
+	var responses []GeneralResponse
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &responses); err != nil {
+			return false
+		}
+	} else {
+		var res GeneralResponse
+		if err := json.Unmarshal(body, &res); err != nil {
+			return false
+		}
+		responses = append(responses, res)
+	}
+	for _, res := range responses {
+		if res.Error != nil && codes[res.Error.Code] {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// jitter returns a random duration in [d/2, d]
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// parseRetryAfter parses Retry-After in seconds or a http date
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseUint(v, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
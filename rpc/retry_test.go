@@ -0,0 +1,241 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+type testResponse struct {
+	StatusCode int
+	Header     map[string]string
+	Body       string
+}
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           RetryConfig
+		responses     []testResponse
+		call          func(RpcClient) ([]byte, error)
+		expectedCalls int32
+		expectedBody  string
+		expectedError error
+	}{
+		{
+			name: "retry on 429",
+			cfg:  RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []testResponse{
+				{StatusCode: 429, Header: map[string]string{"Retry-After": "0"}},
+				{StatusCode: 503},
+				{StatusCode: 200, Body: `{"jsonrpc":"2.0","result":1,"id":1}`},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				return rc.Call(context.Background(), "getSlot")
+			},
+			expectedCalls: 3,
+			expectedBody:  `{"jsonrpc":"2.0","result":1,"id":1}`,
+		},
+		{
+			name: "retry on json-rpc error",
+			cfg:  RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []testResponse{
+				{StatusCode: 200, Body: `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind by 42 slots"},"id":1}`},
+				{StatusCode: 200, Body: `{"jsonrpc":"2.0","result":1,"id":1}`},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				return rc.Call(context.Background(), "getSlot")
+			},
+			expectedCalls: 2,
+			expectedBody:  `{"jsonrpc":"2.0","result":1,"id":1}`,
+		},
+		{
+			name: "retry a batch on json-rpc error",
+			cfg:  RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []testResponse{
+				{StatusCode: 200, Body: `[{"jsonrpc":"2.0","result":1,"id":1},{"jsonrpc":"2.0","error":{"code":-32016,"message":"Minimum context slot has not been reached"},"id":2}]`},
+				{StatusCode: 200, Body: `[{"jsonrpc":"2.0","result":1,"id":1},{"jsonrpc":"2.0","result":2,"id":2}]`},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				bodies, err := rc.BatchCall(context.Background(), []BatchRequest{NewBatchRequest("getSlot"), NewBatchRequest("getBlockHeight")})
+				if err != nil {
+					return nil, err
+				}
+				return bodies[1], nil
+			},
+			expectedCalls: 2,
+			expectedBody:  `{"jsonrpc":"2.0","result":2,"id":2}`,
+		},
+		{
+			name: "fatal json-rpc error",
+			cfg:  RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []testResponse{
+				{StatusCode: 200, Body: `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params"},"id":1}`},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				return rc.Call(context.Background(), "getSlot")
+			},
+			expectedCalls: 1,
+			expectedBody:  `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params"},"id":1}`,
+		},
+		{
+			name: "fatal status code",
+			cfg:  RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []testResponse{
+				{StatusCode: 401, Body: `unauthorized`},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				return rc.Call(context.Background(), "getSlot")
+			},
+			expectedCalls: 1,
+			expectedBody:  `unauthorized`,
+			expectedError: &HTTPError{StatusCode: 401},
+		},
+		{
+			name: "exceed max retries",
+			cfg:  RetryConfig{MaxRetries: pointer.Int(2), InitialBackoff: time.Millisecond},
+			responses: []testResponse{
+				{StatusCode: 429},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				return rc.Call(context.Background(), "getSlot")
+			},
+			expectedCalls: 3,
+			expectedBody:  ``,
+			expectedError: &HTTPError{StatusCode: 429},
+		},
+		{
+			name: "retries turned off",
+			cfg:  RetryConfig{MaxRetries: pointer.Int(0), InitialBackoff: time.Millisecond},
+			responses: []testResponse{
+				{StatusCode: 429},
+				{StatusCode: 200, Body: `{"jsonrpc":"2.0","result":1,"id":1}`},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				return rc.Call(context.Background(), "getSlot")
+			},
+			expectedCalls: 1,
+			expectedBody:  ``,
+			expectedError: &HTTPError{StatusCode: 429},
+		},
+		{
+			name: "sendTransaction is not retried by default",
+			cfg:  RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []testResponse{
+				{StatusCode: 502},
+				{StatusCode: 200, Body: `{"jsonrpc":"2.0","result":"sig","id":1}`},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				return rc.Call(context.Background(), "sendTransaction", "tx")
+			},
+			expectedCalls: 1,
+			expectedBody:  ``,
+			expectedError: &HTTPError{StatusCode: 502},
+		},
+		{
+			name: "retry sendTransaction",
+			cfg:  RetryConfig{InitialBackoff: time.Millisecond, RetrySendTransaction: true},
+			responses: []testResponse{
+				{StatusCode: 502},
+				{StatusCode: 200, Body: `{"jsonrpc":"2.0","result":"sig","id":1}`},
+			},
+			call: func(rc RpcClient) ([]byte, error) {
+				return rc.Call(context.Background(), "sendTransaction", "tx")
+			},
+			expectedCalls: 2,
+			expectedBody:  `{"jsonrpc":"2.0","result":"sig","id":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				i := int(atomic.AddInt32(&calls, 1)) - 1
+				if i >= len(tt.responses) {
+					i = len(tt.responses) - 1
+				}
+				res := tt.responses[i]
+				for k, v := range res.Header {
+					rw.Header().Set(k, v)
+				}
+				rw.WriteHeader(res.StatusCode)
+				_, err := rw.Write([]byte(res.Body))
+				assert.Nil(t, err)
+			}))
+			defer server.Close()
+
+			c := New(WithEndpoint(server.URL), WithRetry(tt.cfg))
+			body, err := tt.call(c)
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedBody, string(body))
+			assert.Equal(t, tt.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestWithRetry_ContextDone(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.Header().Set("Retry-After", "10")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c := New(WithEndpoint(server.URL), WithRetry(RetryConfig{}))
+	_, err := c.Call(ctx, "getSlot")
+	assert.Equal(t, &HTTPError{StatusCode: 429, RetryAfter: 10 * time.Second}, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("invalid"))
+
+	d := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, d > 58*time.Second && d <= time.Minute, d)
+}
+
+func TestWithMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := rw.Write([]byte(`{"jsonrpc":"2.0","result":1,"id":1}`))
+		assert.Nil(t, err)
+	}))
+	defer server.Close()
+
+	order := []string{}
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, r Request) ([]byte, error) {
+				order = append(order, name)
+				assert.Equal(t, []string{"getSlot"}, r.Methods)
+				return next(ctx, r)
+			}
+		}
+	}
+	errMiddleware := func(next Handler) Handler {
+		return func(ctx context.Context, r Request) ([]byte, error) {
+			return nil, errors.New("blocked")
+		}
+	}
+
+	c := New(WithEndpoint(server.URL), WithMiddleware(record("a"), record("b")))
+	_, err := c.Call(context.Background(), "getSlot")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, order)
+
+	c = New(WithEndpoint(server.URL), WithMiddleware(errMiddleware))
+	_, err = c.Call(context.Background(), "getSlot")
+	assert.Equal(t, errors.New("blocked"), err)
+}
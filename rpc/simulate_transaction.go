@@ -18,9 +18,10 @@ type SimulateTransactionResponseResult struct {
 
 // SimulateTransactionResponseResultValue is a part of SimulateTransactionResponseResult
 type SimulateTransactionResponseResultValue struct {
-	Err      interface{}    `json:"err"`
-	Logs     []string       `json:"logs,omitempty"`
-	Accounts []*AccountInfo `json:"accounts,omitempty"`
+	Err           interface{}    `json:"err"`
+	Logs          []string       `json:"logs,omitempty"`
+	Accounts      []*AccountInfo `json:"accounts,omitempty"`
+	UnitsConsumed *uint64        `json:"unitsConsumed,omitempty"`
 }
 
 type SimulateTransactionConfig struct {
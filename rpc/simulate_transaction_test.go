@@ -3,13 +3,15 @@ package rpc
 import (
 	"context"
 	"testing"
+
+	"github.com/portto/solana-go-sdk/pkg/pointer"
 )
 
 func TestSimulateTransaction(t *testing.T) {
 	tests := []testRpcCallParam{
 		{
 			RequestBody:  `{"jsonrpc":"2.0", "id":1, "method":"simulateTransaction", "params":["5nxpoKAc5anKyiJuwj5f2SLxnruNHDjpFFz1TAw5VvpL1L4TbF4mVUMwiH36uBMnJEhpxqtKvjPFMaBms2vNe2LYQjydRs2niy5pBsBjjxif5mxkEa3S27pc5epeYATPA9Xhgagz2TDzniZEYQgQ6uEGyKGJRQ2AX9qpTY7LtHxN8sUqn5SuZAMnM27iZ9bwwyjjBGepRmz1mfQfFvSV92exnJRjCrzcR5VPuViSAxDtwZFVzB8CVcA3M9ZFaUn8mhTe9U8wKFYEm1mH9cPjWHpwm5h4S2yvMVSw1"]}`,
-			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":80208054},"value":{"accounts":null,"err":null,"logs":["Program 11111111111111111111111111111111 invoke [1]","Program 11111111111111111111111111111111 success"]}},"id":1}`,
+			ResponseBody: `{"jsonrpc":"2.0","result":{"context":{"slot":80208054},"value":{"accounts":null,"err":null,"logs":["Program 11111111111111111111111111111111 invoke [1]","Program 11111111111111111111111111111111 success"],"unitsConsumed":150}},"id":1}`,
 			RpcCall: func(rc RpcClient) (interface{}, error) {
 				return rc.SimulateTransaction(
 					context.TODO(),
@@ -27,7 +29,8 @@ func TestSimulateTransaction(t *testing.T) {
 						Slot: 80208054,
 					},
 					Value: SimulateTransactionResponseResultValue{
-						Logs: []string{"Program 11111111111111111111111111111111 invoke [1]", "Program 11111111111111111111111111111111 success"},
+						Logs:          []string{"Program 11111111111111111111111111111111 invoke [1]", "Program 11111111111111111111111111111111 success"},
+						UnitsConsumed: pointer.Uint64(150),
 					},
 				},
 			},
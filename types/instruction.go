@@ -1,6 +1,18 @@
 package types
 
-import "github.com/portto/solana-go-sdk/common"
+import (
+	"errors"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// errors returned by DecodeInstruction of program packages
+var (
+	ErrProgramIDMismatch      = errors.New("program id mismatch")
+	ErrUnknownInstruction     = errors.New("unknown instruction")
+	ErrInvalidInstructionData = errors.New("invalid instruction data")
+	ErrNotEnoughAccounts      = errors.New("not enough accounts")
+)
 
 type CompiledInstruction struct {
 	ProgramIDIndex int
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrAccountFailedToParseKeypair = errors.New("failed to parse keypair")
+
+// DefaultKeypairPath returns the default keypair path of the solana cli, ~/.config/solana/id.json
+func DefaultKeypairPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "solana", "id.json"), nil
+}
+
+// AccountFromKeypairJSON generate a account by a json byte array keypair which is produced by solana-keygen
+func AccountFromKeypairJSON(b []byte) (Account, error) {
+	var key []byte
+	if err := json.Unmarshal(b, &key); err != nil {
+		return Account{}, fmt.Errorf("%w, err: %v", ErrAccountFailedToParseKeypair, err)
+	}
+	return AccountFromBytes(key)
+}
+
+// AccountFromKeypairFile generate a account by a keypair file which is produced by solana-keygen
+func AccountFromKeypairFile(path string) (Account, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Account{}, err
+	}
+	return AccountFromKeypairJSON(b)
+}
+
+// AccountFromPath generate a account by a keypair path. an empty path is DefaultKeypairPath and
+// a leading ~ is expanded to the home directory.
+func AccountFromPath(path string) (Account, error) {
+	path, err := expandKeypairPath(path)
+	if err != nil {
+		return Account{}, err
+	}
+	return AccountFromKeypairFile(path)
+}
+
+// KeypairJSON returns the keypair in the solana-keygen format
+func (a Account) KeypairJSON() []byte {
+	numbers := make([]string, 0, len(a.PrivateKey))
+	for _, b := range a.PrivateKey {
+		numbers = append(numbers, fmt.Sprint(b))
+	}
+	return []byte("[" + strings.Join(numbers, ",") + "]")
+}
+
+// SaveKeypairFile writes the keypair in the solana-keygen format. the file is only readable by the owner
+// and the parent directories are created if they don't exist.
+func (a Account) SaveKeypairFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, a.KeypairJSON(), 0o600)
+}
+
+func expandKeypairPath(path string) (string, error) {
+	if path == "" {
+		return DefaultKeypairPath()
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, path[1:]), nil
+	}
+	return path, nil
+}
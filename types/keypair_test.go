@@ -0,0 +1,84 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountFromKeypairJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want common.PublicKey
+		err  error
+	}{
+		{
+			name: "keypair",
+			json: "[214,49,53,208,232,140,85,41,45,128,173,3,79,105,136,236,132,164,35,93,59,196,51,59,127,139,1,155,245,83,230,184,21,109,62,131,66,207,210,237,39,93,125,50,137,69,236,28,138,68,1,30,175,228,109,140,77,52,105,79,223,111,131,31]",
+			want: common.PublicKeyFromString("2SeBK1pUxnVbY82vN4TEJiWh4GwaGDkffxPegQP3DFPk"),
+		},
+		{
+			name: "invalid json",
+			json: "[214,49",
+			err:  ErrAccountFailedToParseKeypair,
+		},
+		{
+			name: "length mismatch",
+			json: "[1,2,3]",
+			err:  ErrAccountPrivateKeyLengthMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AccountFromKeypairJSON([]byte(tt.json))
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got.PublicKey)
+			if err == nil {
+				assert.Equal(t, tt.json, string(got.KeypairJSON()))
+			}
+		})
+	}
+}
+
+func TestAccount_SaveKeypairFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keys", "id.json")
+
+	account := NewAccount()
+	assert.NoError(t, account.SaveKeypairFile(path))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	got, err := AccountFromKeypairFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, account, got)
+
+	got, err = AccountFromPath(path)
+	assert.NoError(t, err)
+	assert.Equal(t, account, got)
+}
+
+func TestAccountFromPath_Default(t *testing.T) {
+	home := t.TempDir()
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+
+	defaultPath, err := DefaultKeypairPath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".config", "solana", "id.json"), defaultPath)
+
+	account := NewAccount()
+	assert.NoError(t, account.SaveKeypairFile(defaultPath))
+
+	for _, path := range []string{"", "~/.config/solana/id.json"} {
+		got, err := AccountFromPath(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, account, got, path)
+	}
+}
@@ -12,21 +12,58 @@ import (
 	"github.com/mr-tron/base58"
 )
 
+type MessageVersion string
+
+const (
+	MessageVersionLegacy MessageVersion = "legacy"
+	MessageVersionV0     MessageVersion = "v0"
+)
+
+// messageVersionPrefix is the highest bit of the first byte. a versioned message sets it and
+// stores its version number in the remaining bits, a legacy message starts with its header instead.
+const messageVersionPrefix = 0x80
+
 type MessageHeader struct {
 	NumRequireSignatures        uint8
 	NumReadonlySignedAccounts   uint8
 	NumReadonlyUnsignedAccounts uint8
 }
 
+// CompiledAddressLookupTable is an address table lookup used by a v0 message to load accounts
+type CompiledAddressLookupTable struct {
+	AccountKey      common.PublicKey
+	WritableIndexes []uint8
+	ReadonlyIndexes []uint8
+}
+
+// AddressLookupTableAccount is an on-chain lookup table and the addresses it stores
+type AddressLookupTableAccount struct {
+	Key       common.PublicKey
+	Addresses []common.PublicKey
+}
+
 type Message struct {
-	Header          MessageHeader
-	Accounts        []common.PublicKey
-	RecentBlockHash string
-	Instructions    []CompiledInstruction
+	Version             MessageVersion
+	Header              MessageHeader
+	Accounts            []common.PublicKey
+	RecentBlockHash     string
+	Instructions        []CompiledInstruction
+	AddressLookupTables []CompiledAddressLookupTable
 }
 
 func (m *Message) Serialize() ([]byte, error) {
 	b := []byte{}
+	switch m.Version {
+	case "", MessageVersionLegacy:
+		if len(m.AddressLookupTables) > 0 {
+			return nil, errors.New("legacy message doesn't support address lookup tables")
+		}
+	case MessageVersionV0:
+		b = append(b, messageVersionPrefix|0)
+	default:
+		return nil, fmt.Errorf("unsupported message version: %v", m.Version)
+	}
+
 	b = append(b, m.Header.NumRequireSignatures)
 	b = append(b, m.Header.NumReadonlySignedAccounts)
 	b = append(b, m.Header.NumReadonlyUnsignedAccounts)
@@ -53,32 +90,153 @@ func (m *Message) Serialize() ([]byte, error) {
 		b = append(b, bincode.UintToVarLenBytes(uint64(len(instruction.Data)))...)
 		b = append(b, instruction.Data...)
 	}
+
+	if m.Version == MessageVersionV0 {
+		b = append(b, bincode.UintToVarLenBytes(uint64(len(m.AddressLookupTables)))...)
+		for _, addressLookupTable := range m.AddressLookupTables {
+			b = append(b, addressLookupTable.AccountKey.Bytes()...)
+			b = append(b, bincode.UintToVarLenBytes(uint64(len(addressLookupTable.WritableIndexes)))...)
+			b = append(b, addressLookupTable.WritableIndexes...)
+			b = append(b, bincode.UintToVarLenBytes(uint64(len(addressLookupTable.ReadonlyIndexes)))...)
+			b = append(b, addressLookupTable.ReadonlyIndexes...)
+		}
+	}
 	return b, nil
 }
 
+// IsSigner reports whether the static account at the index is a signer
+func (m *Message) IsSigner(index int) bool {
+	return index < int(m.Header.NumRequireSignatures)
+}
+
+// IsWritable reports whether the static account at the index is writable, accounts loaded from
+// address lookup tables are not included
+func (m *Message) IsWritable(index int) bool {
+	if index >= len(m.Accounts) {
+		return false
+	}
+	if m.IsSigner(index) {
+		return index < int(m.Header.NumRequireSignatures-m.Header.NumReadonlySignedAccounts)
+	}
+	return index < len(m.Accounts)-int(m.Header.NumReadonlyUnsignedAccounts)
+}
+
+// WritableAccounts returns the writable static accounts
+func (m *Message) WritableAccounts() []common.PublicKey {
+	accounts := []common.PublicKey{}
+	for i, account := range m.Accounts {
+		if m.IsWritable(i) {
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts
+}
+
+// DecompileInstructions converts compiled instructions back to instructions.
+// it returns nil if the message loads accounts from address lookup tables or an account index is out of range,
+// use DecompileInstructionsWithAddressLookupTables to resolve looked-up accounts and get the error.
 func (m *Message) DecompileInstructions() []Instruction {
+	instructions, err := m.DecompileInstructionsWithAddressLookupTables(nil)
+	if err != nil {
+		return nil
+	}
+	return instructions
+}
+
+// DecompileInstructionsWithAddressLookupTables converts compiled instructions back to instructions.
+// accounts loaded by the message's address table lookups are resolved from the given lookup table accounts.
+func (m *Message) DecompileInstructionsWithAddressLookupTables(addressLookupTableAccounts []AddressLookupTableAccount) ([]Instruction, error) {
+	writableLoadedAccounts, readonlyLoadedAccounts, err := m.LoadAddressLookupTableAccounts(addressLookupTableAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]common.PublicKey, 0, len(m.Accounts)+len(writableLoadedAccounts)+len(readonlyLoadedAccounts))
+	accounts = append(accounts, m.Accounts...)
+	accounts = append(accounts, writableLoadedAccounts...)
+	accounts = append(accounts, readonlyLoadedAccounts...)
+	writableLoadedAccountEnd := len(m.Accounts) + len(writableLoadedAccounts)
+
+	isWritable := func(idx int) bool {
+		if idx >= len(m.Accounts) {
+			return idx < writableLoadedAccountEnd
+		}
+		return idx < int(m.Header.NumRequireSignatures-m.Header.NumReadonlySignedAccounts) ||
+			(idx >= int(m.Header.NumRequireSignatures) &&
+				idx < len(m.Accounts)-int(m.Header.NumReadonlyUnsignedAccounts))
+	}
+
 	instructions := make([]Instruction, 0, len(m.Instructions))
-	for _, cins := range m.Instructions {
-		accounts := make([]AccountMeta, 0, len(cins.Accounts))
-		for i := 0; i < len(cins.Accounts); i++ {
-			accounts = append(accounts, AccountMeta{
-				PubKey:   m.Accounts[cins.Accounts[i]],
-				IsSigner: cins.Accounts[i] < int(m.Header.NumRequireSignatures),
-				IsWritable: cins.Accounts[i] < int(m.Header.NumRequireSignatures-m.Header.NumReadonlySignedAccounts) ||
-					(cins.Accounts[i] >= int(m.Header.NumRequireSignatures) &&
-						cins.Accounts[i] < len(m.Accounts)-int(m.Header.NumReadonlyUnsignedAccounts)),
+	for i, cins := range m.Instructions {
+		if cins.ProgramIDIndex >= len(accounts) {
+			return nil, fmt.Errorf("instruction #%d program id index out of range", i+1)
+		}
+		metas := make([]AccountMeta, 0, len(cins.Accounts))
+		for j := 0; j < len(cins.Accounts); j++ {
+			if cins.Accounts[j] >= len(accounts) {
+				return nil, fmt.Errorf("instruction #%d account #%d index out of range", i+1, j+1)
+			}
+			metas = append(metas, AccountMeta{
+				PubKey:     accounts[cins.Accounts[j]],
+				IsSigner:   cins.Accounts[j] < int(m.Header.NumRequireSignatures),
+				IsWritable: isWritable(cins.Accounts[j]),
 			})
 		}
 		instructions = append(instructions, Instruction{
-			ProgramID: m.Accounts[cins.ProgramIDIndex],
-			Accounts:  accounts,
+			ProgramID: accounts[cins.ProgramIDIndex],
+			Accounts:  metas,
 			Data:      cins.Data,
 		})
 	}
-	return instructions
+	return instructions, nil
+}
+
+// LoadAddressLookupTableAccounts resolves the accounts loaded by the message's address table lookups.
+// the returned accounts are ordered the way the runtime appends them after the static accounts.
+func (m *Message) LoadAddressLookupTableAccounts(addressLookupTableAccounts []AddressLookupTableAccount) ([]common.PublicKey, []common.PublicKey, error) {
+	tables := map[common.PublicKey][]common.PublicKey{}
+	for _, addressLookupTableAccount := range addressLookupTableAccounts {
+		tables[addressLookupTableAccount.Key] = addressLookupTableAccount.Addresses
+	}
+
+	writableAccounts := []common.PublicKey{}
+	readonlyAccounts := []common.PublicKey{}
+	for _, addressLookupTable := range m.AddressLookupTables {
+		addresses, ok := tables[addressLookupTable.AccountKey]
+		if !ok {
+			return nil, nil, fmt.Errorf("address lookup table %v not found", addressLookupTable.AccountKey.ToBase58())
+		}
+		for _, idx := range addressLookupTable.WritableIndexes {
+			if int(idx) >= len(addresses) {
+				return nil, nil, fmt.Errorf("address lookup table %v index %v out of range", addressLookupTable.AccountKey.ToBase58(), idx)
+			}
+			writableAccounts = append(writableAccounts, addresses[idx])
+		}
+		for _, idx := range addressLookupTable.ReadonlyIndexes {
+			if int(idx) >= len(addresses) {
+				return nil, nil, fmt.Errorf("address lookup table %v index %v out of range", addressLookupTable.AccountKey.ToBase58(), idx)
+			}
+			readonlyAccounts = append(readonlyAccounts, addresses[idx])
+		}
+	}
+	return writableAccounts, readonlyAccounts, nil
 }
 
 func MessageDeserialize(messageData []byte) (Message, error) {
+	if len(messageData) == 0 {
+		return Message{}, errors.New("message data is empty")
+	}
+	version := MessageVersionLegacy
+	if messageData[0]&messageVersionPrefix != 0 {
+		switch messageData[0] &^ messageVersionPrefix {
+		case 0:
+			version = MessageVersionV0
+		default:
+			return Message{}, fmt.Errorf("unsupported message version: %v", messageData[0]&^messageVersionPrefix)
+		}
+		messageData = messageData[1:]
+	}
+
 	var numRequireSignatures, numReadonlySignedAccounts, numReadonlyUnsignedAccounts uint8
 	var t uint64
 	var err error
@@ -137,6 +295,9 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		if err != nil {
 			return Message{}, fmt.Errorf("parse instruction #%d data length error: %v", i+1, err)
 		}
+		if uint64(len(messageData)) < dataLen {
+			return Message{}, fmt.Errorf("parse instruction #%d data error", i+1)
+		}
 		var data []byte
 		data, messageData = messageData[:dataLen], messageData[dataLen:]
 
@@ -147,18 +308,65 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		})
 	}
 
+	var addressLookupTables []CompiledAddressLookupTable
+	if version == MessageVersionV0 {
+		addressLookupTableCount, err := parseUvarint(&messageData)
+		if err != nil {
+			return Message{}, fmt.Errorf("parse address lookup table count error: %v", err)
+		}
+		addressLookupTables = make([]CompiledAddressLookupTable, 0, addressLookupTableCount)
+		for i := 0; i < int(addressLookupTableCount); i++ {
+			if len(messageData) < 32 {
+				return Message{}, fmt.Errorf("parse address lookup table #%d account key error", i+1)
+			}
+			accountKey := common.PublicKeyFromBytes(messageData[:32])
+			messageData = messageData[32:]
+
+			writableIndexes, err := parseIndexes(&messageData)
+			if err != nil {
+				return Message{}, fmt.Errorf("parse address lookup table #%d writable indexes error: %v", i+1, err)
+			}
+			readonlyIndexes, err := parseIndexes(&messageData)
+			if err != nil {
+				return Message{}, fmt.Errorf("parse address lookup table #%d readonly indexes error: %v", i+1, err)
+			}
+
+			addressLookupTables = append(addressLookupTables, CompiledAddressLookupTable{
+				AccountKey:      accountKey,
+				WritableIndexes: writableIndexes,
+				ReadonlyIndexes: readonlyIndexes,
+			})
+		}
+	}
+
 	return Message{
+		Version: version,
 		Header: MessageHeader{
 			NumRequireSignatures:        numRequireSignatures,
 			NumReadonlySignedAccounts:   numReadonlySignedAccounts,
 			NumReadonlyUnsignedAccounts: numReadonlyUnsignedAccounts,
 		},
-		Accounts:        accounts,
-		RecentBlockHash: blockHash,
-		Instructions:    instructions,
+		Accounts:            accounts,
+		RecentBlockHash:     blockHash,
+		Instructions:        instructions,
+		AddressLookupTables: addressLookupTables,
 	}, nil
 }
 
+func parseIndexes(messageData *[]byte) ([]uint8, error) {
+	count, err := parseUvarint(messageData)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(*messageData)) < count {
+		return nil, errors.New("data is not enough")
+	}
+	indexes := make([]uint8, count)
+	copy(indexes, (*messageData)[:count])
+	*messageData = (*messageData)[count:]
+	return indexes, nil
+}
+
 func MustMessageDeserialize(messageData []byte) Message {
 	message, err := MessageDeserialize(messageData)
 	if err != nil {
@@ -171,11 +379,17 @@ type NewMessageParam struct {
 	FeePayer        common.PublicKey
 	Instructions    []Instruction
 	RecentBlockhash string
+
+	// AddressLookupTableAccounts makes NewMessage compile a v0 message.
+	// non-signer accounts which are not invoked programs will be loaded from these tables if possible.
+	AddressLookupTableAccounts []AddressLookupTableAccount
 }
 
 func NewMessage(param NewMessageParam) Message {
+	programIDs := map[common.PublicKey]bool{}
 	accountMap := map[common.PublicKey]*AccountMeta{}
 	for _, instruction := range param.Instructions {
+		programIDs[instruction.ProgramID] = true
 		// program is a readonly unsigned account
 		_, exist := accountMap[instruction.ProgramID]
 		if !exist {
@@ -246,6 +460,30 @@ func NewMessage(param NewMessageParam) Message {
 		sortAllAccount()
 	}
 
+	version := MessageVersionLegacy
+	var addressLookupTables []CompiledAddressLookupTable
+	writableLoadedAccount := []common.PublicKey{}
+	readOnlyLoadedAccount := []common.PublicKey{}
+	if len(param.AddressLookupTableAccounts) > 0 {
+		version = MessageVersionV0
+		for _, addressLookupTableAccount := range param.AddressLookupTableAccounts {
+			var writableIndexes, readonlyIndexes []uint8
+			var writableDrained, readonlyDrained []common.PublicKey
+			writableUnsignedAccount, writableIndexes, writableDrained = drainLookupTableAccounts(writableUnsignedAccount, addressLookupTableAccount.Addresses, programIDs)
+			readOnlyUnsignedAccount, readonlyIndexes, readonlyDrained = drainLookupTableAccounts(readOnlyUnsignedAccount, addressLookupTableAccount.Addresses, programIDs)
+			if len(writableIndexes) == 0 && len(readonlyIndexes) == 0 {
+				continue
+			}
+			addressLookupTables = append(addressLookupTables, CompiledAddressLookupTable{
+				AccountKey:      addressLookupTableAccount.Key,
+				WritableIndexes: writableIndexes,
+				ReadonlyIndexes: readonlyIndexes,
+			})
+			writableLoadedAccount = append(writableLoadedAccount, writableDrained...)
+			readOnlyLoadedAccount = append(readOnlyLoadedAccount, readonlyDrained...)
+		}
+	}
+
 	publicKeys := make([]common.PublicKey, 0, len(writableSignedAccount)+len(readOnlySignedAccount)+len(writableUnsignedAccount)+len(readOnlyUnsignedAccount))
 	publicKeys = append(publicKeys, writableSignedAccount...)
 	publicKeys = append(publicKeys, readOnlySignedAccount...)
@@ -255,6 +493,12 @@ func NewMessage(param NewMessageParam) Message {
 	for idx, publicKey := range publicKeys {
 		publicKeyToIdx[publicKey] = idx
 	}
+	for idx, publicKey := range writableLoadedAccount {
+		publicKeyToIdx[publicKey] = len(publicKeys) + idx
+	}
+	for idx, publicKey := range readOnlyLoadedAccount {
+		publicKeyToIdx[publicKey] = len(publicKeys) + len(writableLoadedAccount) + idx
+	}
 
 	compiledInstructions := []CompiledInstruction{}
 	for _, instruction := range param.Instructions {
@@ -270,13 +514,41 @@ func NewMessage(param NewMessageParam) Message {
 	}
 
 	return Message{
+		Version: version,
 		Header: MessageHeader{
 			NumRequireSignatures:        uint8(len(writableSignedAccount) + len(readOnlySignedAccount)),
 			NumReadonlySignedAccounts:   uint8(len(readOnlySignedAccount)),
 			NumReadonlyUnsignedAccounts: uint8(len(readOnlyUnsignedAccount)),
 		},
-		Accounts:        publicKeys,
-		RecentBlockHash: param.RecentBlockhash,
-		Instructions:    compiledInstructions,
+		Accounts:            publicKeys,
+		RecentBlockHash:     param.RecentBlockhash,
+		Instructions:        compiledInstructions,
+		AddressLookupTables: addressLookupTables,
+	}
+}
+
+// drainLookupTableAccounts moves accounts which can be found in the lookup table out of the static accounts.
+// invoked programs must stay in the static accounts. only the first 256 addresses can be referenced by a u8 index.
+func drainLookupTableAccounts(accounts []common.PublicKey, addresses []common.PublicKey, programIDs map[common.PublicKey]bool) ([]common.PublicKey, []uint8, []common.PublicKey) {
+	addressToIdx := map[common.PublicKey]uint8{}
+	for idx := len(addresses) - 1; idx >= 0; idx-- {
+		if idx > 255 {
+			continue
+		}
+		addressToIdx[addresses[idx]] = uint8(idx)
+	}
+
+	remaining := make([]common.PublicKey, 0, len(accounts))
+	indexes := []uint8{}
+	drained := []common.PublicKey{}
+	for _, account := range accounts {
+		idx, ok := addressToIdx[account]
+		if !ok || programIDs[account] {
+			remaining = append(remaining, account)
+			continue
+		}
+		indexes = append(indexes, idx)
+		drained = append(drained, account)
 	}
+	return remaining, indexes, drained
 }
@@ -124,3 +124,384 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 		})
 	}
 }
+
+func TestMessage_SerializeV0(t *testing.T) {
+	tests := []struct {
+		name    string
+		message Message
+		want    []byte
+		wantErr bool
+	}{
+		{
+			message: Message{
+				Version: MessageVersionV0,
+				Header: MessageHeader{
+					NumRequireSignatures:        1,
+					NumReadonlySignedAccounts:   0,
+					NumReadonlyUnsignedAccounts: 1,
+				},
+				Accounts: []common.PublicKey{
+					common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					common.SystemProgramID,
+				},
+				RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				Instructions: []CompiledInstruction{
+					{
+						ProgramIDIndex: 1,
+						Accounts:       []int{0, 2, 3},
+						Data:           []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+					},
+				},
+				AddressLookupTables: []CompiledAddressLookupTable{
+					{
+						AccountKey:      common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"),
+						WritableIndexes: []uint8{2},
+						ReadonlyIndexes: []uint8{0},
+					},
+				},
+			},
+			want:    []byte{128, 1, 0, 1, 2, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 221, 244, 189, 59, 8, 252, 7, 91, 129, 169, 22, 151, 32, 104, 208, 131, 64, 75, 232, 201, 77, 13, 187, 220, 103, 232, 190, 100, 35, 210, 17, 42, 1, 1, 3, 0, 2, 3, 12, 2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1, 241, 61, 2, 62, 211, 181, 33, 219, 74, 147, 127, 38, 231, 159, 99, 194, 103, 129, 201, 15, 51, 106, 114, 199, 122, 142, 121, 87, 112, 78, 138, 249, 1, 2, 1, 0},
+			wantErr: false,
+		},
+		{
+			message: Message{
+				Version: MessageVersionLegacy,
+				Header: MessageHeader{
+					NumRequireSignatures:        1,
+					NumReadonlySignedAccounts:   0,
+					NumReadonlyUnsignedAccounts: 1,
+				},
+				Accounts: []common.PublicKey{
+					common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					common.SystemProgramID,
+				},
+				RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				AddressLookupTables: []CompiledAddressLookupTable{
+					{
+						AccountKey:      common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"),
+						WritableIndexes: []uint8{2},
+						ReadonlyIndexes: []uint8{0},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.message.Serialize()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Message.Serialize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Message.Serialize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageDeserialize(t *testing.T) {
+	tests := []struct {
+		name        string
+		messageData []byte
+		want        Message
+		wantErr     bool
+	}{
+		{
+			messageData: []byte{1, 0, 1, 3, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 134, 172, 209, 213, 227, 137, 61, 108, 116, 171, 205, 124, 54, 68, 61, 110, 80, 31, 240, 117, 108, 137, 97, 222, 38, 242, 68, 156, 27, 65, 29, 142, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 221, 244, 189, 59, 8, 252, 7, 91, 129, 169, 22, 151, 32, 104, 208, 131, 64, 75, 232, 201, 77, 13, 187, 220, 103, 232, 190, 100, 35, 210, 17, 42, 1, 2, 2, 0, 1, 12, 2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+			want: Message{
+				Version: MessageVersionLegacy,
+				Header: MessageHeader{
+					NumRequireSignatures:        1,
+					NumReadonlySignedAccounts:   0,
+					NumReadonlyUnsignedAccounts: 1,
+				},
+				Accounts: []common.PublicKey{
+					common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"),
+					common.SystemProgramID,
+				},
+				RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				Instructions: []CompiledInstruction{
+					{
+						ProgramIDIndex: 2,
+						Accounts:       []int{0, 1},
+						Data:           []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			messageData: []byte{128, 1, 0, 1, 2, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 221, 244, 189, 59, 8, 252, 7, 91, 129, 169, 22, 151, 32, 104, 208, 131, 64, 75, 232, 201, 77, 13, 187, 220, 103, 232, 190, 100, 35, 210, 17, 42, 1, 1, 3, 0, 2, 3, 12, 2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1, 241, 61, 2, 62, 211, 181, 33, 219, 74, 147, 127, 38, 231, 159, 99, 194, 103, 129, 201, 15, 51, 106, 114, 199, 122, 142, 121, 87, 112, 78, 138, 249, 1, 2, 1, 0},
+			want: Message{
+				Version: MessageVersionV0,
+				Header: MessageHeader{
+					NumRequireSignatures:        1,
+					NumReadonlySignedAccounts:   0,
+					NumReadonlyUnsignedAccounts: 1,
+				},
+				Accounts: []common.PublicKey{
+					common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					common.SystemProgramID,
+				},
+				RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				Instructions: []CompiledInstruction{
+					{
+						ProgramIDIndex: 1,
+						Accounts:       []int{0, 2, 3},
+						Data:           []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+					},
+				},
+				AddressLookupTables: []CompiledAddressLookupTable{
+					{
+						AccountKey:      common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"),
+						WritableIndexes: []uint8{2},
+						ReadonlyIndexes: []uint8{0},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			messageData: []byte{129, 1, 0, 1},
+			want:        Message{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MessageDeserialize(tt.messageData)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MessageDeserialize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MessageDeserialize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMessage(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	lookupTable := common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY")
+	instructions := []Instruction{
+		{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer, IsSigner: true, IsWritable: true},
+				{PubKey: to, IsSigner: false, IsWritable: true},
+				{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			},
+			Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		param NewMessageParam
+		want  Message
+	}{
+		{
+			param: NewMessageParam{
+				FeePayer:        feePayer,
+				Instructions:    instructions,
+				RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			},
+			want: Message{
+				Version: MessageVersionLegacy,
+				Header: MessageHeader{
+					NumRequireSignatures:        1,
+					NumReadonlySignedAccounts:   0,
+					NumReadonlyUnsignedAccounts: 2,
+				},
+				Accounts: []common.PublicKey{
+					feePayer,
+					to,
+					common.SystemProgramID,
+					common.SysVarClockPubkey,
+				},
+				RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				Instructions: []CompiledInstruction{
+					{
+						ProgramIDIndex: 2,
+						Accounts:       []int{0, 1, 3},
+						Data:           []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+					},
+				},
+			},
+		},
+		{
+			param: NewMessageParam{
+				FeePayer:        feePayer,
+				Instructions:    instructions,
+				RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				AddressLookupTableAccounts: []AddressLookupTableAccount{
+					{
+						Key:       common.PublicKeyFromString("9HvwukipCq1TVcSWoNQW7ajTUDFyC16KrARqnXppBdwX"),
+						Addresses: []common.PublicKey{common.StakeProgramID},
+					},
+					{
+						Key:       lookupTable,
+						Addresses: []common.PublicKey{common.SysVarClockPubkey, common.SystemProgramID, to},
+					},
+				},
+			},
+			want: Message{
+				Version: MessageVersionV0,
+				Header: MessageHeader{
+					NumRequireSignatures:        1,
+					NumReadonlySignedAccounts:   0,
+					NumReadonlyUnsignedAccounts: 1,
+				},
+				Accounts: []common.PublicKey{
+					feePayer,
+					common.SystemProgramID,
+				},
+				RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				Instructions: []CompiledInstruction{
+					{
+						ProgramIDIndex: 1,
+						Accounts:       []int{0, 2, 3},
+						Data:           []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+					},
+				},
+				AddressLookupTables: []CompiledAddressLookupTable{
+					{
+						AccountKey:      lookupTable,
+						WritableIndexes: []uint8{2},
+						ReadonlyIndexes: []uint8{0},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMessage(tt.param); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessage_DecompileInstructionsWithAddressLookupTables(t *testing.T) {
+	lookupTable := common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY")
+	message := Message{
+		Version: MessageVersionV0,
+		Header: MessageHeader{
+			NumRequireSignatures:        1,
+			NumReadonlySignedAccounts:   0,
+			NumReadonlyUnsignedAccounts: 1,
+		},
+		Accounts: []common.PublicKey{
+			common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+			common.SystemProgramID,
+		},
+		RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		Instructions: []CompiledInstruction{
+			{
+				ProgramIDIndex: 1,
+				Accounts:       []int{0, 2, 3},
+				Data:           []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		AddressLookupTables: []CompiledAddressLookupTable{
+			{
+				AccountKey:      lookupTable,
+				WritableIndexes: []uint8{2},
+				ReadonlyIndexes: []uint8{0},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                       string
+		addressLookupTableAccounts []AddressLookupTableAccount
+		want                       []Instruction
+		wantErr                    bool
+	}{
+		{
+			addressLookupTableAccounts: []AddressLookupTableAccount{
+				{
+					Key: lookupTable,
+					Addresses: []common.PublicKey{
+						common.SysVarClockPubkey,
+						common.SystemProgramID,
+						common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"),
+					},
+				},
+			},
+			want: []Instruction{
+				{
+					ProgramID: common.SystemProgramID,
+					Accounts: []AccountMeta{
+						{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: true},
+						{PubKey: common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"), IsSigner: false, IsWritable: true},
+						{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+					},
+					Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			addressLookupTableAccounts: nil,
+			want:                       nil,
+			wantErr:                    true,
+		},
+		{
+			addressLookupTableAccounts: []AddressLookupTableAccount{
+				{
+					Key:       lookupTable,
+					Addresses: []common.PublicKey{common.SysVarClockPubkey},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	if got := message.DecompileInstructions(); got != nil {
+		t.Errorf("Message.DecompileInstructions() = %v, want nil", got)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := message.DecompileInstructionsWithAddressLookupTables(tt.addressLookupTableAccounts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Message.DecompileInstructionsWithAddressLookupTables() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Message.DecompileInstructionsWithAddressLookupTables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessage_WritableAccounts(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	authority := common.PublicKeyFromString("3ZT31jkAGhUaw8jsy4bTknwBMP8i4Eueh52By4zXcsVw")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	m := Message{
+		Header: MessageHeader{
+			NumRequireSignatures:        2,
+			NumReadonlySignedAccounts:   1,
+			NumReadonlyUnsignedAccounts: 1,
+		},
+		Accounts: []common.PublicKey{feePayer, authority, to, common.SystemProgramID},
+	}
+
+	wantWritable := []bool{true, false, true, false, false}
+	for i, want := range wantWritable {
+		if got := m.IsWritable(i); got != want {
+			t.Errorf("Message.IsWritable(%v) = %v, want %v", i, got, want)
+		}
+	}
+	if got, want := m.WritableAccounts(), []common.PublicKey{feePayer, to}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Message.WritableAccounts() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+var ErrSignerInvalidSignature = errors.New("signer returned an invalid signature")
+
+// Signer signs messages by a key which doesn't have to be in the process, e.g. a hsm, a kms or
+// another service. implementations are in pkg/signer.
+type Signer interface {
+	PublicKey() common.PublicKey
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// AccountSigner is a Signer which holds the private key in memory
+type AccountSigner struct {
+	account Account
+}
+
+func NewAccountSigner(account Account) AccountSigner {
+	return AccountSigner{account: account}
+}
+
+// AccountSigners wraps accounts as signers
+func AccountSigners(accounts ...Account) []Signer {
+	signers := make([]Signer, 0, len(accounts))
+	for _, account := range accounts {
+		signers = append(signers, NewAccountSigner(account))
+	}
+	return signers
+}
+
+func (s AccountSigner) PublicKey() common.PublicKey {
+	return s.account.PublicKey
+}
+
+func (s AccountSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	return s.account.Sign(message), nil
+}
+
+func verifySignature(pubkey common.PublicKey, message, sig []byte) bool {
+	return len(sig) == ed25519.SignatureSize && ed25519.Verify(pubkey.Bytes(), message, sig)
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type badSigner struct {
+	pubkey common.PublicKey
+}
+
+func (s badSigner) PublicKey() common.PublicKey {
+	return s.pubkey
+}
+
+func (s badSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	return make([]byte, 64), nil
+}
+
+func newTestMessage(feePayer, other common.PublicKey) Message {
+	return NewMessage(NewMessageParam{
+		FeePayer: feePayer,
+		Instructions: []Instruction{
+			{
+				ProgramID: common.SystemProgramID,
+				Accounts: []AccountMeta{
+					{PubKey: feePayer, IsSigner: true, IsWritable: true},
+					{PubKey: other, IsSigner: true, IsWritable: true},
+				},
+				Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+	})
+}
+
+func TestNewTransaction_ExternalSigners(t *testing.T) {
+	feePayer, other := NewAccount(), NewAccount()
+	message := newTestMessage(feePayer.PublicKey, other.PublicKey)
+
+	tx, err := NewTransaction(NewTransactionParam{
+		Message:         message,
+		Signers:         []Account{feePayer},
+		ExternalSigners: []Signer{NewAccountSigner(other)},
+	})
+	assert.Nil(t, err)
+
+	want, err := NewTransaction(NewTransactionParam{
+		Message: message,
+		Signers: []Account{feePayer, other},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, want, tx)
+}
+
+func TestTransaction_PartialSign(t *testing.T) {
+	feePayer, other := NewAccount(), NewAccount()
+	message := newTestMessage(feePayer.PublicKey, other.PublicKey)
+
+	tx, err := NewUnsignedTransaction(NewTransactionParam{Message: message})
+	assert.Nil(t, err)
+
+	err = tx.PartialSign(context.Background(), NewAccountSigner(other))
+	assert.Nil(t, err)
+	assert.Equal(t, Signature(make([]byte, 64)), tx.Signatures[0])
+	assert.NotEqual(t, Signature(make([]byte, 64)), tx.Signatures[1])
+
+	err = tx.PartialSign(context.Background(), AccountSigners(feePayer)...)
+	assert.Nil(t, err)
+
+	want, err := NewTransaction(NewTransactionParam{
+		Message: message,
+		Signers: []Account{feePayer, other},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, want, tx)
+
+	err = tx.PartialSign(context.Background(), NewAccountSigner(NewAccount()))
+	assert.True(t, errors.Is(err, ErrTransactionAddNotNecessarySignatures))
+
+	err = tx.PartialSign(context.Background(), badSigner{pubkey: feePayer.PublicKey})
+	assert.True(t, errors.Is(err, ErrSignerInvalidSignature))
+}
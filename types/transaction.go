@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"crypto/ed25519"
 	"encoding/binary"
 	"errors"
@@ -14,6 +15,9 @@ var (
 	ErrTransactionAddNotNecessarySignatures = errors.New("add not necessary signatures")
 )
 
+// MaxTransactionSize is the max size of a serialized tx, which is the IPv6 MTU minus the headers
+const MaxTransactionSize = 1232
+
 type Signature []byte
 
 type Transaction struct {
@@ -24,6 +28,8 @@ type Transaction struct {
 type NewTransactionParam struct {
 	Message Message
 	Signers []Account
+	// ExternalSigners are signers which don't expose the private key, they sign after Signers
+	ExternalSigners []Signer
 }
 
 // NewUnsignedTransaction create a new tx by message and signer without signatures. it will reserve signatures slot.
@@ -46,6 +52,11 @@ func NewUnsignedTransaction(param NewTransactionParam) (Transaction, error) {
 
 // NewTransaction create a new tx by message and signer. it will reserve signatures slot.
 func NewTransaction(param NewTransactionParam) (Transaction, error) {
+	return NewTransactionWithContext(context.Background(), param)
+}
+
+// NewTransactionWithContext is NewTransaction but the context is passed to ExternalSigners
+func NewTransactionWithContext(ctx context.Context, param NewTransactionParam) (Transaction, error) {
 	signatures := make([]Signature, 0, param.Message.Header.NumRequireSignatures)
 	for i := uint8(0); i < param.Message.Header.NumRequireSignatures; i++ {
 		signatures = append(signatures, make([]byte, 64))
@@ -68,10 +79,66 @@ func NewTransaction(param NewTransactionParam) (Transaction, error) {
 		signatures[idx] = signer.Sign(data)
 	}
 
-	return Transaction{
+	tx := Transaction{
 		Signatures: signatures,
 		Message:    param.Message,
-	}, nil
+	}
+	if err := tx.PartialSign(ctx, param.ExternalSigners...); err != nil {
+		return Transaction{}, err
+	}
+	return tx, nil
+}
+
+// PartialSign signs the tx by the signers and puts signatures into their slots. other signatures are kept
+// so the tx can be passed to other parties to sign.
+func (tx *Transaction) PartialSign(ctx context.Context, signers ...Signer) error {
+	if len(signers) == 0 {
+		return nil
+	}
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	tx.reserveSignatures()
+
+	for _, signer := range signers {
+		pubkey := signer.PublicKey()
+		idx := -1
+		for i := uint8(0); i < tx.Message.Header.NumRequireSignatures; i++ {
+			if tx.Message.Accounts[i] == pubkey {
+				idx = int(i)
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("%w, %v is not a signer", ErrTransactionAddNotNecessarySignatures, pubkey)
+		}
+		sig, err := signer.Sign(ctx, data)
+		if err != nil {
+			return fmt.Errorf("failed to sign by %v, err: %v", pubkey, err)
+		}
+		if !verifySignature(pubkey, data, sig) {
+			return fmt.Errorf("%w, signer: %v", ErrSignerInvalidSignature, pubkey)
+		}
+		tx.Signatures[idx] = sig
+	}
+	return nil
+}
+
+// reserveSignatures makes a slot for every required signature and keeps existing signatures
+func (tx *Transaction) reserveSignatures() {
+	n := int(tx.Message.Header.NumRequireSignatures)
+	if len(tx.Signatures) == n {
+		return
+	}
+	signatures := make([]Signature, n)
+	copy(signatures, tx.Signatures)
+	for i := range signatures {
+		if signatures[i] == nil {
+			signatures[i] = make([]byte, 64)
+		}
+	}
+	tx.Signatures = signatures
 }
 
 // AddSignature will add or replace signature into the correct order signature's slot.
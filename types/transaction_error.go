@@ -0,0 +1,293 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransactionError is an error of a processed transaction, it wraps one of
+// TransactionErrorKind, *InstructionError, *InsufficientFundsForRentError,
+// *DuplicateInstructionError and *ProgramExecutionTemporarilyRestrictedError
+type TransactionError struct {
+	Err error
+	// Raw is the error in rpc json
+	Raw interface{}
+}
+
+func (e *TransactionError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("unknown transaction error: %v", e.Raw)
+	}
+	return e.Err.Error()
+}
+
+func (e *TransactionError) Unwrap() error {
+	return e.Err
+}
+
+// TransactionErrorKind is a transaction error without any data
+type TransactionErrorKind string
+
+const (
+	TransactionErrorAccountInUse                       TransactionErrorKind = "AccountInUse"
+	TransactionErrorAccountLoadedTwice                 TransactionErrorKind = "AccountLoadedTwice"
+	TransactionErrorAccountNotFound                    TransactionErrorKind = "AccountNotFound"
+	TransactionErrorProgramAccountNotFound             TransactionErrorKind = "ProgramAccountNotFound"
+	TransactionErrorInsufficientFundsForFee            TransactionErrorKind = "InsufficientFundsForFee"
+	TransactionErrorInvalidAccountForFee               TransactionErrorKind = "InvalidAccountForFee"
+	TransactionErrorAlreadyProcessed                   TransactionErrorKind = "AlreadyProcessed"
+	TransactionErrorBlockhashNotFound                  TransactionErrorKind = "BlockhashNotFound"
+	TransactionErrorCallChainTooDeep                   TransactionErrorKind = "CallChainTooDeep"
+	TransactionErrorMissingSignatureForFee             TransactionErrorKind = "MissingSignatureForFee"
+	TransactionErrorInvalidAccountIndex                TransactionErrorKind = "InvalidAccountIndex"
+	TransactionErrorSignatureFailure                   TransactionErrorKind = "SignatureFailure"
+	TransactionErrorInvalidProgramForExecution         TransactionErrorKind = "InvalidProgramForExecution"
+	TransactionErrorSanitizeFailure                    TransactionErrorKind = "SanitizeFailure"
+	TransactionErrorClusterMaintenance                 TransactionErrorKind = "ClusterMaintenance"
+	TransactionErrorAccountBorrowOutstanding           TransactionErrorKind = "AccountBorrowOutstanding"
+	TransactionErrorWouldExceedMaxBlockCostLimit       TransactionErrorKind = "WouldExceedMaxBlockCostLimit"
+	TransactionErrorUnsupportedVersion                 TransactionErrorKind = "UnsupportedVersion"
+	TransactionErrorInvalidWritableAccount             TransactionErrorKind = "InvalidWritableAccount"
+	TransactionErrorWouldExceedMaxAccountCostLimit     TransactionErrorKind = "WouldExceedMaxAccountCostLimit"
+	TransactionErrorWouldExceedAccountDataBlockLimit   TransactionErrorKind = "WouldExceedAccountDataBlockLimit"
+	TransactionErrorTooManyAccountLocks                TransactionErrorKind = "TooManyAccountLocks"
+	TransactionErrorAddressLookupTableNotFound         TransactionErrorKind = "AddressLookupTableNotFound"
+	TransactionErrorInvalidAddressLookupTableOwner     TransactionErrorKind = "InvalidAddressLookupTableOwner"
+	TransactionErrorInvalidAddressLookupTableData      TransactionErrorKind = "InvalidAddressLookupTableData"
+	TransactionErrorInvalidAddressLookupTableIndex     TransactionErrorKind = "InvalidAddressLookupTableIndex"
+	TransactionErrorInvalidRentPayingAccount           TransactionErrorKind = "InvalidRentPayingAccount"
+	TransactionErrorWouldExceedMaxVoteCostLimit        TransactionErrorKind = "WouldExceedMaxVoteCostLimit"
+	TransactionErrorWouldExceedAccountDataTotalLimit   TransactionErrorKind = "WouldExceedAccountDataTotalLimit"
+	TransactionErrorMaxLoadedAccountsDataSizeExceeded  TransactionErrorKind = "MaxLoadedAccountsDataSizeExceeded"
+	TransactionErrorInvalidLoadedAccountsDataSizeLimit TransactionErrorKind = "InvalidLoadedAccountsDataSizeLimit"
+	TransactionErrorResanitizationNeeded               TransactionErrorKind = "ResanitizationNeeded"
+	TransactionErrorUnbalancedTransaction              TransactionErrorKind = "UnbalancedTransaction"
+)
+
+func (e TransactionErrorKind) Error() string {
+	return string(e)
+}
+
+// InstructionError means the instruction at Index failed, Err is one of InstructionErrorKind, CustomError and BorshIoError
+type InstructionError struct {
+	Index uint8
+	Err   error
+}
+
+func (e *InstructionError) Error() string {
+	return fmt.Sprintf("Error processing Instruction %v: %v", e.Index, e.Err)
+}
+
+func (e *InstructionError) Unwrap() error {
+	return e.Err
+}
+
+// InsufficientFundsForRentError means the account at AccountIndex would be left with insufficient funds for rent
+type InsufficientFundsForRentError struct {
+	AccountIndex uint8
+}
+
+func (e *InsufficientFundsForRentError) Error() string {
+	return fmt.Sprintf("Transaction results in an account (%v) with insufficient funds for rent", e.AccountIndex)
+}
+
+// DuplicateInstructionError means the instruction at Index is a duplicate
+type DuplicateInstructionError struct {
+	Index uint8
+}
+
+func (e *DuplicateInstructionError) Error() string {
+	return fmt.Sprintf("Transaction contains a duplicate instruction (%v) that is not allowed", e.Index)
+}
+
+// ProgramExecutionTemporarilyRestrictedError means the program of the account at AccountIndex is restricted
+type ProgramExecutionTemporarilyRestrictedError struct {
+	AccountIndex uint8
+}
+
+func (e *ProgramExecutionTemporarilyRestrictedError) Error() string {
+	return fmt.Sprintf("Execution of the program referenced by account at index %v is temporarily restricted", e.AccountIndex)
+}
+
+// InstructionErrorKind is an instruction error without any data
+type InstructionErrorKind string
+
+const (
+	InstructionErrorGenericError                           InstructionErrorKind = "GenericError"
+	InstructionErrorInvalidArgument                        InstructionErrorKind = "InvalidArgument"
+	InstructionErrorInvalidInstructionData                 InstructionErrorKind = "InvalidInstructionData"
+	InstructionErrorInvalidAccountData                     InstructionErrorKind = "InvalidAccountData"
+	InstructionErrorAccountDataTooSmall                    InstructionErrorKind = "AccountDataTooSmall"
+	InstructionErrorInsufficientFunds                      InstructionErrorKind = "InsufficientFunds"
+	InstructionErrorIncorrectProgramId                     InstructionErrorKind = "IncorrectProgramId"
+	InstructionErrorMissingRequiredSignature               InstructionErrorKind = "MissingRequiredSignature"
+	InstructionErrorAccountAlreadyInitialized              InstructionErrorKind = "AccountAlreadyInitialized"
+	InstructionErrorUninitializedAccount                   InstructionErrorKind = "UninitializedAccount"
+	InstructionErrorUnbalancedInstruction                  InstructionErrorKind = "UnbalancedInstruction"
+	InstructionErrorModifiedProgramId                      InstructionErrorKind = "ModifiedProgramId"
+	InstructionErrorExternalAccountLamportSpend            InstructionErrorKind = "ExternalAccountLamportSpend"
+	InstructionErrorExternalAccountDataModified            InstructionErrorKind = "ExternalAccountDataModified"
+	InstructionErrorReadonlyLamportChange                  InstructionErrorKind = "ReadonlyLamportChange"
+	InstructionErrorReadonlyDataModified                   InstructionErrorKind = "ReadonlyDataModified"
+	InstructionErrorDuplicateAccountIndex                  InstructionErrorKind = "DuplicateAccountIndex"
+	InstructionErrorExecutableModified                     InstructionErrorKind = "ExecutableModified"
+	InstructionErrorRentEpochModified                      InstructionErrorKind = "RentEpochModified"
+	InstructionErrorNotEnoughAccountKeys                   InstructionErrorKind = "NotEnoughAccountKeys"
+	InstructionErrorAccountDataSizeChanged                 InstructionErrorKind = "AccountDataSizeChanged"
+	InstructionErrorAccountNotExecutable                   InstructionErrorKind = "AccountNotExecutable"
+	InstructionErrorAccountBorrowFailed                    InstructionErrorKind = "AccountBorrowFailed"
+	InstructionErrorAccountBorrowOutstanding               InstructionErrorKind = "AccountBorrowOutstanding"
+	InstructionErrorDuplicateAccountOutOfSync              InstructionErrorKind = "DuplicateAccountOutOfSync"
+	InstructionErrorInvalidError                           InstructionErrorKind = "InvalidError"
+	InstructionErrorExecutableDataModified                 InstructionErrorKind = "ExecutableDataModified"
+	InstructionErrorExecutableLamportChange                InstructionErrorKind = "ExecutableLamportChange"
+	InstructionErrorExecutableAccountNotRentExempt         InstructionErrorKind = "ExecutableAccountNotRentExempt"
+	InstructionErrorUnsupportedProgramId                   InstructionErrorKind = "UnsupportedProgramId"
+	InstructionErrorCallDepth                              InstructionErrorKind = "CallDepth"
+	InstructionErrorMissingAccount                         InstructionErrorKind = "MissingAccount"
+	InstructionErrorReentrancyNotAllowed                   InstructionErrorKind = "ReentrancyNotAllowed"
+	InstructionErrorMaxSeedLengthExceeded                  InstructionErrorKind = "MaxSeedLengthExceeded"
+	InstructionErrorInvalidSeeds                           InstructionErrorKind = "InvalidSeeds"
+	InstructionErrorInvalidRealloc                         InstructionErrorKind = "InvalidRealloc"
+	InstructionErrorComputationalBudgetExceeded            InstructionErrorKind = "ComputationalBudgetExceeded"
+	InstructionErrorPrivilegeEscalation                    InstructionErrorKind = "PrivilegeEscalation"
+	InstructionErrorProgramEnvironmentSetupFailure         InstructionErrorKind = "ProgramEnvironmentSetupFailure"
+	InstructionErrorProgramFailedToComplete                InstructionErrorKind = "ProgramFailedToComplete"
+	InstructionErrorProgramFailedToCompile                 InstructionErrorKind = "ProgramFailedToCompile"
+	InstructionErrorImmutable                              InstructionErrorKind = "Immutable"
+	InstructionErrorIncorrectAuthority                     InstructionErrorKind = "IncorrectAuthority"
+	InstructionErrorAccountNotRentExempt                   InstructionErrorKind = "AccountNotRentExempt"
+	InstructionErrorInvalidAccountOwner                    InstructionErrorKind = "InvalidAccountOwner"
+	InstructionErrorArithmeticOverflow                     InstructionErrorKind = "ArithmeticOverflow"
+	InstructionErrorUnsupportedSysvar                      InstructionErrorKind = "UnsupportedSysvar"
+	InstructionErrorIllegalOwner                           InstructionErrorKind = "IllegalOwner"
+	InstructionErrorMaxAccountsDataAllocationsExceeded     InstructionErrorKind = "MaxAccountsDataAllocationsExceeded"
+	InstructionErrorMaxAccountsExceeded                    InstructionErrorKind = "MaxAccountsExceeded"
+	InstructionErrorMaxInstructionTraceLengthExceeded      InstructionErrorKind = "MaxInstructionTraceLengthExceeded"
+	InstructionErrorBuiltinProgramsMustConsumeComputeUnits InstructionErrorKind = "BuiltinProgramsMustConsumeComputeUnits"
+)
+
+func (e InstructionErrorKind) Error() string {
+	return string(e)
+}
+
+// CustomError is an error code defined by the program, e.g. tokenprog.Error
+type CustomError uint32
+
+func (e CustomError) Error() string {
+	return fmt.Sprintf("custom program error: %#x", uint32(e))
+}
+
+// BorshIoError is a borsh (de)serialization error of the program
+type BorshIoError string
+
+func (e BorshIoError) Error() string {
+	return fmt.Sprintf("Failed to serialize or deserialize account data: %v", string(e))
+}
+
+// ParseTransactionError parses a transaction error in rpc json, e.g. {"InstructionError":[0,{"Custom":1}]}.
+// it returns nil if raw is nil. an unknown error is parsed as a TransactionErrorKind by its name.
+func ParseTransactionError(raw interface{}) *TransactionError {
+	if raw == nil {
+		return nil
+	}
+	return &TransactionError{
+		Err: parseTransactionError(raw),
+		Raw: raw,
+	}
+}
+
+func parseTransactionError(raw interface{}) error {
+	switch v := raw.(type) {
+	case string:
+		return TransactionErrorKind(v)
+	case map[string]interface{}:
+		for name, data := range v {
+			switch name {
+			case "InstructionError":
+				if e, ok := parseInstructionError(data); ok {
+					return e
+				}
+			case "DuplicateInstruction":
+				if index, ok := parseUint8(data); ok {
+					return &DuplicateInstructionError{Index: index}
+				}
+			case "InsufficientFundsForRent":
+				if index, ok := parseAccountIndex(data); ok {
+					return &InsufficientFundsForRentError{AccountIndex: index}
+				}
+			case "ProgramExecutionTemporarilyRestricted":
+				if index, ok := parseAccountIndex(data); ok {
+					return &ProgramExecutionTemporarilyRestrictedError{AccountIndex: index}
+				}
+			}
+			return TransactionErrorKind(name)
+		}
+	}
+	j, _ := json.Marshal(raw)
+	return TransactionErrorKind(j)
+}
+
+func parseInstructionError(data interface{}) (*InstructionError, bool) {
+	v, ok := data.([]interface{})
+	if !ok || len(v) != 2 {
+		return nil, false
+	}
+	index, ok := parseUint8(v[0])
+	if !ok {
+		return nil, false
+	}
+
+	switch inner := v[1].(type) {
+	case string:
+		return &InstructionError{Index: index, Err: InstructionErrorKind(inner)}, true
+	case map[string]interface{}:
+		for name, data := range inner {
+			switch name {
+			case "Custom":
+				if code, ok := parseUint64(data); ok && code <= 0xffffffff {
+					return &InstructionError{Index: index, Err: CustomError(code)}, true
+				}
+			case "BorshIoError":
+				if message, ok := data.(string); ok {
+					return &InstructionError{Index: index, Err: BorshIoError(message)}, true
+				}
+			}
+			return &InstructionError{Index: index, Err: InstructionErrorKind(name)}, true
+		}
+	}
+	return nil, false
+}
+
+func parseAccountIndex(data interface{}) (uint8, bool) {
+	v, ok := data.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	return parseUint8(v["account_index"])
+}
+
+func parseUint8(v interface{}) (uint8, bool) {
+	n, ok := parseUint64(v)
+	if !ok || n > 0xff {
+		return 0, false
+	}
+	return uint8(n), true
+}
+
+func parseUint64(v interface{}) (uint64, bool) {
+	switch n := v.(type) {
+	case float64:
+		if n < 0 || n != float64(uint64(n)) {
+			return 0, false
+		}
+		return uint64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		if err != nil || i < 0 {
+			return 0, false
+		}
+		return uint64(i), true
+	}
+	return 0, false
+}
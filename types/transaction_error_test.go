@@ -0,0 +1,98 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTransactionError(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawJSON string
+		want    error
+		message string
+	}{
+		{
+			rawJSON: `"BlockhashNotFound"`,
+			want:    TransactionErrorBlockhashNotFound,
+			message: "BlockhashNotFound",
+		},
+		{
+			rawJSON: `{"InstructionError":[0,{"Custom":1}]}`,
+			want:    &InstructionError{Index: 0, Err: CustomError(1)},
+			message: "Error processing Instruction 0: custom program error: 0x1",
+		},
+		{
+			rawJSON: `{"InstructionError":[2,"InvalidAccountData"]}`,
+			want:    &InstructionError{Index: 2, Err: InstructionErrorInvalidAccountData},
+			message: "Error processing Instruction 2: InvalidAccountData",
+		},
+		{
+			rawJSON: `{"InstructionError":[1,{"BorshIoError":"Unknown"}]}`,
+			want:    &InstructionError{Index: 1, Err: BorshIoError("Unknown")},
+			message: "Error processing Instruction 1: Failed to serialize or deserialize account data: Unknown",
+		},
+		{
+			rawJSON: `{"InsufficientFundsForRent":{"account_index":3}}`,
+			want:    &InsufficientFundsForRentError{AccountIndex: 3},
+			message: "Transaction results in an account (3) with insufficient funds for rent",
+		},
+		{
+			rawJSON: `{"DuplicateInstruction":4}`,
+			want:    &DuplicateInstructionError{Index: 4},
+			message: "Transaction contains a duplicate instruction (4) that is not allowed",
+		},
+		{
+			rawJSON: `{"ProgramExecutionTemporarilyRestricted":{"account_index":5}}`,
+			want:    &ProgramExecutionTemporarilyRestrictedError{AccountIndex: 5},
+			message: "Execution of the program referenced by account at index 5 is temporarily restricted",
+		},
+		{
+			name:    "unknown error",
+			rawJSON: `{"NewError":{"foo":1}}`,
+			want:    TransactionErrorKind("NewError"),
+			message: "NewError",
+		},
+		{
+			name:    "unknown instruction error",
+			rawJSON: `{"InstructionError":[0,{"NewError":1}]}`,
+			want:    &InstructionError{Index: 0, Err: InstructionErrorKind("NewError")},
+			message: "Error processing Instruction 0: NewError",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw interface{}
+			assert.Nil(t, json.Unmarshal([]byte(tt.rawJSON), &raw))
+			got := ParseTransactionError(raw)
+			assert.Equal(t, &TransactionError{Err: tt.want, Raw: raw}, got)
+			assert.Equal(t, tt.message, got.Error())
+		})
+	}
+
+	assert.Nil(t, ParseTransactionError(nil))
+}
+
+func TestTransactionError_As(t *testing.T) {
+	var raw interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"InstructionError":[1,{"Custom":6001}]}`), &raw))
+	var err error = ParseTransactionError(raw)
+
+	var instructionErr *InstructionError
+	assert.True(t, errors.As(err, &instructionErr))
+	assert.Equal(t, uint8(1), instructionErr.Index)
+
+	var customErr CustomError
+	assert.True(t, errors.As(err, &customErr))
+	assert.Equal(t, CustomError(6001), customErr)
+
+	assert.True(t, errors.Is(ParseTransactionError("AccountInUse"), TransactionErrorAccountInUse))
+}
+
+func TestTransactionError_NilErr(t *testing.T) {
+	assert.Equal(t, "unknown transaction error: <nil>", (&TransactionError{}).Error())
+	assert.Equal(t, "unknown transaction error: map[Foo:1]", (&TransactionError{Raw: map[string]interface{}{"Foo": 1}}).Error())
+}
@@ -0,0 +1,158 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+var (
+	ErrTransactionInvalidSignature  = errors.New("invalid signature")
+	ErrTransactionMessageMismatch   = errors.New("message mismatch")
+	ErrTransactionSignatureMismatch = errors.New("signature count mismatch")
+)
+
+var emptySignature = make([]byte, 64)
+
+// ToBase64 serializes the tx even if it is partially signed, missing signatures are zero.
+// the result can be passed to other parties and parsed by TransactionFromBase64.
+func (tx Transaction) ToBase64() (string, error) {
+	b, err := tx.serializePartial()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// ToBase58 is ToBase64 but in base58
+func (tx Transaction) ToBase58() (string, error) {
+	b, err := tx.serializePartial()
+	if err != nil {
+		return "", err
+	}
+	return base58.Encode(b), nil
+}
+
+// TransactionFromBase64 parses a tx serialized by ToBase64
+func TransactionFromBase64(s string) (Transaction, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("failed to base64 decode, err: %v", err)
+	}
+	return TransactionDeserialize(b)
+}
+
+// TransactionFromBase58 parses a tx serialized by ToBase58
+func TransactionFromBase58(s string) (Transaction, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("failed to base58 decode, err: %v", err)
+	}
+	return TransactionDeserialize(b)
+}
+
+func (tx Transaction) serializePartial() ([]byte, error) {
+	n := int(tx.Message.Header.NumRequireSignatures)
+	if len(tx.Signatures) > n {
+		return nil, fmt.Errorf("%w, expected: %v, got: %v", ErrTransactionSignatureMismatch, n, len(tx.Signatures))
+	}
+	signatures := make([]Signature, n)
+	copy(signatures, tx.Signatures)
+	for i := range signatures {
+		if len(signatures[i]) == 0 {
+			signatures[i] = emptySignature
+		}
+	}
+	tx.Signatures = signatures
+	return tx.Serialize()
+}
+
+// MissingSigners returns signers whose signature slot is empty
+func (tx Transaction) MissingSigners() []common.PublicKey {
+	missing := []common.PublicKey{}
+	for i := 0; i < int(tx.Message.Header.NumRequireSignatures); i++ {
+		if i >= len(tx.Signatures) || isEmptySignature(tx.Signatures[i]) {
+			missing = append(missing, tx.Message.Accounts[i])
+		}
+	}
+	return missing
+}
+
+// IsFullySigned reports whether all signature slots are filled, it doesn't verify them
+func (tx Transaction) IsFullySigned() bool {
+	return len(tx.MissingSigners()) == 0
+}
+
+// VerifySignatures verifies every present signature against the message. missing signatures are skipped,
+// use MissingSigners to check them. a tampered message fails the verification.
+func (tx Transaction) VerifySignatures() error {
+	if len(tx.Signatures) > int(tx.Message.Header.NumRequireSignatures) {
+		return fmt.Errorf("%w, expected: %v, got: %v", ErrTransactionSignatureMismatch, tx.Message.Header.NumRequireSignatures, len(tx.Signatures))
+	}
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	for i, sig := range tx.Signatures {
+		if isEmptySignature(sig) {
+			continue
+		}
+		if !verifySignature(tx.Message.Accounts[i], data, sig) {
+			return fmt.Errorf("%w, signer: %v", ErrTransactionInvalidSignature, tx.Message.Accounts[i])
+		}
+	}
+	return nil
+}
+
+// MergeSignatures copies signatures from partially signed copies of the tx into it. every copy must have
+// the same message and every signature must be valid, otherwise an error is returned and the tx is left unchanged.
+func (tx *Transaction) MergeSignatures(others ...Transaction) error {
+	data, err := tx.Message.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize message, err: %v", err)
+	}
+	n := int(tx.Message.Header.NumRequireSignatures)
+	if len(tx.Signatures) > n {
+		return fmt.Errorf("%w, expected: %v, got: %v", ErrTransactionSignatureMismatch, n, len(tx.Signatures))
+	}
+	signatures := make([]Signature, n)
+	for i := range signatures {
+		if i < len(tx.Signatures) && tx.Signatures[i] != nil {
+			signatures[i] = tx.Signatures[i]
+		} else {
+			signatures[i] = make([]byte, 64)
+		}
+	}
+
+	for _, other := range others {
+		otherData, err := other.Message.Serialize()
+		if err != nil {
+			return fmt.Errorf("failed to serialize message, err: %v", err)
+		}
+		if !bytes.Equal(data, otherData) {
+			return ErrTransactionMessageMismatch
+		}
+		if len(other.Signatures) > n {
+			return fmt.Errorf("%w, expected: %v, got: %v", ErrTransactionSignatureMismatch, n, len(other.Signatures))
+		}
+		for i, sig := range other.Signatures {
+			if isEmptySignature(sig) {
+				continue
+			}
+			if !verifySignature(tx.Message.Accounts[i], data, sig) {
+				return fmt.Errorf("%w, signer: %v", ErrTransactionInvalidSignature, tx.Message.Accounts[i])
+			}
+			signatures[i] = sig
+		}
+	}
+	tx.Signatures = signatures
+	return nil
+}
+
+func isEmptySignature(sig []byte) bool {
+	return len(sig) == 0 || bytes.Equal(sig, emptySignature)
+}
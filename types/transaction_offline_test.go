@@ -0,0 +1,87 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_OfflineSigning(t *testing.T) {
+	feePayer, other := NewAccount(), NewAccount()
+	message := newTestMessage(feePayer.PublicKey, other.PublicKey)
+
+	unsigned, err := NewUnsignedTransaction(NewTransactionParam{Message: message})
+	assert.Nil(t, err)
+	assert.False(t, unsigned.IsFullySigned())
+	assert.Equal(t, []common.PublicKey{feePayer.PublicKey, other.PublicKey}, unsigned.MissingSigners())
+
+	exported, err := unsigned.ToBase64()
+	assert.Nil(t, err)
+
+	// every party signs its own copy
+	sign := func(account Account) string {
+		tx, err := TransactionFromBase64(exported)
+		assert.Nil(t, err)
+		assert.Nil(t, tx.PartialSign(context.Background(), NewAccountSigner(account)))
+		s, err := tx.ToBase58()
+		assert.Nil(t, err)
+		return s
+	}
+	copies := []Transaction{}
+	for _, s := range []string{sign(feePayer), sign(other)} {
+		tx, err := TransactionFromBase58(s)
+		assert.Nil(t, err)
+		assert.Nil(t, tx.VerifySignatures())
+		assert.Len(t, tx.MissingSigners(), 1)
+		copies = append(copies, tx)
+	}
+
+	tx := Transaction{Message: message}
+	assert.Nil(t, tx.MergeSignatures(copies...))
+	assert.True(t, tx.IsFullySigned())
+	assert.Nil(t, tx.VerifySignatures())
+
+	want, err := NewTransaction(NewTransactionParam{
+		Message: message,
+		Signers: []Account{feePayer, other},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, want, tx)
+}
+
+func TestTransaction_Tampering(t *testing.T) {
+	feePayer, other := NewAccount(), NewAccount()
+	message := newTestMessage(feePayer.PublicKey, other.PublicKey)
+
+	tx, err := NewTransaction(NewTransactionParam{
+		Message: message,
+		Signers: []Account{feePayer},
+	})
+	assert.Nil(t, err)
+
+	tampered := tx
+	tampered.Message.RecentBlockHash = "9rAtxuhtKn8qagc3UtZFyhLrw5zgh6etNKLYPVwLhqfm"
+	err = tampered.VerifySignatures()
+	assert.True(t, errors.Is(err, ErrTransactionInvalidSignature))
+
+	merged := Transaction{Message: message}
+	err = merged.MergeSignatures(tampered)
+	assert.True(t, errors.Is(err, ErrTransactionMessageMismatch))
+
+	forged := Transaction{
+		Message:    message,
+		Signatures: []Signature{make([]byte, 64), feePayer.Sign([]byte("hello"))},
+	}
+	err = merged.MergeSignatures(forged)
+	assert.True(t, errors.Is(err, ErrTransactionInvalidSignature))
+	assert.Equal(t, []common.PublicKey{feePayer.PublicKey, other.PublicKey}, merged.MissingSigners())
+
+	// a valid copy before an invalid one isn't merged either
+	err = merged.MergeSignatures(tx, forged)
+	assert.True(t, errors.Is(err, ErrTransactionInvalidSignature))
+	assert.Nil(t, merged.Signatures)
+	assert.Equal(t, []common.PublicKey{feePayer.PublicKey, other.PublicKey}, merged.MissingSigners())
+}
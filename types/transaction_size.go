@@ -0,0 +1,130 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/pkg/bincode"
+)
+
+// MaxTransactionAccountLocks is the max number of accounts a tx can lock, including programs and
+// accounts loaded from address lookup tables
+const MaxTransactionAccountLocks = 64
+
+var ErrInstructionGroupTooLarge = errors.New("instruction group doesn't fit in a transaction")
+
+// SerializedSize returns the size of the serialized message. the recent blockhash is always counted as 32 bytes
+// so the size can be computed before the blockhash is fetched.
+func (m *Message) SerializedSize() int {
+	size := 3
+	if m.Version == MessageVersionV0 {
+		size++
+	}
+	size += varLenSize(len(m.Accounts)) + len(m.Accounts)*32
+	size += 32
+	size += varLenSize(len(m.Instructions))
+	for _, instruction := range m.Instructions {
+		size += 1
+		size += varLenSize(len(instruction.Accounts)) + len(instruction.Accounts)
+		size += varLenSize(len(instruction.Data)) + len(instruction.Data)
+	}
+	if m.Version == MessageVersionV0 {
+		size += varLenSize(len(m.AddressLookupTables))
+		for _, addressLookupTable := range m.AddressLookupTables {
+			size += 32
+			size += varLenSize(len(addressLookupTable.WritableIndexes)) + len(addressLookupTable.WritableIndexes)
+			size += varLenSize(len(addressLookupTable.ReadonlyIndexes)) + len(addressLookupTable.ReadonlyIndexes)
+		}
+	}
+	return size
+}
+
+// NumAccountLocks returns the number of accounts the message locks, including accounts loaded from address lookup tables
+func (m *Message) NumAccountLocks() int {
+	n := len(m.Accounts)
+	for _, addressLookupTable := range m.AddressLookupTables {
+		n += len(addressLookupTable.WritableIndexes) + len(addressLookupTable.ReadonlyIndexes)
+	}
+	return n
+}
+
+// SerializedSize returns the size of the serialized tx. every required signature is counted whether it is signed or not.
+func (tx *Transaction) SerializedSize() int {
+	n := int(tx.Message.Header.NumRequireSignatures)
+	return varLenSize(n) + n*64 + tx.Message.SerializedSize()
+}
+
+func varLenSize(n int) int {
+	return len(bincode.UintToVarLenBytes(uint64(n)))
+}
+
+type SplitInstructionsParam struct {
+	FeePayer        common.PublicKey
+	RecentBlockhash string
+	// InstructionGroups are packed in order. instructions in a group always go to the same tx,
+	// e.g. creating an associated token account and transferring to it.
+	InstructionGroups [][]Instruction
+	// Prefix is put at the start of every message, e.g. compute budget instructions
+	Prefix []Instruction
+	// AddressLookupTableAccounts makes messages v0, see NewMessageParam
+	AddressLookupTableAccounts []AddressLookupTableAccount
+	// MaxSize is the max size of a tx, the default is MaxTransactionSize
+	MaxSize int
+	// MaxAccountLocks is the max number of accounts of a tx, the default is MaxTransactionAccountLocks
+	MaxAccountLocks int
+}
+
+// SplitInstructions packs instruction groups into the fewest messages which keep the order of groups and fit in
+// the size and account lock limits. every message is paid by the fee payer. send them in sequence if a group
+// depends on an earlier one, otherwise they can be sent in parallel.
+func SplitInstructions(param SplitInstructionsParam) ([]Message, error) {
+	maxSize := param.MaxSize
+	if maxSize == 0 {
+		maxSize = MaxTransactionSize
+	}
+	maxAccountLocks := param.MaxAccountLocks
+	if maxAccountLocks == 0 {
+		maxAccountLocks = MaxTransactionAccountLocks
+	}
+
+	newMessage := func(instructions []Instruction) Message {
+		return NewMessage(NewMessageParam{
+			FeePayer:                   param.FeePayer,
+			Instructions:               append(append([]Instruction{}, param.Prefix...), instructions...),
+			RecentBlockhash:            param.RecentBlockhash,
+			AddressLookupTableAccounts: param.AddressLookupTableAccounts,
+		})
+	}
+	fits := func(m Message) bool {
+		tx := Transaction{Message: m}
+		return tx.SerializedSize() <= maxSize && m.NumAccountLocks() <= maxAccountLocks
+	}
+
+	messages := []Message{}
+	var current []Instruction
+	var currentMessage Message
+	for i, group := range param.InstructionGroups {
+		if len(group) == 0 {
+			continue
+		}
+		candidate := append(append([]Instruction{}, current...), group...)
+		if m := newMessage(candidate); fits(m) {
+			current, currentMessage = candidate, m
+			continue
+		}
+		m := newMessage(group)
+		if !fits(m) {
+			tx := Transaction{Message: m}
+			return nil, fmt.Errorf("%w, index: %v, size: %v, account locks: %v", ErrInstructionGroupTooLarge, i, tx.SerializedSize(), m.NumAccountLocks())
+		}
+		if len(current) > 0 {
+			messages = append(messages, currentMessage)
+		}
+		current, currentMessage = group, m
+	}
+	if len(current) > 0 {
+		messages = append(messages, currentMessage)
+	}
+	return messages, nil
+}
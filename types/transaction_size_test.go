@@ -0,0 +1,159 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_SerializedSize(t *testing.T) {
+	feePayer, other := NewAccount(), NewAccount()
+	lookupTable := AddressLookupTableAccount{
+		Key:       common.PublicKeyFromString("HEhDGuxaxGr9LuNtBdvbX2uggyAKoxYgHFaAiqxVu8UY"),
+		Addresses: []common.PublicKey{common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")},
+	}
+	instructions := []Instruction{
+		{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer.PublicKey, IsSigner: true, IsWritable: true},
+				{PubKey: other.PublicKey, IsSigner: true, IsWritable: false},
+				{PubKey: lookupTable.Addresses[0], IsSigner: false, IsWritable: true},
+			},
+			Data: make([]byte, 200),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		message Message
+	}{
+		{
+			name: "legacy",
+			message: NewMessage(NewMessageParam{
+				FeePayer:        feePayer.PublicKey,
+				Instructions:    instructions,
+				RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+			}),
+		},
+		{
+			name: "v0",
+			message: NewMessage(NewMessageParam{
+				FeePayer:                   feePayer.PublicKey,
+				Instructions:               instructions,
+				RecentBlockhash:            "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				AddressLookupTableAccounts: []AddressLookupTableAccount{lookupTable},
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawMessage, err := tt.message.Serialize()
+			assert.Nil(t, err)
+			assert.Equal(t, len(rawMessage), tt.message.SerializedSize())
+
+			unsigned := Transaction{Message: tt.message}
+			tx, err := NewTransaction(NewTransactionParam{
+				Message: tt.message,
+				Signers: []Account{feePayer, other},
+			})
+			assert.Nil(t, err)
+			rawTx, err := tx.Serialize()
+			assert.Nil(t, err)
+			assert.Equal(t, len(rawTx), unsigned.SerializedSize())
+			assert.Equal(t, len(rawTx), tx.SerializedSize())
+		})
+	}
+}
+
+func TestSplitInstructions(t *testing.T) {
+	feePayer := NewAccount().PublicKey
+	transfer := func() Instruction {
+		return Instruction{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer, IsSigner: true, IsWritable: true},
+				{PubKey: NewAccount().PublicKey, IsSigner: false, IsWritable: true},
+			},
+			Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+		}
+	}
+	groups := make([][]Instruction, 0, 30)
+	for i := 0; i < 30; i++ {
+		groups = append(groups, []Instruction{transfer()})
+	}
+
+	tests := []struct {
+		name      string
+		param     SplitInstructionsParam
+		wantSizes []int
+	}{
+		{
+			name: "size limit",
+			param: SplitInstructionsParam{
+				InstructionGroups: groups,
+			},
+			wantSizes: []int{21, 9},
+		},
+		{
+			name: "account lock limit",
+			param: SplitInstructionsParam{
+				InstructionGroups: groups,
+				MaxAccountLocks:   10,
+			},
+			wantSizes: []int{8, 8, 8, 6},
+		},
+		{
+			name: "groups",
+			param: SplitInstructionsParam{
+				InstructionGroups: [][]Instruction{
+					{transfer(), transfer(), transfer()},
+					{transfer(), transfer(), transfer()},
+					{},
+					{transfer()},
+				},
+				MaxAccountLocks: 7,
+			},
+			wantSizes: []int{3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.param.FeePayer = feePayer
+			tt.param.RecentBlockhash = "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5"
+			messages, err := SplitInstructions(tt.param)
+			assert.Nil(t, err)
+
+			want := []Instruction{}
+			for _, group := range tt.param.InstructionGroups {
+				want = append(want, group...)
+			}
+			got := []Instruction{}
+			sizes := []int{}
+			for _, m := range messages {
+				assert.Equal(t, feePayer, m.Accounts[0])
+				tx := Transaction{Message: m}
+				assert.LessOrEqual(t, tx.SerializedSize(), MaxTransactionSize)
+				instructions := m.DecompileInstructions()
+				got = append(got, instructions...)
+				sizes = append(sizes, len(instructions))
+			}
+			assert.Equal(t, want, got)
+			assert.Equal(t, tt.wantSizes, sizes)
+		})
+	}
+}
+
+func TestSplitInstructions_GroupTooLarge(t *testing.T) {
+	_, err := SplitInstructions(SplitInstructionsParam{
+		FeePayer:        NewAccount().PublicKey,
+		RecentBlockhash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		InstructionGroups: [][]Instruction{
+			{{ProgramID: common.MemoProgramID, Accounts: []AccountMeta{}, Data: []byte("hello")}},
+			{{ProgramID: common.MemoProgramID, Accounts: []AccountMeta{}, Data: make([]byte, MaxTransactionSize)}},
+		},
+	})
+	assert.True(t, errors.Is(err, ErrInstructionGroupTooLarge))
+}
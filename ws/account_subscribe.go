@@ -0,0 +1,58 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// AccountSubscribeConfig is an option config for `accountSubscribe`
+type AccountSubscribeConfig struct {
+	Commitment rpc.Commitment                   `json:"commitment,omitempty"`
+	Encoding   rpc.GetAccountInfoConfigEncoding `json:"encoding,omitempty"`
+}
+
+// AccountNotification is the result of `accountNotification`
+type AccountNotification struct {
+	Context rpc.Context     `json:"context"`
+	Value   rpc.AccountInfo `json:"value"`
+}
+
+type AccountSubscription struct {
+	*subscription
+	C <-chan AccountNotification
+}
+
+// AccountSubscribe subscribes to an account to receive notifications when the lamports or data change
+func (c *Client) AccountSubscribe(ctx context.Context, base58Addr string) (*AccountSubscription, error) {
+	return c.accountSubscribe(ctx, []interface{}{base58Addr})
+}
+
+// AccountSubscribeWithConfig subscribes to an account to receive notifications when the lamports or data change
+func (c *Client) AccountSubscribeWithConfig(ctx context.Context, base58Addr string, cfg AccountSubscribeConfig) (*AccountSubscription, error) {
+	return c.accountSubscribe(ctx, []interface{}{base58Addr, cfg})
+}
+
+func (c *Client) accountSubscribe(ctx context.Context, params []interface{}) (*AccountSubscription, error) {
+	ch := make(chan AccountNotification, c.notificationBufferSize)
+	sub := newSubscription(c, "accountSubscribe", "accountUnsubscribe", params)
+	sub.notify = func(raw json.RawMessage) (bool, error) {
+		var v AccountNotification
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return false, fmt.Errorf("ws: failed to decode account notification, err: %v", err)
+		}
+		select {
+		case ch <- v:
+		case <-sub.done:
+		case <-c.closed:
+		}
+		return false, nil
+	}
+	sub.closeOut = func() { close(ch) }
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &AccountSubscription{subscription: sub, C: ch}, nil
+}
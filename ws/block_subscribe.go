@@ -0,0 +1,85 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// BlockSubscribeFilter subscribes to all blocks if MentionsAccountOrProgram is empty
+type BlockSubscribeFilter struct {
+	MentionsAccountOrProgram string
+}
+
+func (f BlockSubscribeFilter) MarshalJSON() ([]byte, error) {
+	if f.MentionsAccountOrProgram == "" {
+		return json.Marshal("all")
+	}
+	return json.Marshal(struct {
+		MentionsAccountOrProgram string `json:"mentionsAccountOrProgram"`
+	}{
+		MentionsAccountOrProgram: f.MentionsAccountOrProgram,
+	})
+}
+
+// BlockSubscribeConfig is an option config for `blockSubscribe`
+type BlockSubscribeConfig struct {
+	Commitment                     rpc.Commitment                       `json:"commitment,omitempty"`
+	Encoding                       rpc.GetBlockConfigEncoding           `json:"encoding,omitempty"`
+	TransactionDetails             rpc.GetBlockConfigTransactionDetails `json:"transactionDetails,omitempty"`
+	ShowRewards                    *bool                                `json:"showRewards,omitempty"`
+	MaxSupportedTransactionVersion *uint8                               `json:"maxSupportedTransactionVersion,omitempty"`
+}
+
+// BlockNotification is the result of `blockNotification`
+type BlockNotification struct {
+	Context rpc.Context            `json:"context"`
+	Value   BlockNotificationValue `json:"value"`
+}
+
+type BlockNotificationValue struct {
+	Slot  uint64                      `json:"slot"`
+	Err   interface{}                 `json:"err"`
+	Block *rpc.GetBlockResponseResult `json:"block"`
+}
+
+type BlockSubscription struct {
+	*subscription
+	C <-chan BlockNotification
+}
+
+// BlockSubscribe subscribes to receive notification anytime a new block is confirmed or finalized.
+// the node needs to enable `--rpc-pubsub-enable-block-subscription`
+func (c *Client) BlockSubscribe(ctx context.Context, filter BlockSubscribeFilter) (*BlockSubscription, error) {
+	return c.blockSubscribe(ctx, []interface{}{filter})
+}
+
+// BlockSubscribeWithConfig subscribes to receive notification anytime a new block is confirmed or finalized.
+// the node needs to enable `--rpc-pubsub-enable-block-subscription`
+func (c *Client) BlockSubscribeWithConfig(ctx context.Context, filter BlockSubscribeFilter, cfg BlockSubscribeConfig) (*BlockSubscription, error) {
+	return c.blockSubscribe(ctx, []interface{}{filter, cfg})
+}
+
+func (c *Client) blockSubscribe(ctx context.Context, params []interface{}) (*BlockSubscription, error) {
+	ch := make(chan BlockNotification, c.notificationBufferSize)
+	sub := newSubscription(c, "blockSubscribe", "blockUnsubscribe", params)
+	sub.notify = func(raw json.RawMessage) (bool, error) {
+		var v BlockNotification
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return false, fmt.Errorf("ws: failed to decode block notification, err: %v", err)
+		}
+		select {
+		case ch <- v:
+		case <-sub.done:
+		case <-c.closed:
+		}
+		return false, nil
+	}
+	sub.closeOut = func() { close(ch) }
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &BlockSubscription{subscription: sub, C: ch}, nil
+}
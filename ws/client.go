@@ -0,0 +1,490 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+const (
+	LocalnetWSEndpoint = "ws://localhost:8900"
+	DevnetWSEndpoint   = "wss://api.devnet.solana.com"
+	TestnetWSEndpoint  = "wss://api.testnet.solana.com"
+	MainnetWSEndpoint  = "wss://api.mainnet-beta.solana.com"
+)
+
+// requestTimeout limits the requests sent by the client itself, e.g. resubscribing
+const requestTimeout = 10 * time.Second
+
+var (
+	ErrClientClosed   = errors.New("ws: client closed")
+	ErrNotConnected   = errors.New("ws: not connected")
+	ErrConnectionLost = errors.New("ws: connection lost")
+)
+
+// Client is a websocket client for the pubsub rpc api. it keeps a single connection,
+// reconnects with backoff when the connection drops and resubscribes all active subscriptions.
+type Client struct {
+	endpoint               string
+	dialer                 *websocket.Dialer
+	header                 http.Header
+	pingInterval           time.Duration
+	reconnectInterval      time.Duration
+	maxReconnectInterval   time.Duration
+	notificationBufferSize int
+
+	mu                sync.Mutex
+	conn              *websocket.Conn
+	nextID            uint64
+	pendingRequests   map[uint64]*pendingRequest
+	subscriptions     map[*subscription]struct{}
+	subscriptionsByID map[uint64]*subscription
+
+	writeMu   sync.Mutex
+	closed    chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+type jsonRpcRequest struct {
+	JsonRpc string        `json:"jsonrpc"`
+	Id      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params,omitempty"`
+}
+
+type jsonRpcMessage struct {
+	JsonRpc string             `json:"jsonrpc"`
+	Id      *uint64            `json:"id,omitempty"`
+	Method  string             `json:"method,omitempty"`
+	Params  json.RawMessage    `json:"params,omitempty"`
+	Result  json.RawMessage    `json:"result,omitempty"`
+	Error   *rpc.ErrorResponse `json:"error,omitempty"`
+}
+
+type notificationParams struct {
+	Result       json.RawMessage `json:"result"`
+	Subscription uint64          `json:"subscription"`
+}
+
+type pendingRequest struct {
+	subscription *subscription
+	ch           chan pendingResponse
+}
+
+type pendingResponse struct {
+	message jsonRpcMessage
+	err     error
+}
+
+// Connect dials the endpoint and starts to serve the connection in background
+func Connect(ctx context.Context, endpoint string, opts ...Option) (*Client, error) {
+	c := &Client{
+		endpoint:          endpoint,
+		pendingRequests:   map[uint64]*pendingRequest{},
+		subscriptions:     map[*subscription]struct{}{},
+		subscriptionsByID: map[uint64]*subscription{},
+		closed:            make(chan struct{}),
+		done:              make(chan struct{}),
+	}
+
+	setDefaultOptions(c)
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.conn = conn
+	c.mu.Unlock()
+	go c.run(conn)
+
+	return c, nil
+}
+
+// Close closes the connection and all subscriptions. it will not reconnect anymore.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.mu.Lock()
+		conn := c.conn
+		c.mu.Unlock()
+		if conn != nil {
+			c.writeMu.Lock()
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			c.writeMu.Unlock()
+			conn.Close()
+		}
+	})
+	<-c.done
+	return nil
+}
+
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Client) dial(ctx context.Context) (*websocket.Conn, error) {
+	conn, _, err := c.dialer.DialContext(ctx, c.endpoint, c.header)
+	if err != nil {
+		return nil, fmt.Errorf("ws: failed to dial %v, err: %v", c.endpoint, err)
+	}
+	return conn, nil
+}
+
+func (c *Client) run(conn *websocket.Conn) {
+	defer close(c.done)
+	defer c.closeAllSubscriptions()
+
+	for {
+		connDone := make(chan struct{})
+		go c.keepalive(conn, connDone)
+		go c.resubscribe()
+
+		c.readLoop(conn)
+
+		close(connDone)
+		conn.Close()
+		c.connectionLost()
+
+		conn = c.reconnect()
+		if conn == nil {
+			return
+		}
+		c.mu.Lock()
+		c.conn = conn
+		c.mu.Unlock()
+	}
+}
+
+// reconnect dials the endpoint with exponential backoff. it returns nil if the client is closed
+func (c *Client) reconnect() *websocket.Conn {
+	interval := c.reconnectInterval
+	for {
+		select {
+		case <-c.closed:
+			return nil
+		case <-time.After(interval):
+		}
+
+		conn, err := c.dial(context.Background())
+		if err == nil {
+			if c.isClosed() {
+				conn.Close()
+				return nil
+			}
+			return conn
+		}
+
+		interval *= 2
+		if interval > c.maxReconnectInterval {
+			interval = c.maxReconnectInterval
+		}
+	}
+}
+
+func (c *Client) keepalive(conn *websocket.Conn, connDone chan struct{}) {
+	if c.pingInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-connDone:
+			return
+		case <-ticker.C:
+			c.writeMu.Lock()
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.pingInterval))
+			c.writeMu.Unlock()
+			if err != nil {
+				// the read loop will notice the broken connection
+				conn.Close()
+				return
+			}
+		}
+	}
+}
+
+func (c *Client) extendReadDeadline(conn *websocket.Conn) {
+	if c.pingInterval <= 0 {
+		return
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * c.pingInterval))
+}
+
+func (c *Client) readLoop(conn *websocket.Conn) {
+	c.extendReadDeadline(conn)
+	conn.SetPongHandler(func(string) error {
+		c.extendReadDeadline(conn)
+		return nil
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.extendReadDeadline(conn)
+
+		var message jsonRpcMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			continue
+		}
+		if message.Id != nil {
+			c.handleResponse(message)
+			continue
+		}
+		if message.Method != "" {
+			c.handleNotification(message)
+		}
+	}
+}
+
+func (c *Client) handleResponse(message jsonRpcMessage) {
+	c.mu.Lock()
+	req, ok := c.pendingRequests[*message.Id]
+	if !ok {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.pendingRequests, *message.Id)
+
+	// register the subscription id before any notification of it is read
+	if req.subscription != nil && message.Error == nil {
+		var subscriptionID uint64
+		if err := json.Unmarshal(message.Result, &subscriptionID); err == nil {
+			if _, active := c.subscriptions[req.subscription]; active {
+				req.subscription.id = subscriptionID
+				req.subscription.subscribed = true
+				req.subscription.established = true
+				c.subscriptionsByID[subscriptionID] = req.subscription
+			} else {
+				// it was unsubscribed before the server confirmed it
+				go c.request(context.Background(), req.subscription.unsubscribeMethod, []interface{}{subscriptionID}, nil)
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	req.ch <- pendingResponse{message: message}
+}
+
+func (c *Client) handleNotification(message jsonRpcMessage) {
+	var params notificationParams
+	if err := json.Unmarshal(message.Params, &params); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	sub, ok := c.subscriptionsByID[params.Subscription]
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if sub.deliver(params.Result) {
+		// the server removes the subscription after its final notification
+		c.removeSubscription(sub)
+		sub.close()
+	}
+}
+
+func (c *Client) connectionLost() {
+	c.mu.Lock()
+	c.conn = nil
+	pendingRequests := c.pendingRequests
+	c.pendingRequests = map[uint64]*pendingRequest{}
+	c.subscriptionsByID = map[uint64]*subscription{}
+	for sub := range c.subscriptions {
+		sub.subscribed = false
+	}
+	c.mu.Unlock()
+
+	for _, req := range pendingRequests {
+		req.ch <- pendingResponse{err: ErrConnectionLost}
+	}
+}
+
+func (c *Client) resubscribe() {
+	c.mu.Lock()
+	subs := make([]*subscription, 0, len(c.subscriptions))
+	for sub := range c.subscriptions {
+		if sub.established && !sub.subscribed {
+			subs = append(subs, sub)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		_, err := c.request(ctx, sub.method, sub.params, sub)
+		cancel()
+		if err == nil {
+			continue
+		}
+		var rpcErr *rpc.JSONRPCError
+		if errors.As(err, &rpcErr) {
+			// the server rejects it, it is pointless to retry
+			sub.fail(fmt.Errorf("ws: failed to resubscribe, err: %w", err))
+			c.removeSubscription(sub)
+			sub.close()
+		}
+		// other errors mean the connection is broken again, it will be retried after reconnecting
+	}
+}
+
+func (c *Client) closeAllSubscriptions() {
+	c.mu.Lock()
+	subs := make([]*subscription, 0, len(c.subscriptions))
+	for sub := range c.subscriptions {
+		subs = append(subs, sub)
+	}
+	c.subscriptions = map[*subscription]struct{}{}
+	c.subscriptionsByID = map[uint64]*subscription{}
+	c.conn = nil
+	pendingRequests := c.pendingRequests
+	c.pendingRequests = map[uint64]*pendingRequest{}
+	c.mu.Unlock()
+
+	for _, req := range pendingRequests {
+		req.ch <- pendingResponse{err: ErrClientClosed}
+	}
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+// request sends a json rpc request and waits for its response
+func (c *Client) request(ctx context.Context, method string, params []interface{}, sub *subscription) (json.RawMessage, error) {
+	if c.isClosed() {
+		return nil, ErrClientClosed
+	}
+
+	c.mu.Lock()
+	conn := c.conn
+	if conn == nil {
+		c.mu.Unlock()
+		return nil, ErrNotConnected
+	}
+	c.nextID++
+	id := c.nextID
+	req := &pendingRequest{
+		subscription: sub,
+		ch:           make(chan pendingResponse, 1),
+	}
+	c.pendingRequests[id] = req
+	c.mu.Unlock()
+
+	j, err := json.Marshal(jsonRpcRequest{
+		JsonRpc: "2.0",
+		Id:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		c.removePendingRequest(id)
+		return nil, fmt.Errorf("ws: failed to prepare payload, err: %v", err)
+	}
+
+	c.writeMu.Lock()
+	err = conn.WriteMessage(websocket.TextMessage, j)
+	c.writeMu.Unlock()
+	if err != nil {
+		c.removePendingRequest(id)
+		return nil, fmt.Errorf("ws: failed to write message, err: %v", err)
+	}
+
+	select {
+	case res := <-req.ch:
+		if res.err != nil {
+			return nil, res.err
+		}
+		if res.message.Error != nil {
+			return nil, rpc.NewJSONRPCError(*res.message.Error)
+		}
+		return res.message.Result, nil
+	case <-ctx.Done():
+		c.removePendingRequest(id)
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Client) removePendingRequest(id uint64) {
+	c.mu.Lock()
+	delete(c.pendingRequests, id)
+	c.mu.Unlock()
+}
+
+// subscribe registers a subscription and sends the subscribe request
+func (c *Client) subscribe(ctx context.Context, sub *subscription) error {
+	c.mu.Lock()
+	c.subscriptions[sub] = struct{}{}
+	c.mu.Unlock()
+
+	if _, err := c.request(ctx, sub.method, sub.params, sub); err != nil {
+		c.removeSubscription(sub)
+		sub.close()
+		return err
+	}
+	return nil
+}
+
+// removeSubscription removes the subscription from the client and returns its server id if it is subscribed
+func (c *Client) removeSubscription(sub *subscription) (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.subscriptions[sub]; !ok {
+		return 0, false
+	}
+	delete(c.subscriptions, sub)
+	if !sub.subscribed {
+		return 0, false
+	}
+	sub.subscribed = false
+	if c.subscriptionsByID[sub.id] == sub {
+		delete(c.subscriptionsByID, sub.id)
+	}
+	return sub.id, true
+}
+
+func (c *Client) unsubscribe(ctx context.Context, sub *subscription) error {
+	id, subscribed := c.removeSubscription(sub)
+	sub.close()
+	if !subscribed {
+		return nil
+	}
+
+	result, err := c.request(ctx, sub.unsubscribeMethod, []interface{}{id}, nil)
+	if err != nil {
+		if errors.Is(err, ErrNotConnected) || errors.Is(err, ErrConnectionLost) || errors.Is(err, ErrClientClosed) {
+			// the subscription is gone with the connection
+			return nil
+		}
+		return err
+	}
+	var ok bool
+	if err := json.Unmarshal(result, &ok); err != nil {
+		return fmt.Errorf("ws: failed to decode unsubscribe result, err: %v", err)
+	}
+	if !ok {
+		return fmt.Errorf("ws: failed to unsubscribe %v", id)
+	}
+	return nil
+}
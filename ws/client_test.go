@@ -0,0 +1,397 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/portto/solana-go-sdk/pkg/pointer"
+	"github.com/portto/solana-go-sdk/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is a local stand-in of the pubsub server. it answers every subscribe request with
+// an increasing subscription id and every unsubscribe request with true.
+type testServer struct {
+	*httptest.Server
+	t *testing.T
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	nextID   uint64
+	requests chan string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{
+		t:        t,
+		nextID:   100,
+		requests: make(chan string, 16),
+	}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(rw, req, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var r struct {
+				Id     uint64 `json:"id"`
+				Method string `json:"method"`
+			}
+			assert.Nil(t, json.Unmarshal(data, &r))
+			s.requests <- string(data)
+
+			var result interface{} = true
+			if strings.HasSuffix(r.Method, "Subscribe") {
+				s.mu.Lock()
+				s.nextID++
+				result = s.nextID
+				s.mu.Unlock()
+			}
+			b, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "result": result, "id": r.Id})
+			s.write(string(b))
+		}
+	}))
+	return s
+}
+
+func (s *testServer) endpoint() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *testServer) write(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Nil(s.t, s.conn.WriteMessage(websocket.TextMessage, []byte(message)))
+}
+
+func (s *testServer) dropConnection() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conn.Close()
+}
+
+func (s *testServer) expectRequest(expected string) {
+	select {
+	case got := <-s.requests:
+		assert.JSONEq(s.t, expected, got)
+	case <-time.After(time.Second):
+		s.t.Fatalf("request not received, want: %v", expected)
+	}
+}
+
+func connectTestServer(t *testing.T, s *testServer) *Client {
+	c, err := Connect(context.Background(), s.endpoint(), WithReconnectInterval(10*time.Millisecond, 50*time.Millisecond))
+	require.Nil(t, err)
+	return c
+}
+
+func TestClient_AccountSubscribe(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Close()
+	c := connectTestServer(t, s)
+	defer c.Close()
+
+	sub, err := c.AccountSubscribeWithConfig(context.Background(), "CM78CPUeXjn8o3yroDHxUtKsZZgoy4GPkPPXfouKNH12", AccountSubscribeConfig{
+		Commitment: rpc.CommitmentFinalized,
+		Encoding:   rpc.GetAccountInfoConfigEncodingBase64,
+	})
+	require.Nil(t, err)
+	s.expectRequest(`{"jsonrpc":"2.0","id":1,"method":"accountSubscribe","params":["CM78CPUeXjn8o3yroDHxUtKsZZgoy4GPkPPXfouKNH12",{"commitment":"finalized","encoding":"base64"}]}`)
+
+	s.write(`{"jsonrpc":"2.0","method":"accountNotification","params":{"result":{"context":{"slot":5199307},"value":{"data":["AAAAAA==","base64"],"executable":false,"lamports":33594,"owner":"11111111111111111111111111111111","rentEpoch":635}},"subscription":101}}`)
+	select {
+	case got := <-sub.C:
+		assert.Equal(t, AccountNotification{
+			Context: rpc.Context{Slot: 5199307},
+			Value: rpc.AccountInfo{
+				Lamports:  33594,
+				Owner:     "11111111111111111111111111111111",
+				RentEpoch: 635,
+				Data:      []interface{}{"AAAAAA==", "base64"},
+			},
+		}, got)
+	case <-time.After(time.Second):
+		t.Fatal("notification not received")
+	}
+
+	require.Nil(t, sub.Unsubscribe(context.Background()))
+	s.expectRequest(`{"jsonrpc":"2.0","id":2,"method":"accountUnsubscribe","params":[101]}`)
+	_, ok := <-sub.C
+	assert.False(t, ok)
+}
+
+func TestClient_SignatureSubscribe(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Close()
+	c := connectTestServer(t, s)
+	defer c.Close()
+
+	sub, err := c.SignatureSubscribeWithConfig(context.Background(), "2EBVM6cB8vAAD93Ktr6Vd8p67XPbQzCJX47MpReuiCXJAtcjaxpvWpcg9Ege1Nr5Tk3a2GFrByT7WPBjdsTycY9b", SignatureSubscribeConfig{
+		Commitment:                 rpc.CommitmentConfirmed,
+		EnableReceivedNotification: true,
+	})
+	require.Nil(t, err)
+	s.expectRequest(`{"jsonrpc":"2.0","id":1,"method":"signatureSubscribe","params":["2EBVM6cB8vAAD93Ktr6Vd8p67XPbQzCJX47MpReuiCXJAtcjaxpvWpcg9Ege1Nr5Tk3a2GFrByT7WPBjdsTycY9b",{"commitment":"confirmed","enableReceivedNotification":true}]}`)
+
+	s.write(`{"jsonrpc":"2.0","method":"signatureNotification","params":{"result":{"context":{"slot":5207623},"value":"receivedSignature"},"subscription":101}}`)
+	s.write(`{"jsonrpc":"2.0","method":"signatureNotification","params":{"result":{"context":{"slot":5207624},"value":{"err":null}},"subscription":101}}`)
+
+	got := []SignatureNotification{}
+	for v := range sub.C {
+		got = append(got, v)
+	}
+	assert.Equal(t, []SignatureNotification{
+		{Context: rpc.Context{Slot: 5207623}, Value: SignatureNotificationValue{Received: true}},
+		{Context: rpc.Context{Slot: 5207624}, Value: SignatureNotificationValue{}},
+	}, got)
+
+	// it is closed by the final notification, nothing to send
+	require.Nil(t, sub.Unsubscribe(context.Background()))
+	select {
+	case r := <-s.requests:
+		t.Fatalf("unexpected request: %v", r)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestClient_Notifications(t *testing.T) {
+	type subscribeFunc func(*Client) (interface{}, error)
+	tests := []struct {
+		name         string
+		subscribe    subscribeFunc
+		requestBody  string
+		notification string
+		want         interface{}
+	}{
+		{
+			name: "programSubscribe",
+			subscribe: func(c *Client) (interface{}, error) {
+				sub, err := c.ProgramSubscribeWithConfig(context.Background(), "11111111111111111111111111111111", ProgramSubscribeConfig{
+					Encoding: rpc.GetProgramAccountsConfigEncodingBase64,
+					Filters:  []rpc.GetProgramAccountsConfigFilter{{DataSize: 80}},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return <-sub.C, nil
+			},
+			requestBody:  `{"jsonrpc":"2.0","id":1,"method":"programSubscribe","params":["11111111111111111111111111111111",{"encoding":"base64","filters":[{"dataSize":80}]}]}`,
+			notification: `{"jsonrpc":"2.0","method":"programNotification","params":{"result":{"context":{"slot":5208469},"value":{"pubkey":"H4vnBqifaSACnKa7acsxstsY1iV1bvJNxsCY7enrd1hq","account":{"data":["","base64"],"executable":false,"lamports":33594,"owner":"11111111111111111111111111111111","rentEpoch":636}}},"subscription":101}}`,
+			want: ProgramNotification{
+				Context: rpc.Context{Slot: 5208469},
+				Value: rpc.GetProgramAccounts{
+					Pubkey: "H4vnBqifaSACnKa7acsxstsY1iV1bvJNxsCY7enrd1hq",
+					Account: rpc.AccountInfo{
+						Lamports:  33594,
+						Owner:     "11111111111111111111111111111111",
+						RentEpoch: 636,
+						Data:      []interface{}{"", "base64"},
+					},
+				},
+			},
+		},
+		{
+			name: "logsSubscribe",
+			subscribe: func(c *Client) (interface{}, error) {
+				sub, err := c.LogsSubscribeWithConfig(context.Background(), LogsSubscribeFilter{Mentions: []string{"11111111111111111111111111111111"}}, LogsSubscribeConfig{Commitment: rpc.CommitmentFinalized})
+				if err != nil {
+					return nil, err
+				}
+				return <-sub.C, nil
+			},
+			requestBody:  `{"jsonrpc":"2.0","id":1,"method":"logsSubscribe","params":[{"mentions":["11111111111111111111111111111111"]},{"commitment":"finalized"}]}`,
+			notification: `{"jsonrpc":"2.0","method":"logsNotification","params":{"result":{"context":{"slot":5208469},"value":{"signature":"5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv","err":null,"logs":["SBF program 83astBRguLMdt2h5U1Tpdq5tjFoJ6noeGwaY3mDLVcri success"]}},"subscription":101}}`,
+			want: LogsNotification{
+				Context: rpc.Context{Slot: 5208469},
+				Value: LogsNotificationValue{
+					Signature: "5h6xBEauJ3PK6SWCZ1PGjBvj8vDdWG3KpwATGy1ARAXFSDwt8GFXM7W5Ncn16wmqokgpiKRLuS83KUxyZyv2sUYv",
+					Logs:      []string{"SBF program 83astBRguLMdt2h5U1Tpdq5tjFoJ6noeGwaY3mDLVcri success"},
+				},
+			},
+		},
+		{
+			name: "logsSubscribe all",
+			subscribe: func(c *Client) (interface{}, error) {
+				sub, err := c.LogsSubscribe(context.Background(), LogsSubscribeFilter{})
+				if err != nil {
+					return nil, err
+				}
+				return <-sub.C, nil
+			},
+			requestBody:  `{"jsonrpc":"2.0","id":1,"method":"logsSubscribe","params":["all"]}`,
+			notification: `{"jsonrpc":"2.0","method":"logsNotification","params":{"result":{"context":{"slot":1},"value":{"signature":"1","err":{"InstructionError":[0,{"Custom":1}]},"logs":[]}},"subscription":101}}`,
+			want: LogsNotification{
+				Context: rpc.Context{Slot: 1},
+				Value: LogsNotificationValue{
+					Signature: "1",
+					Err:       map[string]interface{}{"InstructionError": []interface{}{float64(0), map[string]interface{}{"Custom": float64(1)}}},
+					Logs:      []string{},
+				},
+			},
+		},
+		{
+			name: "slotSubscribe",
+			subscribe: func(c *Client) (interface{}, error) {
+				sub, err := c.SlotSubscribe(context.Background())
+				if err != nil {
+					return nil, err
+				}
+				return <-sub.C, nil
+			},
+			requestBody:  `{"jsonrpc":"2.0","id":1,"method":"slotSubscribe"}`,
+			notification: `{"jsonrpc":"2.0","method":"slotNotification","params":{"result":{"parent":75,"root":44,"slot":76},"subscription":101}}`,
+			want:         SlotNotification{Parent: 75, Root: 44, Slot: 76},
+		},
+		{
+			name: "rootSubscribe",
+			subscribe: func(c *Client) (interface{}, error) {
+				sub, err := c.RootSubscribe(context.Background())
+				if err != nil {
+					return nil, err
+				}
+				return <-sub.C, nil
+			},
+			requestBody:  `{"jsonrpc":"2.0","id":1,"method":"rootSubscribe"}`,
+			notification: `{"jsonrpc":"2.0","method":"rootNotification","params":{"result":42,"subscription":101}}`,
+			want:         uint64(42),
+		},
+		{
+			name: "blockSubscribe",
+			subscribe: func(c *Client) (interface{}, error) {
+				sub, err := c.BlockSubscribeWithConfig(context.Background(), BlockSubscribeFilter{MentionsAccountOrProgram: "LieKvPRE8XeX3Y2xVNHjKlpAScD12lYySBVQ4HqoJ5op"}, BlockSubscribeConfig{
+					Commitment:                     rpc.CommitmentConfirmed,
+					Encoding:                       rpc.GetBlockConfigEncodingBase64,
+					TransactionDetails:             rpc.GetBlockConfigTransactionDetailsSignatures,
+					ShowRewards:                    pointer.Bool(false),
+					MaxSupportedTransactionVersion: pointer.Uint8(0),
+				})
+				if err != nil {
+					return nil, err
+				}
+				return <-sub.C, nil
+			},
+			requestBody:  `{"jsonrpc":"2.0","id":1,"method":"blockSubscribe","params":[{"mentionsAccountOrProgram":"LieKvPRE8XeX3Y2xVNHjKlpAScD12lYySBVQ4HqoJ5op"},{"commitment":"confirmed","encoding":"base64","transactionDetails":"signatures","showRewards":false,"maxSupportedTransactionVersion":0}]}`,
+			notification: `{"jsonrpc":"2.0","method":"blockNotification","params":{"result":{"context":{"slot":112301554},"value":{"slot":112301554,"block":{"previousBlockhash":"GJp125YAN4ufCSUvZJVdCyWQJ7RPWMmwxoyUQySydZA","blockhash":"6ojMHjctdqfB55JDpEpqfHnP96fiaHEcvzEQ2NNcxzHP","parentSlot":112301553,"signatures":["3ZVTvQ4h6tXtpXiYpCxBrb1Pf6u5AJFG5TAbFZ4CAB3Bfv9y4fJc4ywj1ECFsG8xBKdBcFgYrNDyHvd5PVQXeqq6"],"blockTime":1639926816,"blockHeight":101210751},"err":null}},"subscription":101}}`,
+			want: BlockNotification{
+				Context: rpc.Context{Slot: 112301554},
+				Value: BlockNotificationValue{
+					Slot: 112301554,
+					Block: &rpc.GetBlockResponseResult{
+						PreviousBlockhash: "GJp125YAN4ufCSUvZJVdCyWQJ7RPWMmwxoyUQySydZA",
+						Blockhash:         "6ojMHjctdqfB55JDpEpqfHnP96fiaHEcvzEQ2NNcxzHP",
+						ParentSLot:        112301553,
+						Signatures:        []string{"3ZVTvQ4h6tXtpXiYpCxBrb1Pf6u5AJFG5TAbFZ4CAB3Bfv9y4fJc4ywj1ECFsG8xBKdBcFgYrNDyHvd5PVQXeqq6"},
+						BlockTime:         pointer.Int64(1639926816),
+						BlockHeight:       pointer.Int64(101210751),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			defer s.Close()
+			c := connectTestServer(t, s)
+			defer c.Close()
+
+			go func() {
+				s.expectRequest(tt.requestBody)
+				s.write(tt.notification)
+			}()
+
+			got, err := tt.subscribe(c)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_Resubscribe(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Close()
+	c := connectTestServer(t, s)
+	defer c.Close()
+
+	sub, err := c.SlotSubscribe(context.Background())
+	require.Nil(t, err)
+	s.expectRequest(`{"jsonrpc":"2.0","id":1,"method":"slotSubscribe"}`)
+
+	s.dropConnection()
+	s.expectRequest(`{"jsonrpc":"2.0","id":2,"method":"slotSubscribe"}`)
+
+	// wait for the client to receive the new subscription id
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		_, ok := c.subscriptionsByID[102]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	s.write(`{"jsonrpc":"2.0","method":"slotNotification","params":{"result":{"parent":75,"root":44,"slot":76},"subscription":101}}`)
+	s.write(`{"jsonrpc":"2.0","method":"slotNotification","params":{"result":{"parent":76,"root":44,"slot":77},"subscription":102}}`)
+	select {
+	case got := <-sub.C:
+		assert.Equal(t, SlotNotification{Parent: 76, Root: 44, Slot: 77}, got)
+	case <-time.After(time.Second):
+		t.Fatal("notification not received")
+	}
+}
+
+func TestClient_Close(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Close()
+	c := connectTestServer(t, s)
+
+	sub, err := c.RootSubscribe(context.Background())
+	require.Nil(t, err)
+
+	require.Nil(t, c.Close())
+	_, ok := <-sub.C
+	assert.False(t, ok)
+
+	_, err = c.SlotSubscribe(context.Background())
+	assert.Equal(t, ErrClientClosed, err)
+}
+
+func TestClient_SubscribeError(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(rw, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, _, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`)))
+		}
+	}))
+	defer server.Close()
+
+	c, err := Connect(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"))
+	require.Nil(t, err)
+	defer c.Close()
+
+	_, err = c.BlockSubscribe(context.Background(), BlockSubscribeFilter{})
+	assert.Equal(t, &rpc.JSONRPCError{Code: -32601, Message: "Method not found"}, err)
+}
@@ -0,0 +1,93 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+type LogsSubscribeFilterType string
+
+const (
+	// LogsSubscribeFilterAll subscribes to all transactions except for simple vote transactions
+	LogsSubscribeFilterAll LogsSubscribeFilterType = "all"
+	// LogsSubscribeFilterAllWithVotes subscribes to all transactions including simple vote transactions
+	LogsSubscribeFilterAllWithVotes LogsSubscribeFilterType = "allWithVotes"
+)
+
+// LogsSubscribeFilter set either Type or Mentions. it subscribes to all transactions if nothing is set.
+type LogsSubscribeFilter struct {
+	Type LogsSubscribeFilterType
+	// Mentions subscribes to transactions mentioning the pubkeys, the node only supports one pubkey now
+	Mentions []string
+}
+
+func (f LogsSubscribeFilter) MarshalJSON() ([]byte, error) {
+	if len(f.Mentions) > 0 {
+		return json.Marshal(struct {
+			Mentions []string `json:"mentions"`
+		}{
+			Mentions: f.Mentions,
+		})
+	}
+	if f.Type == "" {
+		return json.Marshal(LogsSubscribeFilterAll)
+	}
+	return json.Marshal(f.Type)
+}
+
+// LogsSubscribeConfig is an option config for `logsSubscribe`
+type LogsSubscribeConfig struct {
+	Commitment rpc.Commitment `json:"commitment,omitempty"`
+}
+
+// LogsNotification is the result of `logsNotification`
+type LogsNotification struct {
+	Context rpc.Context           `json:"context"`
+	Value   LogsNotificationValue `json:"value"`
+}
+
+type LogsNotificationValue struct {
+	Signature string      `json:"signature"`
+	Err       interface{} `json:"err"`
+	Logs      []string    `json:"logs"`
+}
+
+type LogsSubscription struct {
+	*subscription
+	C <-chan LogsNotification
+}
+
+// LogsSubscribe subscribes to transaction logging
+func (c *Client) LogsSubscribe(ctx context.Context, filter LogsSubscribeFilter) (*LogsSubscription, error) {
+	return c.logsSubscribe(ctx, []interface{}{filter})
+}
+
+// LogsSubscribeWithConfig subscribes to transaction logging
+func (c *Client) LogsSubscribeWithConfig(ctx context.Context, filter LogsSubscribeFilter, cfg LogsSubscribeConfig) (*LogsSubscription, error) {
+	return c.logsSubscribe(ctx, []interface{}{filter, cfg})
+}
+
+func (c *Client) logsSubscribe(ctx context.Context, params []interface{}) (*LogsSubscription, error) {
+	ch := make(chan LogsNotification, c.notificationBufferSize)
+	sub := newSubscription(c, "logsSubscribe", "logsUnsubscribe", params)
+	sub.notify = func(raw json.RawMessage) (bool, error) {
+		var v LogsNotification
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return false, fmt.Errorf("ws: failed to decode logs notification, err: %v", err)
+		}
+		select {
+		case ch <- v:
+		case <-sub.done:
+		case <-c.closed:
+		}
+		return false, nil
+	}
+	sub.closeOut = func() { close(ch) }
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &LogsSubscription{subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,60 @@
+package ws
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Option is a configuration type for the Client
+type Option func(*Client)
+
+// WithDialer is an Option that allows you provide your own websocket dialer
+func WithDialer(d *websocket.Dialer) Option {
+	return func(c *Client) {
+		c.dialer = d
+	}
+}
+
+// WithHTTPHeader is an Option that sets extra headers of the websocket handshake, e.g. an api key
+func WithHTTPHeader(h http.Header) Option {
+	return func(c *Client) {
+		c.header = h
+	}
+}
+
+// WithPingInterval is an Option that configures how often a ping is sent.
+// the connection is considered broken if nothing is read in two intervals. zero disables keepalive.
+func WithPingInterval(d time.Duration) Option {
+	return func(c *Client) {
+		c.pingInterval = d
+	}
+}
+
+// WithReconnectInterval is an Option that configures the backoff of reconnecting.
+// the interval doubles after each failed attempt until it reaches max.
+func WithReconnectInterval(initial, max time.Duration) Option {
+	return func(c *Client) {
+		c.reconnectInterval = initial
+		c.maxReconnectInterval = max
+	}
+}
+
+// WithNotificationBufferSize is an Option that configures the buffer size of each notification channel
+func WithNotificationBufferSize(n int) Option {
+	return func(c *Client) {
+		c.notificationBufferSize = n
+	}
+}
+
+func setDefaultOptions(c *Client) {
+	c.dialer = &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 10 * time.Second,
+	}
+	c.pingInterval = 30 * time.Second
+	c.reconnectInterval = 500 * time.Millisecond
+	c.maxReconnectInterval = 30 * time.Second
+	c.notificationBufferSize = 64
+}
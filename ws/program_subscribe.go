@@ -0,0 +1,59 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// ProgramSubscribeConfig is an option config for `programSubscribe`
+type ProgramSubscribeConfig struct {
+	Commitment rpc.Commitment                       `json:"commitment,omitempty"`
+	Encoding   rpc.GetProgramAccountsConfigEncoding `json:"encoding,omitempty"`
+	Filters    []rpc.GetProgramAccountsConfigFilter `json:"filters,omitempty"`
+}
+
+// ProgramNotification is the result of `programNotification`
+type ProgramNotification struct {
+	Context rpc.Context            `json:"context"`
+	Value   rpc.GetProgramAccounts `json:"value"`
+}
+
+type ProgramSubscription struct {
+	*subscription
+	C <-chan ProgramNotification
+}
+
+// ProgramSubscribe subscribes to a program to receive notifications when the lamports or data of an account owned by it change
+func (c *Client) ProgramSubscribe(ctx context.Context, programId string) (*ProgramSubscription, error) {
+	return c.programSubscribe(ctx, []interface{}{programId})
+}
+
+// ProgramSubscribeWithConfig subscribes to a program to receive notifications when the lamports or data of an account owned by it change
+func (c *Client) ProgramSubscribeWithConfig(ctx context.Context, programId string, cfg ProgramSubscribeConfig) (*ProgramSubscription, error) {
+	return c.programSubscribe(ctx, []interface{}{programId, cfg})
+}
+
+func (c *Client) programSubscribe(ctx context.Context, params []interface{}) (*ProgramSubscription, error) {
+	ch := make(chan ProgramNotification, c.notificationBufferSize)
+	sub := newSubscription(c, "programSubscribe", "programUnsubscribe", params)
+	sub.notify = func(raw json.RawMessage) (bool, error) {
+		var v ProgramNotification
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return false, fmt.Errorf("ws: failed to decode program notification, err: %v", err)
+		}
+		select {
+		case ch <- v:
+		case <-sub.done:
+		case <-c.closed:
+		}
+		return false, nil
+	}
+	sub.closeOut = func() { close(ch) }
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &ProgramSubscription{subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,36 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RootSubscription receives the latest root slot
+type RootSubscription struct {
+	*subscription
+	C <-chan uint64
+}
+
+// RootSubscribe subscribes to receive a notification anytime a new root is set by the validator
+func (c *Client) RootSubscribe(ctx context.Context) (*RootSubscription, error) {
+	ch := make(chan uint64, c.notificationBufferSize)
+	sub := newSubscription(c, "rootSubscribe", "rootUnsubscribe", nil)
+	sub.notify = func(raw json.RawMessage) (bool, error) {
+		var v uint64
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return false, fmt.Errorf("ws: failed to decode root notification, err: %v", err)
+		}
+		select {
+		case ch <- v:
+		case <-sub.done:
+		case <-c.closed:
+		}
+		return false, nil
+	}
+	sub.closeOut = func() { close(ch) }
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &RootSubscription{subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,85 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/rpc"
+)
+
+// SignatureSubscribeConfig is an option config for `signatureSubscribe`
+type SignatureSubscribeConfig struct {
+	Commitment                 rpc.Commitment `json:"commitment,omitempty"`
+	EnableReceivedNotification bool           `json:"enableReceivedNotification,omitempty"`
+}
+
+// SignatureNotification is the result of `signatureNotification`
+type SignatureNotification struct {
+	Context rpc.Context                `json:"context"`
+	Value   SignatureNotificationValue `json:"value"`
+}
+
+type SignatureNotificationValue struct {
+	// Received is true if the signature is received by the node but not processed yet.
+	// it only happens if EnableReceivedNotification is set.
+	Received bool
+	Err      interface{}
+}
+
+func (v *SignatureNotificationValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if s != "receivedSignature" {
+			return fmt.Errorf("unknown signature notification: %v", s)
+		}
+		*v = SignatureNotificationValue{Received: true}
+		return nil
+	}
+	var processed struct {
+		Err interface{} `json:"err"`
+	}
+	if err := json.Unmarshal(data, &processed); err != nil {
+		return err
+	}
+	*v = SignatureNotificationValue{Err: processed.Err}
+	return nil
+}
+
+// SignatureSubscription is closed automatically after the signature reaches the commitment
+type SignatureSubscription struct {
+	*subscription
+	C <-chan SignatureNotification
+}
+
+// SignatureSubscribe subscribes to a transaction signature to receive a notification when it is confirmed
+func (c *Client) SignatureSubscribe(ctx context.Context, signature string) (*SignatureSubscription, error) {
+	return c.signatureSubscribe(ctx, []interface{}{signature})
+}
+
+// SignatureSubscribeWithConfig subscribes to a transaction signature to receive a notification when it is confirmed
+func (c *Client) SignatureSubscribeWithConfig(ctx context.Context, signature string, cfg SignatureSubscribeConfig) (*SignatureSubscription, error) {
+	return c.signatureSubscribe(ctx, []interface{}{signature, cfg})
+}
+
+func (c *Client) signatureSubscribe(ctx context.Context, params []interface{}) (*SignatureSubscription, error) {
+	ch := make(chan SignatureNotification, c.notificationBufferSize)
+	sub := newSubscription(c, "signatureSubscribe", "signatureUnsubscribe", params)
+	sub.notify = func(raw json.RawMessage) (bool, error) {
+		var v SignatureNotification
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return false, fmt.Errorf("ws: failed to decode signature notification, err: %v", err)
+		}
+		select {
+		case ch <- v:
+		case <-sub.done:
+		case <-c.closed:
+		}
+		return !v.Value.Received, nil
+	}
+	sub.closeOut = func() { close(ch) }
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &SignatureSubscription{subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SlotNotification is the result of `slotNotification`
+type SlotNotification struct {
+	Parent uint64 `json:"parent"`
+	Root   uint64 `json:"root"`
+	Slot   uint64 `json:"slot"`
+}
+
+type SlotSubscription struct {
+	*subscription
+	C <-chan SlotNotification
+}
+
+// SlotSubscribe subscribes to receive a notification anytime a slot is processed by the validator
+func (c *Client) SlotSubscribe(ctx context.Context) (*SlotSubscription, error) {
+	ch := make(chan SlotNotification, c.notificationBufferSize)
+	sub := newSubscription(c, "slotSubscribe", "slotUnsubscribe", nil)
+	sub.notify = func(raw json.RawMessage) (bool, error) {
+		var v SlotNotification
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return false, fmt.Errorf("ws: failed to decode slot notification, err: %v", err)
+		}
+		select {
+		case ch <- v:
+		case <-sub.done:
+		case <-c.closed:
+		}
+		return false, nil
+	}
+	sub.closeOut = func() { close(ch) }
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &SlotSubscription{subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,97 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// subscription is the untyped part shared by all subscriptions
+type subscription struct {
+	client            *Client
+	method            string
+	unsubscribeMethod string
+	params            []interface{}
+
+	// id, subscribed and established are guarded by client.mu. id changes after resubscribing.
+	// established means the server has accepted it once, only those are resubscribed after reconnecting.
+	id          uint64
+	subscribed  bool
+	established bool
+
+	// notify decodes and sends a notification, it reports whether it is the final one
+	notify   func(raw json.RawMessage) (bool, error)
+	closeOut func()
+
+	mu        sync.Mutex
+	closed    bool
+	done      chan struct{}
+	errs      chan error
+	closeOnce sync.Once
+}
+
+func newSubscription(c *Client, method, unsubscribeMethod string, params []interface{}) *subscription {
+	return &subscription{
+		client:            c,
+		method:            method,
+		unsubscribeMethod: unsubscribeMethod,
+		params:            params,
+		done:              make(chan struct{}),
+		errs:              make(chan error, 1),
+	}
+}
+
+// Err returns a channel which receives errors of the subscription, e.g. a notification failed to decode.
+// it is closed with the notification channel.
+func (s *subscription) Err() <-chan error {
+	return s.errs
+}
+
+// Unsubscribe cancels the subscription and closes its channels
+func (s *subscription) Unsubscribe(ctx context.Context) error {
+	return s.client.unsubscribe(ctx, s)
+}
+
+// deliver passes a notification to the subscriber, it blocks until the subscriber receives it or the subscription closes
+func (s *subscription) deliver(raw json.RawMessage) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	final, err := s.notify(raw)
+	if err != nil {
+		s.sendErr(err)
+		return false
+	}
+	return final
+}
+
+func (s *subscription) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.sendErr(err)
+}
+
+func (s *subscription) sendErr(err error) {
+	select {
+	case s.errs <- err:
+	default:
+	}
+}
+
+func (s *subscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		s.closed = true
+		if s.closeOut != nil {
+			s.closeOut()
+		}
+		close(s.errs)
+		s.mu.Unlock()
+	})
+}